@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"time"
 
 	_ "net/http/pprof"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 	"github.com/gsmcwhirter/go-util/v5/pprofsidecar"
 	"golang.org/x/sync/errgroup"
@@ -15,6 +17,8 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v12/bot"
 )
 
+var errAdminNotLoopback = errors.New("admin server must listen on a loopback address")
+
 type config struct {
 	BotName             string  `mapstructure:"bot_name"`
 	BotPresence         string  `mapstructure:"bot_presence"`
@@ -36,6 +40,28 @@ type config struct {
 	TraceProbability    float64 `mapstructure:"trace_probability"`
 	PrometheusNamespace string  `mapstructure:"prometheus_namespace"`
 	PrometheusHostPort  string  `mapstructure:"prometheus_hostport"`
+	AdminHostPort       string  `mapstructure:"admin_hostport"`
+	APIHostPort         string  `mapstructure:"api_hostport"`
+
+	BackupDir      string        `mapstructure:"backup_dir"`
+	BackupInterval time.Duration `mapstructure:"backup_interval"`
+	BackupRetain   int           `mapstructure:"backup_retain"`
+	BackupTimeout  time.Duration `mapstructure:"backup_timeout"`
+
+	CleanupInterval   time.Duration `mapstructure:"cleanup_interval"`
+	CleanupClosedDays int           `mapstructure:"cleanup_closed_days"`
+	CleanupEmptyDays  int           `mapstructure:"cleanup_empty_days"`
+	CleanupCorrupt    bool          `mapstructure:"cleanup_corrupt"`
+	CleanupDryRun     bool          `mapstructure:"cleanup_dry_run"`
+
+	ReminderInterval time.Duration `mapstructure:"reminder_interval"`
+	ReminderLead     time.Duration `mapstructure:"reminder_lead"`
+
+	WebhookAttempts       int           `mapstructure:"webhook_attempts"`
+	WebhookBackoff        time.Duration `mapstructure:"webhook_backoff"`
+	WebhookDeadLetterFile string        `mapstructure:"webhook_dead_letter_file"`
+
+	CalendarURL string `mapstructure:"calendar_url"`
 }
 
 func start(c config) error {
@@ -76,10 +102,18 @@ func start(c config) error {
 	defer deferutil.CheckDefer(b.Disconnect)
 
 	deps.MessageHandler().ConnectToBot(b)
+	deps.interactions.ConnectToBot(b)
+	deps.apiServer.ConnectToBot(b)
+	deps.notifier.ConnectToBot(b)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// the !-commands keep working without slash commands, so this is not fatal
+	if err = deps.interactions.RegisterCommands(ctx); err != nil {
+		level.Error(deps.Logger()).Err("could not register slash commands", err)
+	}
+
 	mux := http.NewServeMux()
 	if deps.promHandler != nil {
 		mux.Handle("/metrics", deps.promHandler)
@@ -92,19 +126,77 @@ func start(c config) error {
 		Handler:      mux,
 	}
 
-	err = pprofsidecar.Run(ctx, c.PProfHostPort, nil, runAll(deps, b, prom))
+	srvs := []*http.Server{prom}
+
+	// the API and calendar feeds are meant to be reachable from outside, unlike the metrics server
+	if c.APIHostPort != "" {
+		apiMux := http.NewServeMux()
+		apiMux.Handle("/guilds/", deps.apiServer)
+		apiMux.Handle("/calendar/", deps.calServer)
+
+		srvs = append(srvs, &http.Server{
+			Addr:         c.APIHostPort,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 5 * time.Second,
+			Handler:      apiMux,
+		})
+	}
+
+	// the admin routes are unauthenticated, so they only listen on a loopback address
+	if deps.backuper != nil {
+		if !isLoopbackHostPort(c.AdminHostPort) {
+			return errors.Wrap(errAdminNotLoopback, "bad admin_hostport", "admin_hostport", c.AdminHostPort)
+		}
+
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/admin/backup", http.TimeoutHandler(deps.backuper, c.BackupTimeout, "backup timed out\n"))
+
+		srvs = append(srvs, &http.Server{
+			Addr:         c.AdminHostPort,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: c.BackupTimeout + 5*time.Second,
+			Handler:      adminMux,
+		})
+	}
+
+	err = pprofsidecar.Run(ctx, c.PProfHostPort, nil, runAll(deps, b, srvs...))
 
 	level.Error(deps.Logger()).Err("error in start; quitting", err)
 	return err
 }
 
-func runAll(deps *dependencies, b bot.DiscordBot, srv *http.Server) func(context.Context) error {
+func isLoopbackHostPort(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func runAll(deps *dependencies, b bot.DiscordBot, srvs ...*http.Server) func(context.Context) error {
 	return func(ctx context.Context) error {
 		g, ctx := errgroup.WithContext(ctx)
 
 		g.Go(func() error { return b.Run(ctx) })
-		g.Go(serverStartFunc(deps, srv))
-		g.Go(serverShutdownFunc(ctx, deps, srv))
+		if deps.backuper != nil {
+			g.Go(func() error { return deps.backuper.Run(ctx) })
+		}
+		if deps.cleaner != nil {
+			g.Go(func() error { return deps.cleaner.Run(ctx) })
+		}
+		g.Go(func() error { return deps.webhooks.Run(ctx) })
+		g.Go(func() error { return deps.notifier.Run(ctx) })
+		g.Go(func() error { return deps.reminder.Run(ctx) })
+		for _, srv := range srvs {
+			g.Go(serverStartFunc(deps, srv))
+			g.Go(serverShutdownFunc(ctx, deps, srv))
+		}
 
 		return g.Wait()
 	}
@@ -26,11 +26,34 @@ func setup(start func(config) error) *cli.Command {
 	c.Flags().String("log_level", "", "The minimum log level to show")
 	c.Flags().Int("num_workers", 0, "The number of worker goroutines to run")
 	c.Flags().String("pprof_hostport", "", "The host and port for the pprof http server to listen on")
+	c.Flags().String("backup_dir", "", "The directory to write database backups to (disabled if empty)")
+	c.Flags().Duration("backup_interval", 0, "The time between scheduled database backups")
+	c.Flags().Int("backup_retain", 0, "The number of database backups to keep")
+	c.Flags().Duration("backup_timeout", 0, "The longest an /admin/backup request may run (default 10m)")
+	c.Flags().String("admin_hostport", "", "The loopback host and port for the /admin/backup http server to listen on")
+	c.Flags().Duration("cleanup_interval", 0, "The time between scheduled event cleanups (disabled if 0)")
+	c.Flags().Int("cleanup_closed_days", 0, "Scheduled cleanup deletes events closed for more than this many days (0 to disable)")
+	c.Flags().Int("cleanup_empty_days", 0, "Scheduled cleanup deletes events without signups older than this many days (0 to disable)")
+	c.Flags().Bool("cleanup_corrupt", false, "Scheduled cleanup deletes records that cannot be decoded")
+	c.Flags().Bool("cleanup_dry_run", false, "Scheduled cleanup only logs what it would delete")
+	c.Flags().Duration("reminder_interval", 0, "The time between checks for event reminders and confirmation deadlines to send direct messages about (disabled if 0)")
+	c.Flags().Duration("reminder_lead", 0, "How long before the start of an event its main group is reminded")
+	c.Flags().Int("webhook_attempts", 5, "The number of tries for each webhook delivery before giving up")
+	c.Flags().Duration("webhook_backoff", 0, "The wait before the first webhook retry, doubling after each one (default 1s)")
+	c.Flags().String("webhook_dead_letter_file", "", "The file to append failed webhook deliveries to as json lines (only logged if empty)")
+	c.Flags().String("api_hostport", "", "The host and port for the public events API and calendar feed http server to listen on (disabled if empty)")
+	c.Flags().String("calendar_url", "", "The public base url of the api server (api_hostport), used to build calendar feed links (!calendar only attaches files if empty)")
 
 	c.SetRunFunc(func(cmd *cli.Command, args []string) (err error) {
 		v := viper.New()
 
 		v.SetDefault("pprof_hostport", "127.0.0.1:6060")
+		v.SetDefault("backup_interval", "24h")
+		v.SetDefault("backup_retain", 7)
+		v.SetDefault("backup_timeout", "10m")
+		v.SetDefault("admin_hostport", "127.0.0.1:6061")
+		v.SetDefault("reminder_interval", "5m")
+		v.SetDefault("reminder_lead", "1h")
 
 		if configFile != "" {
 			v.SetConfigFile(configFile)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 
 	bolt "github.com/coreos/bbolt"
@@ -19,19 +21,32 @@ import (
 	census "github.com/gsmcwhirter/go-util/v5/stats"
 	"golang.org/x/time/rate"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/backup"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/bugsnag"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/cleanup"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/httpapi"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/interactions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/reminders"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/stats"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 )
 
 type dependencies struct {
 	logger log.Logger
 
-	db       *bolt.DB
-	trialAPI storage.TrialAPI
-	guildAPI storage.GuildAPI
+	db         *bolt.DB
+	trialAPI   storage.TrialAPI
+	guildAPI   storage.GuildAPI
+	profileAPI storage.ProfileAPI
+	backuper   backup.Backuper
+	cleaner    cleanup.Cleaner
+	webhooks   webhook.Dispatcher
+	notifier   notify.Notifier
+	reminder   reminders.Reminder
 
 	httpDoer   httpclient.Doer
 	httpClient httpclient.HTTPClient
@@ -48,10 +63,13 @@ type dependencies struct {
 	debugHandler      *cmdhandler.CommandHandler
 	discordMsgHandler bot.DiscordMessageHandler
 	msgHandlers       msghandler.Handlers
+	interactions      interactions.Handler
 
 	rep         bugsnag.Reporter
 	census      *census.Census
 	promHandler http.Handler
+	apiServer   httpapi.Server
+	calServer   httpapi.CalendarServer
 }
 
 func createDependencies(conf config) (*dependencies, error) {
@@ -108,11 +126,57 @@ func createDependencies(conf config) (*dependencies, error) {
 	if err != nil {
 		return d, err
 	}
+	d.trialAPI = storage.TrialAPIWithChannelIDs(d.trialAPI, msghandler.ChannelLookup(d.botSession))
 
 	d.guildAPI, err = storage.NewBoltGuildAPI(context.Background(), d.db, d.census)
 	if err != nil {
 		return d, err
 	}
+	d.guildAPI = storage.GuildAPIWithChannelIDs(d.guildAPI, msghandler.ChannelLookup(d.botSession))
+
+	d.profileAPI, err = storage.NewBoltProfileAPI(context.Background(), d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	if conf.BackupDir != "" {
+		d.backuper, err = backup.NewBackuper(d, backup.Options{
+			Directory: conf.BackupDir,
+			Prefix:    strings.TrimSuffix(filepath.Base(conf.Database), filepath.Ext(conf.Database)),
+			Interval:  conf.BackupInterval,
+			Retain:    conf.BackupRetain,
+		})
+		if err != nil {
+			return d, err
+		}
+	}
+
+	if conf.CleanupInterval > 0 {
+		d.cleaner = cleanup.NewCleaner(d, cleanup.Options{
+			Rules: cleanup.Rules{
+				ClosedDays: conf.CleanupClosedDays,
+				EmptyDays:  conf.CleanupEmptyDays,
+				Corrupt:    conf.CleanupCorrupt,
+			},
+			DryRun:   conf.CleanupDryRun,
+			Interval: conf.CleanupInterval,
+		})
+	}
+
+	d.webhooks = webhook.NewDispatcher(d, webhook.Options{
+		Attempts:       conf.WebhookAttempts,
+		Backoff:        conf.WebhookBackoff,
+		DeadLetterFile: conf.WebhookDeadLetterFile,
+	})
+
+	d.notifier = notify.NewNotifier(d, notify.Options{
+		Color: 0xaa63ff,
+	})
+
+	d.reminder = reminders.NewReminder(d, reminders.Options{
+		Interval: conf.ReminderInterval,
+		Lead:     conf.ReminderLead,
+	})
 
 	d.httpClient = httpclient.NewHTTPClient(d)
 	h := http.Header{}
@@ -122,7 +186,7 @@ func createDependencies(conf config) (*dependencies, error) {
 
 	d.wsClient = wsclient.NewWSClient(d, wsclient.Options{MaxConcurrentHandlers: conf.NumWorkers})
 
-	d.cmdHandler, err = commands.CommandHandler(d, conf.Version, commands.Options{CmdIndicator: "!"})
+	d.cmdHandler, err = commands.CommandHandler(d, conf.Version, commands.Options{CmdIndicator: "!", CalendarURL: conf.CalendarURL})
 	if err != nil {
 		return d, err
 	}
@@ -147,6 +211,16 @@ func createDependencies(conf config) (*dependencies, error) {
 		SuccessColor:            0xaa63ff,
 	})
 
+	d.interactions = interactions.NewHandler(d, interactions.Options{
+		ErrorColor:   0xff0000,
+		SuccessColor: 0xaa63ff,
+	})
+
+	d.apiServer = httpapi.NewServer(d, httpapi.Options{
+		SuccessColor: 0xaa63ff,
+	})
+	d.calServer = httpapi.NewCalendarServer(d)
+
 	return d, nil
 }
 
@@ -161,8 +235,10 @@ func (d *dependencies) Close() {
 }
 
 func (d *dependencies) Logger() log.Logger                         { return d.logger }
+func (d *dependencies) DB() *bolt.DB                               { return d.db }
 func (d *dependencies) GuildAPI() storage.GuildAPI                 { return d.guildAPI }
 func (d *dependencies) TrialAPI() storage.TrialAPI                 { return d.trialAPI }
+func (d *dependencies) ProfileAPI() storage.ProfileAPI             { return d.profileAPI }
 func (d *dependencies) HTTPDoer() httpclient.Doer                  { return d.httpDoer }
 func (d *dependencies) HTTPClient() httpclient.HTTPClient          { return d.httpClient }
 func (d *dependencies) WSDialer() wsclient.Dialer                  { return d.wsDialer }
@@ -176,6 +252,8 @@ func (d *dependencies) AdminHandler() *cmdhandler.CommandHandler   { return d.ad
 func (d *dependencies) DebugHandler() *cmdhandler.CommandHandler   { return d.debugHandler }
 func (d *dependencies) MessageHandler() msghandler.Handlers        { return d.msgHandlers }
 func (d *dependencies) ErrReporter() errreport.Reporter            { return d.rep }
+func (d *dependencies) Webhooks() webhook.Dispatcher               { return d.webhooks }
+func (d *dependencies) Notifier() notify.Notifier                  { return d.notifier }
 func (d *dependencies) Census() *census.Census                     { return d.census }
 func (d *dependencies) DiscordMessageHandler() bot.DiscordMessageHandler {
 	return d.discordMsgHandler
@@ -5,8 +5,9 @@ import (
 	"fmt"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
-	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/cleanup"
 )
 
 type config struct {
@@ -14,7 +15,12 @@ type config struct {
 	// User      string `mapstructure:"user"`
 	Guild string `mapstructure:"guild"`
 	// Channel   string `mapstructure:"channel"`
-	AllGuilds bool `mapstructure:"all_guilds"`
+	AllGuilds     bool `mapstructure:"all_guilds"`
+	DryRun        bool `mapstructure:"dry_run"`
+	MaxNameLength int  `mapstructure:"max_name_length"`
+	ClosedDays    int  `mapstructure:"closed_days"`
+	EmptyDays     int  `mapstructure:"empty_days"`
+	Corrupt       bool `mapstructure:"corrupt"`
 }
 
 func start(c config) error {
@@ -26,45 +32,40 @@ func start(c config) error {
 	}
 	defer deps.Close()
 
-	gid, err := snowflake.FromString(c.Guild)
-	if err != nil {
-		return errors.Wrap(err, "could not parse guild id")
-	}
+	cl := cleanup.NewCleaner(deps, cleanup.Options{
+		Rules: cleanup.Rules{
+			MaxNameLength: c.MaxNameLength,
+			ClosedDays:    c.ClosedDays,
+			EmptyDays:     c.EmptyDays,
+			Corrupt:       c.Corrupt,
+		},
+		DryRun: c.DryRun,
+	})
 
-	if err := cleanupGuildTrials(deps, gid, 200); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func cleanupGuildTrials(deps *dependencies, gid snowflake.Snowflake, maxlen int) error {
 	ctx := context.Background()
 
-	tx, err := deps.TrialAPI().NewTransaction(ctx, gid.ToString(), true)
-	if err != nil {
-		return errors.Wrap(err, "could not get trials transaction")
-	}
-	defer deferutil.CheckDefer(func() error { return tx.Rollback(ctx) })
-
-	ct := 0
-	trials := tx.GetTrials(ctx)
-	for _, t := range trials {
-		tName := t.GetName(ctx)
-		if len(tName) > maxlen {
-			fmt.Printf("Deleting `%s`\n", tName)
-			if err := tx.DeleteTrial(ctx, tName); err != nil {
-				return errors.Wrap(err, "could not delete trial")
-			}
-			ct++
+	var actions []cleanup.Action
+	if c.AllGuilds {
+		actions, err = cl.CleanAll(ctx)
+	} else {
+		var gid snowflake.Snowflake
+		gid, err = snowflake.FromString(c.Guild)
+		if err != nil {
+			return errors.Wrap(err, "could not parse guild id")
 		}
+
+		actions, err = cl.CleanGuild(ctx, gid.ToString())
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return errors.Wrap(err, "could not commit deletes")
+	verb := "Deleted"
+	if c.DryRun {
+		verb = "Would delete"
 	}
 
-	fmt.Printf("Deleted %d trials\n", ct)
+	for _, a := range actions {
+		fmt.Printf("%s %s\n", verb, a)
+	}
+	fmt.Printf("%s %d records\n", verb, len(actions))
 
-	return nil
+	return err
 }
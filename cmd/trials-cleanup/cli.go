@@ -20,7 +20,12 @@ func setup(start func(config) error) *cli.Command {
 	c.Flags().String("guild", "0", "The discord guild id to impersonate")
 	// c.Flags().String("channel", "0", "The discord channel id to impersonate")
 	c.Flags().String("database", "", "The database file")
-	c.Flags().Bool("all_guilds", false, "Dump a list of guilds")
+	c.Flags().Bool("all_guilds", false, "Clean up every guild in the database")
+	c.Flags().Bool("dry_run", false, "Report what would be deleted without deleting anything")
+	c.Flags().Int("max_name_length", 200, "Delete events with names longer than this (0 to disable)")
+	c.Flags().Int("closed_days", 0, "Delete events that have been closed for more than this many days (0 to disable)")
+	c.Flags().Int("empty_days", 0, "Delete events without signups created more than this many days ago (0 to disable)")
+	c.Flags().Bool("corrupt", false, "Delete records that cannot be decoded")
 
 	c.SetRunFunc(func(cmd *cli.Command, args []string) (err error) {
 		v := viper.New()
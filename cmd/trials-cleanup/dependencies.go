@@ -67,6 +67,10 @@ func (d *dependencies) GuildAPI() storage.GuildAPI {
 	return d.guildAPI
 }
 
+func (d *dependencies) Census() *census.Census {
+	return d.census
+}
+
 // func (d *dependencies) BotSession() *etfapi.Session {
 // 	return d.botSession
 // }
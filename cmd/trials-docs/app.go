@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+)
+
+// the command reference is written between these lines, which must each be on a line of their own
+const (
+	beginMarker = "<!-- begin commands -->\n"
+	endMarker   = "<!-- end commands -->\n"
+)
+
+type config struct {
+	Readme string `mapstructure:"readme"`
+}
+
+func start(c config) error {
+	contents, err := ioutil.ReadFile(c.Readme)
+	if err != nil {
+		return errors.Wrap(err, "could not read the readme", "file", c.Readme)
+	}
+
+	begin := bytes.Index(contents, []byte(beginMarker))
+	end := bytes.Index(contents, []byte(endMarker))
+	if begin < 0 || end < begin {
+		return errors.New("could not find the command reference markers")
+	}
+
+	var b bytes.Buffer
+	b.Write(contents[:begin+len(beginMarker)])
+	b.WriteString("\n")
+	b.WriteString(commands.Reference())
+	b.WriteString("\n")
+	b.Write(contents[end:])
+
+	return errors.Wrap(ioutil.WriteFile(c.Readme, b.Bytes(), 0644), "could not write the readme", "file", c.Readme)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/gsmcwhirter/go-util/v5/cli"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+func setup(start func(config) error) *cli.Command {
+	c := cli.NewCLI(AppName, BuildVersion, BuildSHA, BuildDate, cli.CommandOptions{
+		ShortHelp: "Write the command reference into the README",
+		Args:      cli.NoArgs,
+	})
+
+	c.Flags().String("readme", "./README.md", "The file with the command reference markers to write between")
+
+	c.SetRunFunc(func(cmd *cli.Command, args []string) (err error) {
+		v := viper.New()
+
+		err = v.BindPFlags(cmd.Flags())
+		if err != nil {
+			return errors.Wrap(err, "could not bind flags to viper")
+		}
+
+		conf := config{}
+		err = v.Unmarshal(&conf)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal config into struct")
+		}
+
+		return start(conf)
+	})
+
+	return c
+}
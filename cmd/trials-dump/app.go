@@ -3,11 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 )
 
 type config struct {
@@ -15,11 +19,59 @@ type config struct {
 	// User      string `mapstructure:"user"`
 	Guild string `mapstructure:"guild"`
 	// Channel   string `mapstructure:"channel"`
-	AllGuilds bool `mapstructure:"all_guilds"`
+	AllGuilds       bool   `mapstructure:"all_guilds"`
+	Format          string `mapstructure:"format"`
+	State           string `mapstructure:"state"`
+	Name            string `mapstructure:"name"`
+	IncludeCanceled bool   `mapstructure:"include_canceled"`
+	OutputDir       string `mapstructure:"output_dir"`
+}
+
+type trialFilter struct {
+	state       storage.TrialState
+	namePattern string
+}
+
+func newTrialFilter(c config) (trialFilter, error) {
+	f := trialFilter{
+		state:       storage.TrialState(strings.ToLower(c.State)),
+		namePattern: strings.ToLower(c.Name),
+	}
+
+	if _, err := path.Match(f.namePattern, ""); err != nil {
+		return f, errors.Wrap(err, "bad name pattern", "pattern", c.Name)
+	}
+
+	return f, nil
+}
+
+func (f trialFilter) matches(ctx context.Context, t storage.Trial) bool {
+	if f.state != "" && t.GetState(ctx) != f.state {
+		return false
+	}
+
+	if f.namePattern == "" {
+		return true
+	}
+
+	ok, _ := path.Match(f.namePattern, strings.ToLower(t.GetName(ctx)))
+	return ok
 }
 
 func start(c config) error {
-	fmt.Printf("%+v\n", c)
+	if c.Format == "text" || c.Format == "" {
+		fmt.Printf("%+v\n", c)
+	}
+
+	f, err := newTrialFilter(c)
+	if err != nil {
+		return err
+	}
+
+	out, err := newDumper(c)
+	if err != nil {
+		return err
+	}
 
 	deps, err := createDependencies(c)
 	if err != nil {
@@ -43,25 +95,42 @@ func start(c config) error {
 	// }
 
 	if c.AllGuilds {
-		return dumpAllGuilds(deps)
+		return dumpAllGuilds(deps, f, out)
 	}
 
-	if err := dumpGuildSettings(deps, gid); err != nil {
+	if err := dumpGuild(deps, gid.ToString(), f, out); err != nil {
 		return err
 	}
 
-	if err := dumpGuildTrials(deps, gid); err != nil {
-		return err
+	return out.Finish()
+}
+
+func dumpAllGuilds(deps *dependencies, f trialFilter, out dumper) error {
+	ctx := context.Background()
+
+	guilds, err := deps.GuildAPI().AllGuilds(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list guilds")
 	}
 
-	return nil
+	for _, gid := range guilds {
+		if err := dumpGuild(deps, gid, f, out); err != nil {
+			return errors.Wrap(err, "could not dump guild", "guild_id", gid)
+		}
+	}
+
+	return out.Finish()
 }
 
-func dumpAllGuilds(deps *dependencies) error {
-	return nil
+func dumpGuild(deps *dependencies, gid string, f trialFilter, out dumper) error {
+	if err := dumpGuildSettings(deps, gid, out); err != nil {
+		return err
+	}
+
+	return dumpGuildTrials(deps, gid, f, out)
 }
 
-func dumpGuildSettings(deps *dependencies, gid snowflake.Snowflake) error {
+func dumpGuildSettings(deps *dependencies, gid string, out dumper) error {
 	ctx := context.Background()
 
 	t, err := deps.GuildAPI().NewTransaction(ctx, false)
@@ -70,43 +139,31 @@ func dumpGuildSettings(deps *dependencies, gid snowflake.Snowflake) error {
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
 
-	g, err := t.GetGuild(ctx, gid.ToString())
+	// AddGuild does not write in a read-only transaction; it just gives defaults for guilds without settings
+	g, err := t.AddGuild(ctx, gid)
 	if err != nil {
 		return errors.Wrap(err, "could not get guild for settings")
 	}
 
-	gsettings := g.GetSettings(ctx)
-	fmt.Printf("SETTINGS: %+v\n\n", gsettings)
-	return nil
+	return out.Guild(ctx, gid, g.GetSettings(ctx))
 }
 
-func dumpGuildTrials(deps *dependencies, gid snowflake.Snowflake) error {
+func dumpGuildTrials(deps *dependencies, gid string, f trialFilter, out dumper) error {
 	ctx := context.Background()
-	t, err := deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	t, err := deps.TrialAPI().NewTransaction(ctx, gid, false)
 	if err != nil {
 		return errors.Wrap(err, "could not get trials transaction")
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
 
-	for _, t := range t.GetTrials(ctx) {
-		fmt.Printf(`Name: %s
-	State: %s
-	SignupChannel: %s
-	AnnounceChannel: %s
-	Description: %s
-	Role Counts:`, t.GetName(ctx), t.GetState(ctx), t.GetSignupChannel(ctx), t.GetAnnounceChannel(ctx), t.GetDescription(ctx))
-		for _, rc := range t.GetRoleCounts(ctx) {
-			fmt.Printf(`
-		%s: %d`, rc.GetRole(ctx), rc.GetCount(ctx))
+	for _, trial := range t.GetTrials(ctx) {
+		if !f.matches(ctx, trial) {
+			continue
 		}
-		fmt.Printf(`
-	Signups:`)
-		for _, su := range t.GetSignups(ctx) {
-			fmt.Printf(`
-		%s: %s`, su.GetName(ctx), su.GetRole(ctx))
+
+		if err := out.Trial(ctx, gid, trial); err != nil {
+			return err
 		}
-		fmt.Println()
-		fmt.Println()
 	}
 
 	return nil
@@ -20,7 +20,12 @@ func setup(start func(config) error) *cli.Command {
 	c.Flags().String("guild", "0", "The discord guild id to impersonate")
 	// c.Flags().String("channel", "0", "The discord channel id to impersonate")
 	c.Flags().String("database", "", "The database file")
-	c.Flags().Bool("all_guilds", false, "Dump a list of guilds")
+	c.Flags().Bool("all_guilds", false, "Dump every guild in the database")
+	c.Flags().String("format", "text", "The output format (text, table, jsonl, csv, or json)")
+	c.Flags().String("state", "", "Only dump events in this state (open or closed)")
+	c.Flags().String("name", "", "Only dump events whose names match this glob pattern")
+	c.Flags().Bool("include_canceled", false, "Include canceled signups in the output")
+	c.Flags().String("output_dir", ".", "The directory to write csv files into")
 
 	c.SetRunFunc(func(cmd *cli.Command, args []string) (err error) {
 		v := viper.New()
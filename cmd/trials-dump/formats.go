@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// dumper receives each guild's settings followed by its (filtered) trials
+type dumper interface {
+	Guild(ctx context.Context, gid string, s storage.GuildSettings) error
+	Trial(ctx context.Context, gid string, t storage.Trial) error
+	Finish() error
+}
+
+func newDumper(c config) (dumper, error) {
+	switch c.Format {
+	case "text", "":
+		return &textDumper{includeCanceled: c.IncludeCanceled}, nil
+	case "table":
+		return &tableDumper{
+			includeCanceled: c.IncludeCanceled,
+			w:               tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0),
+		}, nil
+	case "jsonl":
+		return &jsonlDumper{includeCanceled: c.IncludeCanceled}, nil
+	case "json":
+		return &jsonDumper{}, nil
+	case "csv":
+		dir := c.OutputDir
+		if dir == "" {
+			dir = "."
+		}
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return nil, errors.Wrap(err, "could not create output directory")
+		}
+		return &csvDumper{includeCanceled: c.IncludeCanceled, dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown format '%s' (need text, table, jsonl, csv, or json)", c.Format)
+	}
+}
+
+func signupsFor(ctx context.Context, t storage.Trial, includeCanceled bool) []storage.TrialSignup {
+	if includeCanceled {
+		return t.GetSignupHistory(ctx)
+	}
+	return t.GetSignups(ctx)
+}
+
+type textDumper struct {
+	includeCanceled bool
+}
+
+func (d *textDumper) Guild(ctx context.Context, gid string, s storage.GuildSettings) error {
+	fmt.Printf("GUILD: %s\n%s\n", gid, s.PrettyString(ctx, i18n.For(i18n.DefaultLocale)))
+	return nil
+}
+
+func (d *textDumper) Trial(ctx context.Context, gid string, t storage.Trial) error {
+	fmt.Printf(`Name: %s
+	State: %s
+	SignupChannel: %s
+	AnnounceChannel: %s
+	Description: %s
+	Role Counts:`, t.GetName(ctx), t.GetState(ctx), t.GetSignupChannel(ctx), t.GetAnnounceChannel(ctx), t.GetDescription(ctx))
+	for _, rc := range t.GetRoleCounts(ctx) {
+		fmt.Printf(`
+		%s: %d`, rc.GetRole(ctx), rc.GetCount(ctx))
+	}
+	fmt.Printf(`
+	Signups:`)
+	for _, su := range signupsFor(ctx, t, d.includeCanceled) {
+		var note string
+		if details := su.GetDetails(ctx); !details.IsZero() {
+			note = fmt.Sprintf(" [character=%q class=%q note=%q]", details.Character, details.Class, details.Note)
+		}
+		if su.IsCanceled(ctx) {
+			note += " (canceled)"
+		}
+		fmt.Printf(`
+		%s: %s%s`, su.GetName(ctx), su.GetRole(ctx), note)
+	}
+	fmt.Println()
+	fmt.Println()
+
+	return nil
+}
+
+func (d *textDumper) Finish() error {
+	return nil
+}
+
+type tableDumper struct {
+	includeCanceled bool
+	w               *tabwriter.Writer
+	headerDone      bool
+}
+
+func (d *tableDumper) Guild(ctx context.Context, gid string, s storage.GuildSettings) error {
+	if d.headerDone {
+		return nil
+	}
+	d.headerDone = true
+
+	header := "GUILD\tEVENT\tSTATE\tSIGNUPS\tROLES"
+	if d.includeCanceled {
+		header += "\tCANCELED"
+	}
+	_, err := fmt.Fprintln(d.w, header)
+	return err
+}
+
+func (d *tableDumper) Trial(ctx context.Context, gid string, t storage.Trial) error {
+	rcs := t.GetRoleCounts(ctx)
+	roles := make([]string, 0, len(rcs))
+	for _, rc := range rcs {
+		roles = append(roles, fmt.Sprintf("%s:%d", rc.GetRole(ctx), rc.GetCount(ctx)))
+	}
+
+	row := fmt.Sprintf("%s\t%s\t%s\t%d\t%s", gid, t.GetName(ctx), t.GetState(ctx), len(t.GetSignups(ctx)), strings.Join(roles, " "))
+	if d.includeCanceled {
+		canceled := 0
+		for _, su := range t.GetSignupHistory(ctx) {
+			if su.IsCanceled(ctx) {
+				canceled++
+			}
+		}
+		row += fmt.Sprintf("\t%d", canceled)
+	}
+
+	_, err := fmt.Fprintln(d.w, row)
+	return err
+}
+
+func (d *tableDumper) Finish() error {
+	return d.w.Flush()
+}
+
+type jsonlDumper struct {
+	includeCanceled bool
+}
+
+func (d *jsonlDumper) Guild(ctx context.Context, gid string, s storage.GuildSettings) error {
+	return nil
+}
+
+func (d *jsonlDumper) Trial(ctx context.Context, gid string, t storage.Trial) error {
+	line := storage.ExportTrialLine{
+		GuildID:     gid,
+		ExportTrial: storage.ExportTrialFrom(ctx, t, d.includeCanceled),
+	}
+
+	b, err := line.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal trial", "trial_name", t.GetName(ctx))
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
+
+func (d *jsonlDumper) Finish() error {
+	return nil
+}
+
+// jsonDumper writes one storage.GuildExport document per line
+type jsonDumper struct {
+	doc *storage.GuildExport
+}
+
+func (d *jsonDumper) flush() error {
+	if d.doc == nil {
+		return nil
+	}
+
+	b, err := d.doc.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal export", "guild_id", d.doc.GuildID)
+	}
+	d.doc = nil
+
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
+
+func (d *jsonDumper) Guild(ctx context.Context, gid string, s storage.GuildSettings) error {
+	if err := d.flush(); err != nil {
+		return err
+	}
+
+	d.doc = &storage.GuildExport{
+		Version:    storage.ExportVersion,
+		GuildID:    gid,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Settings:   storage.ExportSettingsFrom(s),
+		Trials:     []storage.ExportTrial{},
+	}
+
+	return nil
+}
+
+func (d *jsonDumper) Trial(ctx context.Context, gid string, t storage.Trial) error {
+	d.doc.Trials = append(d.doc.Trials, storage.ExportTrialFrom(ctx, t, false))
+	return nil
+}
+
+func (d *jsonDumper) Finish() error {
+	return d.flush()
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// csvDumper writes one csv file of signups per trial into dir
+type csvDumper struct {
+	includeCanceled bool
+	dir             string
+}
+
+func (d *csvDumper) Guild(ctx context.Context, gid string, s storage.GuildSettings) error {
+	return nil
+}
+
+func (d *csvDumper) Trial(ctx context.Context, gid string, t storage.Trial) (err error) {
+	name := unsafeFileChars.ReplaceAllString(strings.ToLower(t.GetName(ctx)), "_")
+	fname := filepath.Join(d.dir, fmt.Sprintf("%s-%s.csv", gid, name))
+
+	f, err := os.Create(fname)
+	if err != nil {
+		return errors.Wrap(err, "could not create csv file", "file", fname)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = errors.Wrap(cerr, "could not close csv file", "file", fname)
+		}
+	}()
+
+	w := csv.NewWriter(f)
+	if err = w.Write([]string{"position", "user", "role", "canceled", "character", "class", "note"}); err != nil {
+		return errors.Wrap(err, "could not write csv header", "file", fname)
+	}
+
+	for i, su := range signupsFor(ctx, t, d.includeCanceled) {
+		details := su.GetDetails(ctx)
+		rec := []string{fmt.Sprintf("%d", i+1), su.GetName(ctx), su.GetRole(ctx), fmt.Sprintf("%t", su.IsCanceled(ctx)), details.Character, details.Class, details.Note}
+		if err = w.Write(rec); err != nil {
+			return errors.Wrap(err, "could not write csv record", "file", fname)
+		}
+	}
+
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return errors.Wrap(err, "could not write csv file", "file", fname)
+	}
+
+	fmt.Println(fname)
+	return nil
+}
+
+func (d *csvDumper) Finish() error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+type config struct {
+	Database string `mapstructure:"database"`
+	Repair   bool   `mapstructure:"repair"`
+}
+
+func start(c config) error {
+	fmt.Printf("%+v\n", c)
+
+	deps, err := createDependencies(c)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	ctx := context.Background()
+
+	issues, err := deps.Checker().Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	quarantinable := 0
+	for _, i := range issues {
+		fmt.Println(i)
+		if i.Quarantinable() {
+			quarantinable++
+		}
+	}
+	fmt.Printf("Found %d issues (%d records to quarantine)\n", len(issues), quarantinable)
+
+	if c.Repair && quarantinable > 0 {
+		moved, err := deps.Checker().Quarantine(ctx, issues)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Quarantined %d records\n", moved)
+	}
+
+	return nil
+}
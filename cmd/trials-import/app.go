@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type config struct {
+	Database string `mapstructure:"database"`
+	Guild    string `mapstructure:"guild"`
+	File     string `mapstructure:"file"`
+	Conflict string `mapstructure:"conflict"`
+	Settings bool   `mapstructure:"settings"`
+}
+
+func start(c config) error {
+	fmt.Printf("%+v\n", c)
+
+	gid, err := snowflake.FromString(c.Guild)
+	if err != nil {
+		return errors.Wrap(err, "could not parse guild id")
+	}
+
+	if gid == 0 {
+		return errors.New("a target guild id is required")
+	}
+
+	mode, err := storage.ParseConflictMode(c.Conflict)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readExport(c.File)
+	if err != nil {
+		return err
+	}
+
+	deps, err := createDependencies(c)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	ctx := context.Background()
+	res, err := storage.ImportGuild(ctx, deps.GuildAPI(), deps.TrialAPI(), gid.ToString(), doc, storage.ImportOptions{
+		Conflict: mode,
+		Settings: c.Settings,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not import guild")
+	}
+
+	fmt.Printf("Imported guild %s into %s (export version %d)\n", doc.GuildID, gid.ToString(), doc.Version)
+	if c.Settings {
+		fmt.Println("Settings: replaced")
+	}
+	printNames("Created", res.Created)
+	printNames("Overwritten", res.Overwritten)
+	printNames("Skipped", res.Skipped)
+
+	renamed := make([]string, 0, len(res.Renamed))
+	for from, to := range res.Renamed {
+		renamed = append(renamed, fmt.Sprintf("%s -> %s", from, to))
+	}
+	sort.Strings(renamed)
+	printNames("Renamed", renamed)
+	printNames("Roles dropped (set them again)", res.DroppedRoles)
+
+	return nil
+}
+
+func readExport(file string) (storage.GuildExport, error) {
+	var doc storage.GuildExport
+	var b []byte
+	var err error
+
+	if file == "-" || file == "" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return doc, errors.Wrap(err, "could not read export file")
+	}
+
+	if err = doc.UnmarshalJSON(b); err != nil {
+		return doc, errors.Wrap(err, "could not parse export file")
+	}
+
+	return doc, nil
+}
+
+func printNames(label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Printf("%s:\n", label)
+	for _, n := range names {
+		fmt.Printf("\t%s\n", n)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/gsmcwhirter/go-util/v5/cli"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+func setup(start func(config) error) *cli.Command {
+	c := cli.NewCLI(AppName, BuildVersion, BuildSHA, BuildDate, cli.CommandOptions{
+		ShortHelp: "Import a guild export into the database",
+		Args:      cli.NoArgs,
+	})
+
+	var configFile string
+
+	c.Flags().StringVar(&configFile, "config", "./config.toml", "The config file to use")
+	c.Flags().String("guild", "0", "The discord guild id to import into")
+	c.Flags().String("database", "", "The database file")
+	c.Flags().String("file", "-", "The export file to import (- for stdin)")
+	c.Flags().String("conflict", "skip", "What to do with trials that already exist (skip, overwrite, or rename)")
+	c.Flags().Bool("settings", false, "Also replace the guild settings with the exported ones")
+
+	c.SetRunFunc(func(cmd *cli.Command, args []string) (err error) {
+		v := viper.New()
+
+		if configFile != "" {
+			v.SetConfigFile(configFile)
+		} else {
+			v.SetConfigName("config")
+			v.AddConfigPath(".") // working directory
+		}
+
+		v.SetEnvPrefix("EDB")
+		v.AutomaticEnv()
+
+		err = v.BindPFlags(cmd.Flags())
+		if err != nil {
+			return errors.Wrap(err, "could not bind flags to viper")
+		}
+
+		err = v.ReadInConfig()
+		if err != nil {
+			return errors.Wrap(err, "could not read in config file")
+		}
+
+		conf := config{}
+		err = v.Unmarshal(&conf)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal config into struct")
+		}
+
+		return start(conf)
+	})
+
+	return c
+}
@@ -0,0 +1,252 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+)
+
+const timestampFormat = "20060102T150405Z"
+
+// ErrNoBackupYet is the error returned when asking about the last backup before one has completed
+var ErrNoBackupYet = errors.New("no backup has completed yet")
+
+type dependencies interface {
+	Logger() logging.Logger
+	DB() *bolt.DB
+	Census() *census.Census
+}
+
+// Options provides a way to pass configuration to NewBackuper
+//
+// - Directory is where backup files are written
+// - Prefix is prepended to each backup file name (defaults to "backup")
+// - Interval is the time between scheduled backups (0 disables scheduled backups)
+// - Retain is the number of backup files to keep (0 keeps all of them)
+type Options struct {
+	Directory string
+	Prefix    string
+	Interval  time.Duration
+	Retain    int
+}
+
+// Backuper is the api for taking online backups of the bolt database
+type Backuper interface {
+	Run(ctx context.Context) error
+	BackupNow(ctx context.Context) (string, error)
+	LastBackup() (string, time.Time, error)
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type backuper struct {
+	deps      dependencies
+	directory string
+	prefix    string
+	interval  time.Duration
+	retain    int
+
+	lock     *sync.Mutex
+	lastPath string
+	lastTime time.Time
+}
+
+// NewBackuper creates a new Backuper object
+func NewBackuper(deps dependencies, opts Options) (Backuper, error) {
+	if opts.Directory == "" {
+		return nil, errors.New("backup directory is required")
+	}
+
+	if err := os.MkdirAll(opts.Directory, 0750); err != nil {
+		return nil, errors.Wrap(err, "could not create backup directory")
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = "backup"
+	}
+
+	b := &backuper{
+		deps:      deps,
+		directory: opts.Directory,
+		prefix:    prefix,
+		interval:  opts.Interval,
+		retain:    opts.Retain,
+		lock:      &sync.Mutex{},
+	}
+
+	return b, nil
+}
+
+// Run takes backups on the configured interval until the context is canceled
+func (b *backuper) Run(ctx context.Context) error {
+	if err := registerStats(); err != nil {
+		return err
+	}
+
+	if b.interval <= 0 {
+		level.Info(b.deps.Logger()).Message("scheduled backups disabled", "directory", b.directory)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	level.Info(b.deps.Logger()).Message("starting scheduled backups", "directory", b.directory, "interval", b.interval.String())
+
+	// the first snapshot is taken at startup rather than one interval later, so that a
+	// restart loop does not go without backups
+	b.scheduledBackup(ctx)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(b.deps.Logger()).Message("stopping scheduled backups")
+			return ctx.Err()
+		case <-ticker.C:
+			b.scheduledBackup(ctx)
+		}
+	}
+}
+
+func (b *backuper) scheduledBackup(ctx context.Context) {
+	if _, err := b.BackupNow(ctx); err != nil {
+		level.Error(b.deps.Logger()).Err("scheduled backup failed", err)
+	}
+}
+
+// BackupNow writes a consistent snapshot of the database and prunes old backups
+//
+// It returns the path of the new backup file
+func (b *backuper) BackupNow(ctx context.Context) (string, error) {
+	ctx, span := b.deps.Census().StartSpan(ctx, "backuper.BackupNow")
+	defer span.End()
+
+	logger := logging.WithContext(ctx, b.deps.Logger())
+
+	// only one backup at a time; the lock also protects lastPath and lastTime
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now().UTC()
+	path := filepath.Join(b.directory, fmt.Sprintf("%s-%s.db", b.prefix, now.Format(timestampFormat)))
+
+	if err := b.writeSnapshot(path); err != nil {
+		if rerr := b.deps.Census().Record(ctx, []census.Measurement{BackupsCount.M(1)}, census.Tag{Key: TagStatus, Val: "error"}); rerr != nil {
+			level.Error(logger).Err("could not record stat", rerr)
+		}
+		return "", err
+	}
+
+	b.lastPath = path
+	b.lastTime = now
+
+	if err := b.deps.Census().Record(ctx, []census.Measurement{BackupsCount.M(1), LastBackupTime.M(now.Unix())}, census.Tag{Key: TagStatus, Val: "ok"}); err != nil {
+		level.Error(logger).Err("could not record stat", err)
+	}
+
+	level.Info(logger).Message("backup complete", "path", path)
+
+	if err := b.prune(); err != nil {
+		level.Error(logger).Err("could not prune old backups", err)
+	}
+
+	return path, nil
+}
+
+// LastBackup returns the path and time of the most recent successful backup
+func (b *backuper) LastBackup() (string, time.Time, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.lastPath == "" {
+		return "", time.Time{}, ErrNoBackupYet
+	}
+
+	return b.lastPath, b.lastTime, nil
+}
+
+func (b *backuper) writeSnapshot(path string) error {
+	f, err := ioutil.TempFile(b.directory, fmt.Sprintf(".%s-*.tmp", b.prefix))
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary backup file")
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName) // nolint: errcheck
+
+	err = b.deps.DB().View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	})
+	if err != nil {
+		f.Close() // nolint: errcheck
+		return errors.Wrap(err, "could not write backup")
+	}
+
+	if err = f.Sync(); err != nil {
+		f.Close() // nolint: errcheck
+		return errors.Wrap(err, "could not sync backup")
+	}
+
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "could not close backup")
+	}
+
+	if err = os.Rename(tmpName, path); err != nil {
+		return errors.Wrap(err, "could not move backup into place")
+	}
+
+	return nil
+}
+
+func (b *backuper) prune() error {
+	if b.retain <= 0 {
+		return nil
+	}
+
+	entries, err := ioutil.ReadDir(b.directory)
+	if err != nil {
+		return errors.Wrap(err, "could not list backup directory")
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if strings.HasPrefix(e.Name(), b.prefix+"-") && strings.HasSuffix(e.Name(), ".db") {
+			names = append(names, e.Name())
+		}
+	}
+
+	if len(names) <= b.retain {
+		return nil
+	}
+
+	// timestamps in the names sort chronologically
+	sort.Strings(names)
+
+	for _, name := range names[:len(names)-b.retain] {
+		if err := os.Remove(filepath.Join(b.directory, name)); err != nil {
+			return errors.Wrap(err, "could not remove old backup", "name", name)
+		}
+		level.Info(b.deps.Logger()).Message("removed old backup", "name", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package backup
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	"github.com/gsmcwhirter/go-util/v5/request"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+)
+
+// ServeHTTP reports on the last backup for a GET and triggers a new backup for a POST
+func (b *backuper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := request.NewRequestContextFrom(r.Context())
+	logger := logging.WithContext(ctx, b.deps.Logger())
+
+	switch r.Method {
+	case http.MethodGet:
+		path, t, err := b.LastBackup()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "last backup: %s at %s\n", path, t.Format(time.RFC3339))
+
+	case http.MethodPost:
+		level.Info(logger).Message("backup requested over http", "remote_addr", r.RemoteAddr)
+
+		path, err := b.BackupNow(ctx)
+		if err != nil {
+			level.Error(logger).Err("requested backup failed", err)
+			http.Error(w, "backup failed", http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "backup written to %s\n", path)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
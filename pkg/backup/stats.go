@@ -0,0 +1,48 @@
+package backup
+
+import (
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+	"go.opencensus.io/stats/view"
+)
+
+var (
+	BackupsCount   = census.Int64("backups_ct", "Backup attempts count", "1")
+	LastBackupTime = census.Int64("last_backup_timestamp", "Unix time of the last successful backup", "s")
+)
+
+var (
+	TagStatus, _ = census.NewTagKey("status")
+)
+
+var (
+	BackupsCountView = &census.View{
+		Name: "backups",
+		TagKeys: []census.TagKey{
+			TagStatus,
+		},
+		Measure:     BackupsCount,
+		Description: "The number of backups attempted",
+		Aggregation: census.CountView(),
+	}
+
+	LastBackupTimeView = &census.View{
+		Name:        "last_backup_timestamp",
+		TagKeys:     []census.TagKey{},
+		Measure:     LastBackupTime,
+		Description: "The unix time of the last successful backup",
+		Aggregation: view.LastValue(),
+	}
+)
+
+func registerStats() error {
+	if err := census.RegisterView(BackupsCountView); err != nil {
+		return errors.Wrap(err, "could not register BackupsCountView")
+	}
+
+	if err := census.RegisterView(LastBackupTimeView); err != nil {
+		return errors.Wrap(err, "could not register LastBackupTimeView")
+	}
+
+	return nil
+}
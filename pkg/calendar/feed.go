@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+)
+
+// NewKey generates a random calendar key for a guild
+func NewKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate calendar key")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// feedToken derives the token for a feed url from the guild's calendar key, so that
+// sharing one feed does not let anyone construct the guild feed or other members' feeds
+func feedToken(key, subject string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(subject)) // nolint: errcheck
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// GuildToken is the token for the feed of every scheduled trial in a guild
+func GuildToken(key string) string {
+	return feedToken(key, "guild")
+}
+
+// UserToken is the token for the feed of the scheduled trials a user signed up for
+func UserToken(key string, uid snowflake.Snowflake) string {
+	return feedToken(key, "user:"+uid.ToString())
+}
+
+// CheckToken reports whether token matches the expected one, in constant time
+func CheckToken(expected, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
+
+// GuildFeedURL builds the url of a guild's feed under the base url of the calendar server
+func GuildFeedURL(baseURL string, gid snowflake.Snowflake, key string) string {
+	return fmt.Sprintf("%s/calendar/%s/%s.ics", strings.TrimRight(baseURL, "/"), gid.ToString(), GuildToken(key))
+}
+
+// UserFeedURL builds the url of a user's feed under the base url of the calendar server
+func UserFeedURL(baseURL string, gid, uid snowflake.Snowflake, key string) string {
+	return fmt.Sprintf("%s/calendar/%s/%s/%s.ics", strings.TrimRight(baseURL, "/"), gid.ToString(), uid.ToString(), UserToken(key, uid))
+}
@@ -0,0 +1,170 @@
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// DefaultDuration is the length given to scheduled trials that do not have one
+const DefaultDuration = time.Hour
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	maxLineOctets = 75
+)
+
+// Scheduled reports whether a trial belongs in a feed: it must be open and have a start time
+func Scheduled(ctx context.Context, t storage.Trial) bool {
+	return t.GetState(ctx) == storage.TrialStateOpen && !t.GetStartTime(ctx).IsZero()
+}
+
+// SignedUp reports whether the user has an active signup for the trial
+func SignedUp(ctx context.Context, t storage.Trial, uid snowflake.Snowflake) bool {
+	nick := cmdhandler.UserMentionString(uid)
+	acct := fmt.Sprintf("<@%s>", uid.ToString())
+
+	for _, su := range t.GetSignups(ctx) {
+		if name := su.GetName(ctx); name == nick || name == acct {
+			return true
+		}
+	}
+	return false
+}
+
+// Render writes an iCalendar document with a VEVENT for each scheduled trial
+//
+// Trials that are not Scheduled are skipped, so callers can pass a guild's full list
+func Render(ctx context.Context, w io.Writer, gid, calName string, trials []storage.Trial, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:-//discord-signup-bot//calendar//EN")
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	lw.line("X-WR-CALNAME:" + escapeText(calName))
+
+	for _, t := range trials {
+		if !Scheduled(ctx, t) {
+			continue
+		}
+
+		start := t.GetStartTime(ctx).UTC()
+		dur := t.GetDuration(ctx)
+		if dur <= 0 {
+			dur = DefaultDuration
+		}
+
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + eventUID(gid, t.GetName(ctx)))
+		lw.line("DTSTAMP:" + now.UTC().Format(icsTimeFormat))
+		lw.line("DTSTART:" + start.Format(icsTimeFormat))
+		lw.line("DTEND:" + start.Add(dur).Format(icsTimeFormat))
+		lw.line("SUMMARY:" + escapeText(t.GetName(ctx)))
+		lw.line("DESCRIPTION:" + escapeText(eventDescription(ctx, t)))
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+// eventUID is stable across renders so calendar apps update events instead of duplicating them
+func eventUID(gid, name string) string {
+	sum := sha256.Sum256([]byte(gid + "/" + strings.ToLower(name)))
+	return hex.EncodeToString(sum[:16]) + "@discord-signup-bot"
+}
+
+func eventDescription(ctx context.Context, t storage.Trial) string {
+	var b strings.Builder
+
+	if d := t.GetDescription(ctx); d != "" {
+		b.WriteString(d)
+		b.WriteString("\n\n")
+	}
+
+	signups := t.GetSignups(ctx)
+	b.WriteString("Roles:\n")
+	for _, rc := range t.GetRoleCounts(ctx) {
+		filled := uint64(0)
+		for _, su := range signups {
+			if strings.EqualFold(su.GetRole(ctx), rc.GetRole(ctx)) {
+				filled++
+			}
+		}
+
+		overflow := uint64(0)
+		if filled > rc.GetCount(ctx) {
+			overflow = filled - rc.GetCount(ctx)
+			filled = rc.GetCount(ctx)
+		}
+
+		fmt.Fprintf(&b, "%s: %d/%d", rc.GetRole(ctx), filled, rc.GetCount(ctx))
+		if overflow > 0 {
+			fmt.Fprintf(&b, " (+%d overflow)", overflow)
+		}
+		b.WriteString("\n")
+	}
+
+	if sc := t.GetSignupChannel(ctx); sc != "" {
+		fmt.Fprintf(&b, "\nSignup channel: #%s", sc)
+	}
+
+	return b.String()
+}
+
+// escapeText escapes a TEXT value as described in RFC 5545 section 3.3.11
+func escapeText(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, ";", `\;`, -1)
+	s = strings.Replace(s, ",", `\,`, -1)
+	s = strings.Replace(s, "\r\n", `\n`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return s
+}
+
+// lineWriter writes CRLF-terminated content lines, folding them at 75 octets
+// without splitting utf-8 sequences (RFC 5545 section 3.1)
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (l *lineWriter) line(s string) {
+	if l.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		if _, l.err = l.w.WriteString(s[:cut] + "\r\n "); l.err != nil {
+			return
+		}
+		s = s[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+
+	_, l.err = l.w.WriteString(s + "\r\n")
+}
@@ -0,0 +1,256 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// Rule names reported in Actions
+const (
+	RuleNameTooLong = "name-too-long"
+	RuleClosed      = "closed"
+	RuleEmpty       = "empty"
+	RuleCorrupt     = "corrupt"
+)
+
+const day = 24 * time.Hour
+
+type dependencies interface {
+	Logger() logging.Logger
+	TrialAPI() storage.TrialAPI
+	GuildAPI() storage.GuildAPI
+	Census() *census.Census
+}
+
+// Rules determines which trials are deleted; each rule is disabled when left at its zero value
+//
+// - MaxNameLength deletes trials whose names are longer than this
+// - ClosedDays deletes trials that have been closed (or canceled) for more than this many days
+// - EmptyDays deletes trials with no signups that were created more than this many days ago
+// - Corrupt deletes records that cannot be decoded
+type Rules struct {
+	MaxNameLength int
+	ClosedDays    int
+	EmptyDays     int
+	Corrupt       bool
+}
+
+// Options provides a way to pass configuration to NewCleaner
+//
+// - DryRun reports what would be deleted without changing anything
+// - Interval is the time between scheduled cleanups in Run (0 disables them)
+type Options struct {
+	Rules    Rules
+	DryRun   bool
+	Interval time.Duration
+}
+
+// Action describes a record that a cleanup deleted (or would delete, in a dry run)
+type Action struct {
+	GuildID string
+	Key     string
+	Rule    string
+}
+
+func (a Action) String() string {
+	return fmt.Sprintf("guild=%s key=%q rule=%s", a.GuildID, a.Key, a.Rule)
+}
+
+// Cleaner is the api for applying cleanup rules to the trials database
+type Cleaner interface {
+	Run(ctx context.Context) error
+	CleanAll(ctx context.Context) ([]Action, error)
+	CleanGuild(ctx context.Context, gid string) ([]Action, error)
+}
+
+type cleaner struct {
+	deps     dependencies
+	rules    Rules
+	dryRun   bool
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewCleaner creates a new Cleaner object
+func NewCleaner(deps dependencies, opts Options) Cleaner {
+	return &cleaner{
+		deps:     deps,
+		rules:    opts.Rules,
+		dryRun:   opts.DryRun,
+		interval: opts.Interval,
+		now:      time.Now,
+	}
+}
+
+// Run cleans every guild on the configured interval until the context is canceled
+func (c *cleaner) Run(ctx context.Context) error {
+	if c.interval <= 0 {
+		level.Info(c.deps.Logger()).Message("scheduled cleanup disabled")
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	level.Info(c.deps.Logger()).Message("starting scheduled cleanup", "interval", c.interval.String(), "dry_run", c.dryRun)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(c.deps.Logger()).Message("stopping scheduled cleanup")
+			return ctx.Err()
+		case <-ticker.C:
+			actions, err := c.CleanAll(ctx)
+			if err != nil {
+				level.Error(c.deps.Logger()).Err("scheduled cleanup failed", err)
+				continue
+			}
+			level.Info(c.deps.Logger()).Message("scheduled cleanup complete", "deleted", len(actions), "dry_run", c.dryRun)
+		}
+	}
+}
+
+// CleanAll applies the rules to every guild in the database
+func (c *cleaner) CleanAll(ctx context.Context) ([]Action, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "cleaner.CleanAll")
+	defer span.End()
+
+	guilds, err := c.deps.GuildAPI().AllGuilds(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list guilds")
+	}
+
+	var actions []Action
+	for _, gid := range guilds {
+		ga, err := c.CleanGuild(ctx, gid)
+		actions = append(actions, ga...)
+		if err != nil {
+			return actions, errors.Wrap(err, "could not clean guild", "guild_id", gid)
+		}
+	}
+
+	return actions, nil
+}
+
+// CleanGuild applies the rules to a single guild
+//
+// Records written before trials tracked their timestamps are stamped with the current
+// time (outside of dry runs) so that the age-based rules can apply to them later
+func (c *cleaner) CleanGuild(ctx context.Context, gid string) ([]Action, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "cleaner.CleanGuild", "guild_id", gid)
+	defer span.End()
+
+	logger := logging.WithContext(ctx, c.deps.Logger())
+
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid, !c.dryRun)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start trials transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	now := c.now()
+	var actions []Action
+
+	if c.rules.Corrupt {
+		for _, key := range t.GetCorruptTrialKeys(ctx) {
+			actions = append(actions, Action{GuildID: gid, Key: key, Rule: RuleCorrupt})
+			if c.dryRun {
+				continue
+			}
+
+			if err := t.DeleteTrialKey(ctx, key); err != nil {
+				return nil, errors.Wrap(err, "could not delete corrupt record", "key", key)
+			}
+		}
+	}
+
+	stamped := 0
+	for _, trial := range t.GetTrials(ctx) {
+		name := trial.GetName(ctx)
+
+		if rule := c.matchRule(ctx, trial, now); rule != "" {
+			actions = append(actions, Action{GuildID: gid, Key: name, Rule: rule})
+			if c.dryRun {
+				continue
+			}
+
+			if err := t.DeleteTrial(ctx, name); err != nil {
+				return nil, errors.Wrap(err, "could not delete trial", "trial_name", name)
+			}
+			continue
+		}
+
+		if c.dryRun || !c.stampLegacy(ctx, trial, now) {
+			continue
+		}
+
+		if err := t.SaveTrial(ctx, trial); err != nil {
+			return nil, errors.Wrap(err, "could not save trial", "trial_name", name)
+		}
+		stamped++
+	}
+
+	if c.dryRun {
+		return actions, nil
+	}
+
+	if err := t.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not commit cleanup")
+	}
+
+	if len(actions) > 0 || stamped > 0 {
+		level.Info(logger).Message("cleaned guild", "deleted", len(actions), "stamped", stamped)
+	}
+
+	return actions, nil
+}
+
+func (c *cleaner) matchRule(ctx context.Context, trial storage.Trial, now time.Time) string {
+	if c.rules.MaxNameLength > 0 && len(trial.GetName(ctx)) > c.rules.MaxNameLength {
+		return RuleNameTooLong
+	}
+
+	state := trial.GetState(ctx)
+	if c.rules.ClosedDays > 0 && (state == storage.TrialStateClosed || state == storage.TrialStateCanceled) {
+		changed := trial.GetStateChangedAt(ctx)
+		if !changed.IsZero() && now.Sub(changed) > time.Duration(c.rules.ClosedDays)*day {
+			return RuleClosed
+		}
+	}
+
+	if c.rules.EmptyDays > 0 && len(trial.GetSignups(ctx)) == 0 {
+		created := trial.GetCreatedAt(ctx)
+		if !created.IsZero() && now.Sub(created) > time.Duration(c.rules.EmptyDays)*day {
+			return RuleEmpty
+		}
+	}
+
+	return ""
+}
+
+func (c *cleaner) stampLegacy(ctx context.Context, trial storage.Trial, now time.Time) bool {
+	changed := false
+
+	if trial.GetCreatedAt(ctx).IsZero() {
+		trial.SetCreatedAt(ctx, now)
+		changed = true
+	}
+
+	if trial.GetStateChangedAt(ctx).IsZero() {
+		trial.SetStateChangedAt(ctx, now)
+		changed = true
+	}
+
+	return changed
+}
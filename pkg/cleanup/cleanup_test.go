@@ -0,0 +1,320 @@
+package cleanup
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	log "github.com/gsmcwhirter/go-util/v5/logging"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Log(...interface{}) error { return nil }
+
+type testDeps struct {
+	trialAPI storage.TrialAPI
+	guildAPI storage.GuildAPI
+}
+
+func (testDeps) Logger() logging.Logger       { return log.NewFrom(nopLogger{}) }
+func (d testDeps) TrialAPI() storage.TrialAPI { return d.trialAPI }
+func (d testDeps) GuildAPI() storage.GuildAPI { return d.guildAPI }
+func (testDeps) Census() *census.Census       { return nil }
+
+var testNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// testTrial is a trial to seed the database with
+type testTrial struct {
+	name    string
+	state   storage.TrialState
+	created time.Time
+	changed time.Time
+	signups []string
+}
+
+var testTrials = []testTrial{
+	{name: "averyveryverylongname", state: storage.TrialStateOpen, created: testNow, changed: testNow, signups: []string{"<@!1>"}},
+	{name: "closedold", state: storage.TrialStateClosed, created: testNow.Add(-60 * day), changed: testNow.Add(-31 * day), signups: []string{"<@!1>"}},
+	{name: "closednew", state: storage.TrialStateClosed, created: testNow.Add(-60 * day), changed: testNow.Add(-29 * day), signups: []string{"<@!1>"}},
+	{name: "canceledold", state: storage.TrialStateCanceled, created: testNow.Add(-60 * day), changed: testNow.Add(-31 * day), signups: []string{"<@!1>"}},
+	{name: "openold", state: storage.TrialStateOpen, created: testNow.Add(-60 * day), changed: testNow.Add(-60 * day), signups: []string{"<@!1>"}},
+	{name: "emptyold", state: storage.TrialStateOpen, created: testNow.Add(-31 * day), changed: testNow.Add(-31 * day)},
+	{name: "emptynew", state: storage.TrialStateOpen, created: testNow.Add(-29 * day), changed: testNow.Add(-29 * day)},
+	{name: "legacy", state: storage.TrialStateClosed},
+}
+
+// testDB is a database with the test trials and a corrupt record in guild 1
+func testDB(t *testing.T) (*bolt.DB, testDeps, func()) {
+	t.Helper()
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "cleanup-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		os.RemoveAll(dir) // nolint: errcheck
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	done := func() {
+		db.Close()        // nolint: errcheck
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+
+	gapi, err := storage.NewBoltGuildAPI(ctx, db, nil)
+	if err != nil {
+		done()
+		t.Fatalf("could not create guild api: %v", err)
+	}
+
+	tapi, err := storage.NewBoltTrialAPI(db, nil)
+	if err != nil {
+		done()
+		t.Fatalf("could not create trial api: %v", err)
+	}
+
+	tx, err := tapi.NewTransaction(ctx, "1", true)
+	if err != nil {
+		done()
+		t.Fatalf("could not start trials transaction: %v", err)
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	for _, tt := range testTrials {
+		trial, err := tx.AddTrial(ctx, tt.name)
+		if err != nil {
+			done()
+			t.Fatalf("could not add trial: %v", err)
+		}
+
+		trial.SetState(ctx, tt.state)
+		trial.SetRoleCount(ctx, "dps", "", 8)
+		for _, su := range tt.signups {
+			trial.AddSignup(ctx, su, "dps")
+		}
+		trial.SetCreatedAt(ctx, tt.created)
+		trial.SetStateChangedAt(ctx, tt.changed)
+
+		if err = tx.SaveTrial(ctx, trial); err != nil {
+			done()
+			t.Fatalf("could not save trial: %v", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		done()
+		t.Fatalf("could not commit trials: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("1")).Put([]byte("broken"), []byte{0xff, 0xff, 0xff})
+	})
+	if err != nil {
+		done()
+		t.Fatalf("could not write corrupt record: %v", err)
+	}
+
+	return db, testDeps{trialAPI: tapi, guildAPI: gapi}, done
+}
+
+// snapshot is every record of a guild, with the id of the last write transaction
+func snapshot(t *testing.T, db *bolt.DB, gid string) (map[string]string, int) {
+	t.Helper()
+
+	records := map[string]string{}
+	txid := 0
+	err := db.View(func(tx *bolt.Tx) error {
+		txid = tx.ID()
+		return tx.Bucket([]byte(gid)).ForEach(func(k, v []byte) error {
+			records[string(k)] = string(v)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("could not read database: %v", err)
+	}
+
+	return records, txid
+}
+
+func sortedActions(actions []Action) []Action {
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].Key < actions[j].Key
+	})
+	return actions
+}
+
+func TestCleanGuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		want  []Action
+	}{
+		{
+			name:  "name too long",
+			rules: Rules{MaxNameLength: 20},
+			want:  []Action{{"1", "averyveryverylongname", RuleNameTooLong}},
+		},
+		{
+			name:  "closed or canceled for too long",
+			rules: Rules{ClosedDays: 30},
+			want:  []Action{{"1", "canceledold", RuleClosed}, {"1", "closedold", RuleClosed}},
+		},
+		{
+			name:  "empty for too long",
+			rules: Rules{EmptyDays: 30},
+			want:  []Action{{"1", "emptyold", RuleEmpty}},
+		},
+		{
+			name:  "corrupt",
+			rules: Rules{Corrupt: true},
+			want:  []Action{{"1", "broken", RuleCorrupt}},
+		},
+		{
+			name:  "all rules",
+			rules: Rules{MaxNameLength: 20, ClosedDays: 30, EmptyDays: 30, Corrupt: true},
+			want: []Action{
+				{"1", "averyveryverylongname", RuleNameTooLong},
+				{"1", "broken", RuleCorrupt},
+				{"1", "canceledold", RuleClosed},
+				{"1", "closedold", RuleClosed},
+				{"1", "emptyold", RuleEmpty},
+			},
+		},
+		{
+			name:  "no rules",
+			rules: Rules{},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("dry run", func(t *testing.T) {
+				db, deps, done := testDB(t)
+				defer done()
+
+				before, beforeTx := snapshot(t, db, "1")
+
+				c := NewCleaner(deps, Options{Rules: tt.rules, DryRun: true}).(*cleaner)
+				c.now = func() time.Time { return testNow }
+
+				got, err := c.CleanGuild(ctx, "1")
+				if err != nil {
+					t.Fatalf("CleanGuild() error = %v", err)
+				}
+				if got = sortedActions(got); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CleanGuild() = %v, want %v", got, tt.want)
+				}
+
+				after, afterTx := snapshot(t, db, "1")
+				if afterTx != beforeTx {
+					t.Errorf("dry run wrote to the database: transaction %d, then %d", beforeTx, afterTx)
+				}
+				if !reflect.DeepEqual(after, before) {
+					t.Errorf("dry run changed the records")
+				}
+			})
+
+			t.Run("deleting", func(t *testing.T) {
+				db, deps, done := testDB(t)
+				defer done()
+
+				before, _ := snapshot(t, db, "1")
+
+				c := NewCleaner(deps, Options{Rules: tt.rules}).(*cleaner)
+				c.now = func() time.Time { return testNow }
+
+				got, err := c.CleanGuild(ctx, "1")
+				if err != nil {
+					t.Fatalf("CleanGuild() error = %v", err)
+				}
+				if got = sortedActions(got); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CleanGuild() = %v, want %v", got, tt.want)
+				}
+
+				after, _ := snapshot(t, db, "1")
+
+				deleted := map[string]bool{}
+				for _, a := range tt.want {
+					deleted[a.Key] = true
+				}
+				for key := range before {
+					if _, kept := after[key]; kept == deleted[key] {
+						t.Errorf("record %q kept=%v, want kept=%v", key, kept, !deleted[key])
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestCleanGuildStampsLegacy(t *testing.T) {
+	ctx := context.Background()
+
+	_, deps, done := testDB(t)
+	defer done()
+
+	c := NewCleaner(deps, Options{Rules: Rules{ClosedDays: 30, EmptyDays: 30}}).(*cleaner)
+	c.now = func() time.Time { return testNow }
+
+	if _, err := c.CleanGuild(ctx, "1"); err != nil {
+		t.Fatalf("CleanGuild() error = %v", err)
+	}
+
+	tx, err := deps.TrialAPI().NewTransaction(ctx, "1", false)
+	if err != nil {
+		t.Fatalf("could not start trials transaction: %v", err)
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	legacy, err := tx.GetTrial(ctx, "legacy")
+	if err != nil {
+		t.Fatalf("legacy trial was deleted: %v", err)
+	}
+	if got := legacy.GetCreatedAt(ctx); !got.Equal(testNow) {
+		t.Errorf("legacy created at %v, want %v", got, testNow)
+	}
+	if got := legacy.GetStateChangedAt(ctx); !got.Equal(testNow) {
+		t.Errorf("legacy state changed at %v, want %v", got, testNow)
+	}
+
+	closed, err := tx.GetTrial(ctx, "closednew")
+	if err != nil {
+		t.Fatalf("closednew trial was deleted: %v", err)
+	}
+	if got, want := closed.GetStateChangedAt(ctx), testNow.Add(-29*day); !got.Equal(want) {
+		t.Errorf("closednew state changed at %v, want %v", got, want)
+	}
+
+	if err = tx.Rollback(ctx); err != nil {
+		t.Fatalf("could not end transaction: %v", err)
+	}
+
+	// the stamps start the ages of legacy trials, which are not yet old enough to delete
+	c.now = func() time.Time { return testNow.Add(29 * day) }
+
+	got, err := c.CleanGuild(ctx, "1")
+	if err != nil {
+		t.Fatalf("CleanGuild() error = %v", err)
+	}
+	if want := []Action{{"1", "closednew", RuleClosed}, {"1", "emptynew", RuleEmpty}}; !reflect.DeepEqual(sortedActions(got), want) {
+		t.Errorf("CleanGuild() a month later = %v, want %v", got, want)
+	}
+}
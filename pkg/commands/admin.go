@@ -1,15 +1,16 @@
 package commands
 
 import (
-	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/parser"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 // ErrGuildNotFound is the error returned when a guild is not known about
 // in a BotSession
-var ErrGuildNotFound = errors.New("guild not found")
+var ErrGuildNotFound = i18n.NewError("err.guild_not_found")
 
 type adminCommands struct {
 	preCommand string
@@ -35,20 +36,29 @@ func AdminCommandHandler(deps adminDependencies, preCommand string) (*cmdhandler
 		return nil, err
 	}
 
-	ch.SetHandler("list", cmdhandler.NewMessageHandler(cc.list))
-	ch.SetHandler("create", cmdhandler.NewMessageHandler(cc.create))
-	ch.SetHandler("edit", cmdhandler.NewMessageHandler(cc.edit))
-	ch.SetHandler("open", cmdhandler.NewMessageHandler(cc.open))
-	ch.SetHandler("close", cmdhandler.NewMessageHandler(cc.close))
-	ch.SetHandler("delete", cmdhandler.NewMessageHandler(cc.delete))
-	ch.SetHandler("announce", cmdhandler.NewMessageHandler(cc.announce))
-	ch.SetHandler("grouping", cmdhandler.NewMessageHandler(cc.grouping))
-	ch.SetHandler("signup", cmdhandler.NewMessageHandler(cc.signup))
-	ch.SetHandler("su", cmdhandler.NewMessageHandler(cc.signup))
-	ch.SetHandler("withdraw", cmdhandler.NewMessageHandler(cc.withdraw))
-	ch.SetHandler("wd", cmdhandler.NewMessageHandler(cc.withdraw))
-	ch.SetHandler("clear", cmdhandler.NewMessageHandler(cc.clear))
-	ch.SetHandler("show", cmdhandler.NewMessageHandler(cc.show))
+	err = setHandlers(ch, adminHelp, map[string]cmdhandler.MessageHandlerFunc{
+		"list":       cc.list,
+		"create":     cc.create,
+		"edit":       cc.edit,
+		"open":       cc.open,
+		"close":      cc.close,
+		"lock":       cc.lock,
+		"cancel":     cc.cancel,
+		"reschedule": cc.reschedule,
+		"delete":     cc.delete,
+		"announce":   cc.announce,
+		"grouping":   cc.grouping,
+		"signup":     cc.signup,
+		"withdraw":   cc.withdraw,
+		"clear":      cc.clear,
+		"show":       cc.show,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch.SetHandler("", cmdhandler.NewMessageHandler(cc.help))
+	ch.SetHandler("help", cmdhandler.NewMessageHandler(cc.help))
 
 	return ch, nil
 }
@@ -5,9 +5,9 @@ import (
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
-	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -33,7 +33,9 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -43,11 +45,11 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	trialName := msg.Contents()[0]
-	phrase := strings.Join(msg.Contents()[1:], " ")
+	phrase := joinPhrase(msg.Contents()[1:])
 
 	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
 	if err != nil {
@@ -55,10 +57,11 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
 	sessionGuild, ok := c.deps.BotSession().Guild(msg.GuildID())
 	if !ok {
@@ -68,11 +71,11 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 	var signupCid snowflake.Snowflake
 	var announceCid snowflake.Snowflake
 
-	if scID, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(msg.Context())); ok {
+	if scID, ok := msghandler.ChannelID(&sessionGuild, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context())); ok {
 		signupCid = scID
 	}
 
-	if acID, ok := sessionGuild.ChannelWithName(trial.GetAnnounceChannel(msg.Context())); ok {
+	if acID, ok := msghandler.ChannelID(&sessionGuild, trial.GetAnnounceChannelID(msg.Context()), trial.GetAnnounceChannel(msg.Context())); ok {
 		announceCid = acID
 	}
 
@@ -96,11 +99,11 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 	r2 := &cmdhandler.EmbedResponse{
 		To:          fmt.Sprintf("%s %s", toStr, phrase),
 		ToChannel:   announceCid,
-		Title:       fmt.Sprintf("Signups are open for %s", trial.GetName(msg.Context())),
+		Title:       p.Sprintf("announce.title", trial.GetName(msg.Context())),
 		Description: trial.GetDescription(msg.Context()),
 		Fields: []cmdhandler.EmbedField{
 			{
-				Name: "Roles Requested",
+				Name: p.Sprintf("announce.roles"),
 				Val:  fmt.Sprintf("```\n%s\n```\n", strings.Join(roleStrs, "\n")),
 			},
 		},
@@ -108,12 +111,12 @@ func (c *adminCommands) announce(msg cmdhandler.Message) (cmdhandler.Response, e
 
 	if signupCid != 0 {
 		r2.Fields = append(r2.Fields, cmdhandler.EmbedField{
-			Name: "Signup Channel",
+			Name: p.Sprintf("announce.signup_channel"),
 			Val:  cmdhandler.ChannelMentionString(signupCid),
 		})
 	}
 
 	level.Info(logger).Message("trial announced", "trial_name", trialName, "announce_channel", r2.ToChannel.ToString(), "announce_to", r2.To)
 
-	return r2, nil
+	return withComponents(msg.Context(), p, r2, trial, false), nil
 }
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+)
+
+func (c *adminCommands) cancel(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.cancel", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "cancel", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return nil, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.need_event_name")
+	}
+
+	trialName := msg.Contents()[0]
+	reason := joinPhrase(msg.Contents()[1:])
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
+	if err != nil {
+		return r, err
+	}
+	trialName = trial.GetName(msg.Context())
+
+	if trial.GetState(msg.Context()) == storage.TrialStateCanceled {
+		return r, i18n.NewError("err.canceled", trialName)
+	}
+
+	// the signups are kept, so that they can be told and the event opened again
+	userMentions := groupingMentions(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+
+	trial.SetState(msg.Context(), storage.TrialStateCanceled)
+	trial.SetCancelReason(msg.Context(), reason)
+
+	if err = t.SaveTrial(msg.Context(), trial); err != nil {
+		return r, errors.Wrap(err, "could not cancel event")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not cancel event")
+	}
+
+	level.Info(logger).Message("trial canceled", "trial_name", trialName, "signups", len(userMentions))
+
+	ev := trialEvent(msg, webhook.EventCancel, trialName)
+	ev.State = string(trial.GetState(msg.Context()))
+	ev.Users = userMentions
+	ev.Reason = reason
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	phrase := p.Sprintf("admin.canceled", trialName)
+	if reason != "" {
+		phrase = p.Sprintf("admin.canceled_reason", trialName, reason)
+	}
+	c.deps.Notifier().Notify(msg.Context(), TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindCancel, phrase, userMentions)...)
+
+	pingSignups(msg, c.deps.BotSession(), r, trial, userMentions, phrase)
+
+	return r, nil
+}
+
+// pingSignups addresses a response to the signups of a trial in its announce channel, like
+// grouping, or to the admin alone when nobody is signed up
+func pingSignups(msg cmdhandler.Message, session *etfapi.Session, r *cmdhandler.SimpleEmbedResponse, trial storage.Trial, userMentions []string, phrase string) {
+	if len(userMentions) == 0 {
+		r.Description = phrase
+		return
+	}
+
+	var announceCid snowflake.Snowflake
+	if sessionGuild, ok := session.Guild(msg.GuildID()); ok {
+		if acID, ok := msghandler.ChannelID(&sessionGuild, trial.GetAnnounceChannelID(msg.Context()), trial.GetAnnounceChannel(msg.Context())); ok {
+			announceCid = acID
+		}
+	}
+
+	r.To = fmt.Sprintf("%s\n\n%s", strings.Join(userMentions, ", "), phrase)
+	r.ToChannel = announceCid
+}
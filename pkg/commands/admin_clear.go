@@ -1,12 +1,11 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -31,7 +30,9 @@ func (c *adminCommands) clear(msg cmdhandler.Message) (cmdhandler.Response, erro
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,11 +42,11 @@ func (c *adminCommands) clear(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	trialName := msg.Contents()[0]
@@ -56,10 +57,11 @@ func (c *adminCommands) clear(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	trial, err := getTrialExact(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
 	trial.ClearSignups(msg.Context())
 
@@ -72,7 +74,7 @@ func (c *adminCommands) clear(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 
 	level.Info(logger).Message("trial cleared", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Event %q cleared successfully", trialName)
+	r.Description = p.Sprintf("admin.cleared", trialName)
 
 	return r, nil
 }
@@ -1,14 +1,16 @@
 package commands
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -31,7 +33,9 @@ func (c *adminCommands) close(msg cmdhandler.Message) (cmdhandler.Response, erro
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,11 +45,11 @@ func (c *adminCommands) close(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	trialName := msg.Contents()[0]
@@ -56,12 +60,24 @@ func (c *adminCommands) close(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
+
+	if trial.GetState(msg.Context()) == storage.TrialStateCanceled {
+		return r, i18n.NewError("err.canceled", trialName)
+	}
 
+	if trial.GetState(msg.Context()) != storage.TrialStateClosed {
+		lockRoster(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+	}
 	trial.SetState(msg.Context(), storage.TrialStateClosed)
+	// closing again ends the withdrawals allowed by a reschedule
+	trial.SetRescheduledAt(msg.Context(), time.Time{})
 
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not close event")
@@ -72,7 +88,12 @@ func (c *adminCommands) close(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 
 	level.Info(logger).Message("trial closed", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Closed event %q", trialName)
+
+	ev := trialEvent(msg, webhook.EventClose, trial.GetName(msg.Context()))
+	ev.State = string(trial.GetState(msg.Context()))
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	r.Description = p.Sprintf("admin.closed", trialName)
 
 	return r, nil
 }
@@ -1,14 +1,14 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -31,7 +31,9 @@ func (c *adminCommands) create(msg cmdhandler.Message) (cmdhandler.Response, err
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,24 +43,26 @@ func (c *adminCommands) create(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	trialName := msg.Contents()[0]
-	settings := msg.Contents()[1:]
-
-	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	settingMap, err := parseSettingDescriptionArgs(msg.Contents()[1:])
 	if err != nil {
 		return r, err
 	}
-	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.AddTrial(msg.Context(), trialName)
+	if err = applyPreset(msg.Context(), c.deps.GuildAPI(), msg.GuildID().ToString(), settingMap); err != nil {
+		return r, err
+	}
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
 	if err != nil {
 		return r, err
 	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	settingMap, err := parseSettingDescriptionArgs(settings)
+	trial, err := t.AddTrial(msg.Context(), trialName)
 	if err != nil {
 		return r, err
 	}
@@ -67,20 +71,21 @@ func (c *adminCommands) create(msg cmdhandler.Message) (cmdhandler.Response, err
 	trial.SetDescription(msg.Context(), settingMap["description"])
 	trial.SetState(msg.Context(), storage.TrialStateOpen)
 
-	if v, ok := settingMap["announcechannel"]; !ok {
-		trial.SetAnnounceChannel(msg.Context(), gsettings.AnnounceChannel)
-	} else {
-		trial.SetAnnounceChannel(msg.Context(), v)
+	trial.SetAnnounceChannel(msg.Context(), gsettings.AnnounceChannel)
+	trial.SetAnnounceChannelID(msg.Context(), gsettings.AnnounceChannelID)
+	trial.SetSignupChannel(msg.Context(), gsettings.SignupChannel)
+	trial.SetSignupChannelID(msg.Context(), gsettings.SignupChannelID)
+
+	if err = setChannels(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap); err != nil {
+		return r, err
 	}
 
 	if v, ok := settingMap["announceto"]; ok {
 		trial.SetAnnounceTo(msg.Context(), v)
 	}
 
-	if v, ok := settingMap["signupchannel"]; !ok {
-		trial.SetSignupChannel(msg.Context(), gsettings.SignupChannel)
-	} else {
-		trial.SetSignupChannel(msg.Context(), v)
+	if err = setSchedule(msg.Context(), trial, settingMap); err != nil {
+		return r, err
 	}
 
 	roleCtEmoList, err := parseRolesString(settingMap["roles"])
@@ -90,9 +95,23 @@ func (c *adminCommands) create(msg cmdhandler.Message) (cmdhandler.Response, err
 	for _, rce := range roleCtEmoList {
 		if rce.ct != 0 {
 			trial.SetRoleCount(msg.Context(), rce.role, rce.emo, rce.ct)
+		} else {
+			trial.RemoveRole(msg.Context(), rce.role)
 		}
 	}
 
+	if err = setAliases(msg.Context(), trial, settingMap); err != nil {
+		return r, err
+	}
+
+	if err = setReservations(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap); err != nil {
+		return r, err
+	}
+
+	if _, err = setPolicy(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap); err != nil {
+		return r, err
+	}
+
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not save event")
 	}
@@ -102,7 +121,12 @@ func (c *adminCommands) create(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	level.Info(logger).Message("trial created", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Event %q created successfully", trialName)
+
+	ev := trialEvent(msg, webhook.EventCreate, trial.GetName(msg.Context()))
+	ev.State = string(trial.GetState(msg.Context()))
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	r.Description = p.Sprintf("admin.created", trialName)
 
 	return r, nil
 }
@@ -1,14 +1,15 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -31,7 +32,9 @@ func (c *adminCommands) delete(msg cmdhandler.Message) (cmdhandler.Response, err
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,11 +44,11 @@ func (c *adminCommands) delete(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	trialName := msg.Contents()[0]
@@ -56,7 +59,21 @@ func (c *adminCommands) delete(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	if err = t.DeleteTrial(msg.Context(), trialName); err != nil {
+	// the members signed up are told of the cancellation once the event is gone
+	var notices []notify.Notice
+	if trial, terr := t.GetTrial(msg.Context(), trialName); terr == nil {
+		users := make([]string, 0, len(trial.GetSignups(msg.Context())))
+		for _, su := range trial.GetSignups(msg.Context()) {
+			users = append(users, su.GetName(msg.Context()))
+		}
+		notices = TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindCancel, p.Sprintf("dm.canceled", trial.GetName(msg.Context())), users)
+	}
+
+	err = t.DeleteTrial(msg.Context(), trialName)
+	if err == storage.ErrTrialNotExist {
+		return r, trialNotFound(msg.Context(), trialName, t.GetTrials(msg.Context()))
+	}
+	if err != nil {
 		return r, errors.Wrap(err, "could not delete event")
 	}
 
@@ -65,7 +82,10 @@ func (c *adminCommands) delete(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	level.Info(logger).Message("trial deleted", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Deleted event %q", trialName)
+	c.deps.Webhooks().Notify(msg.Context(), trialEvent(msg, webhook.EventDelete, trialName))
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+
+	r.Description = p.Sprintf("admin.deleted", trialName)
 
 	return r, nil
 }
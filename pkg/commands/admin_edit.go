@@ -1,12 +1,13 @@
 package commands
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -31,7 +32,9 @@ func (c *adminCommands) edit(msg cmdhandler.Message) (cmdhandler.Response, error
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,42 +44,45 @@ func (c *adminCommands) edit(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	trialName := msg.Contents()[0]
-	settings := msg.Contents()[1:]
-
-	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	settingMap, err := parseSettingDescriptionArgs(msg.Contents()[1:])
 	if err != nil {
 		return r, err
 	}
-	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
 	if err != nil {
 		return r, err
 	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	settingMap, err := parseSettingDescriptionArgs(settings)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
+
+	overflowBefore := OverflowSignups(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 
 	if v, ok := settingMap["description"]; ok {
 		trial.SetDescription(msg.Context(), v)
 	}
 
-	if v, ok := settingMap["announcechannel"]; ok {
-		trial.SetAnnounceChannel(msg.Context(), v)
+	if err = setChannels(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap); err != nil {
+		return r, err
 	}
 
 	if v, ok := settingMap["announceto"]; ok {
 		trial.SetAnnounceTo(msg.Context(), v)
 	}
 
-	if v, ok := settingMap["signupchannel"]; ok {
-		trial.SetSignupChannel(msg.Context(), v)
+	if err = setSchedule(msg.Context(), trial, settingMap); err != nil {
+		return r, err
 	}
 
 	roleCtEmoList, err := parseRolesString(settingMap["roles"])
@@ -91,6 +97,26 @@ func (c *adminCommands) edit(msg cmdhandler.Message) (cmdhandler.Response, error
 		}
 	}
 
+	if err = setAliases(msg.Context(), trial, settingMap); err != nil {
+		return r, err
+	}
+
+	if err = setReservations(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap); err != nil {
+		return r, err
+	}
+
+	reorder, err := setPolicy(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, settingMap)
+	if err != nil {
+		return r, err
+	}
+
+	if reorder && len(trial.GetRosterOrder(msg.Context())) > 0 {
+		trial.SetRosterOrder(msg.Context(), nil)
+		lockRoster(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+	}
+
+	notices := PromotionNotices(msg.Context(), c.deps.BotSession(), p, msg.GuildID(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()), overflowBefore)
+
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not save event")
 	}
@@ -100,7 +126,8 @@ func (c *adminCommands) edit(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	level.Info(logger).Message("trial edited", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Trial %s edited successfully", trialName)
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+	r.Description = p.Sprintf("admin.edited", trialName)
 
 	return r, nil
 }
@@ -1,13 +1,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
-	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -33,7 +35,9 @@ func (c *adminCommands) grouping(msg cmdhandler.Message) (cmdhandler.Response, e
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -43,13 +47,13 @@ func (c *adminCommands) grouping(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	trialName := msg.Contents()[0]
-	phrase := fmt.Sprintf("Grouping now for %s!", trialName)
+	phrase := p.Sprintf("admin.grouping", trialName)
 	if len(msg.Contents()) > 1 {
-		phrase = strings.Join(msg.Contents()[1:], " ")
+		phrase = joinPhrase(msg.Contents()[1:])
 	}
 
 	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
@@ -58,10 +62,13 @@ func (c *adminCommands) grouping(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
 	sessionGuild, ok := c.deps.BotSession().Guild(msg.GuildID())
 	if !ok {
@@ -69,21 +76,11 @@ func (c *adminCommands) grouping(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 
 	var announceCid snowflake.Snowflake
-	if acID, ok := sessionGuild.ChannelWithName(trial.GetAnnounceChannel(msg.Context())); ok {
+	if acID, ok := msghandler.ChannelID(&sessionGuild, trial.GetAnnounceChannelID(msg.Context()), trial.GetAnnounceChannel(msg.Context())); ok {
 		announceCid = acID
 	}
 
-	roleCounts := trial.GetRoleCounts(msg.Context()) // already sorted by name
-	signups := trial.GetSignups(msg.Context())
-
-	userMentions := make([]string, 0, len(signups))
-
-	for _, rc := range roleCounts {
-		suNames, ofNames := getTrialRoleSignups(msg.Context(), signups, rc)
-
-		userMentions = append(userMentions, suNames...)
-		userMentions = append(userMentions, ofNames...)
-	}
+	userMentions := groupingMentions(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 
 	fmt.Printf("** %v\n", userMentions)
 
@@ -96,3 +93,20 @@ func (c *adminCommands) grouping(msg cmdhandler.Message) (cmdhandler.Response, e
 
 	return r, nil
 }
+
+// groupingMentions are the members signed up for a trial, main group before overflow in
+// each role, as they are pinged by grouping and told of a cancel or reschedule
+func groupingMentions(ctx context.Context, trial storage.Trial, rules RosterRules) []string {
+	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
+	signups := trial.GetSignups(ctx)
+
+	userMentions := make([]string, 0, len(signups))
+	for _, rc := range roleCounts {
+		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc, rules)
+
+		userMentions = append(userMentions, suNames...)
+		userMentions = append(userMentions, ofNames...)
+	}
+
+	return userMentions
+}
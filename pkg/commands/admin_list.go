@@ -8,6 +8,7 @@ import (
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -32,7 +33,9 @@ func (c *adminCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -51,9 +54,12 @@ func (c *adminCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error
 	tNamesOpen := make([]string, 0, len(trials))
 	tNamesClosed := make([]string, 0, len(trials))
 	for _, trial := range trials {
-		if trial.GetState(msg.Context()) == storage.TrialStateClosed {
+		switch trial.GetState(msg.Context()) {
+		case storage.TrialStateClosed:
 			tNamesClosed = append(tNamesClosed, fmt.Sprintf("%s (#%s)", trial.GetName(msg.Context()), trial.GetSignupChannel(msg.Context())))
-		} else {
+		case storage.TrialStateCanceled:
+			tNamesClosed = append(tNamesClosed, fmt.Sprintf("%s (#%s) - %s", trial.GetName(msg.Context()), trial.GetSignupChannel(msg.Context()), stateName(p, storage.TrialStateCanceled)))
+		default:
 			tNamesOpen = append(tNamesOpen, fmt.Sprintf("%s (#%s)", trial.GetName(msg.Context()), trial.GetSignupChannel(msg.Context())))
 		}
 	}
@@ -62,11 +68,11 @@ func (c *adminCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error
 
 	r.Fields = []cmdhandler.EmbedField{
 		{
-			Name: "*Available Trials*",
+			Name: p.Sprintf("list.open"),
 			Val:  fmt.Sprintf("```\n%s\n```\n", strings.Join(tNamesOpen, "\n")),
 		},
 		{
-			Name: "*Closed Trials*",
+			Name: p.Sprintf("list.closed"),
 			Val:  fmt.Sprintf("```\n%s\n```\n", strings.Join(tNamesClosed, "\n")),
 		},
 	}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+)
+
+func (c *adminCommands) lock(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.lock", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "lock", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return nil, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.need_event_name")
+	}
+
+	if len(msg.Contents()) > 2 {
+		return r, i18n.NewError("err.too_many_arguments")
+	}
+
+	trialName := msg.Contents()[0]
+
+	var deadlineArg string
+	if len(msg.Contents()) > 1 {
+		deadlineArg = msg.Contents()[1]
+	}
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
+	if err != nil {
+		return r, err
+	}
+	trialName = trial.GetName(msg.Context())
+
+	if trial.GetState(msg.Context()) == storage.TrialStateCanceled {
+		return r, i18n.NewError("err.canceled", trialName)
+	}
+
+	if !trial.GetLockedRoster(msg.Context()).IsZero() {
+		return r, i18n.NewError("err.already_locked", trialName)
+	}
+
+	now := time.Now()
+
+	deadline, err := lockDeadline(msg.Context(), trial, deadlineArg, now)
+	if err != nil {
+		return r, err
+	}
+
+	wasOpen := trial.GetState(msg.Context()) != storage.TrialStateClosed
+	if wasOpen {
+		lockRoster(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, now))
+	}
+	trial.SetState(msg.Context(), storage.TrialStateClosed)
+	trial.SetRescheduledAt(msg.Context(), time.Time{})
+
+	// the rules are read again so that the snapshot follows the order stored above
+	lr := snapshotRoster(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, now), now, deadline)
+	trial.SetLockedRoster(msg.Context(), lr)
+
+	if err = t.SaveTrial(msg.Context(), trial); err != nil {
+		return r, errors.Wrap(err, "could not lock event")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not lock event")
+	}
+
+	level.Info(logger).Message("trial locked", "trial_name", trialName, "deadline", deadline, "locked_signups", len(lr.Signups))
+
+	if wasOpen {
+		ev := trialEvent(msg, webhook.EventClose, trial.GetName(msg.Context()))
+		ev.State = string(trial.GetState(msg.Context()))
+		c.deps.Webhooks().Notify(msg.Context(), ev)
+	}
+
+	mainGroup := make([]string, 0, len(lr.Signups))
+	for _, ls := range lr.Signups {
+		if !ls.Overflow {
+			mainGroup = append(mainGroup, ls.Name)
+		}
+	}
+
+	r.Description = p.Sprintf("admin.locked", trialName, formatTime(deadline), trialName)
+
+	// like a grouping, the main group is pinged in the announce channel
+	if len(mainGroup) > 0 {
+		r.To = strings.Join(mainGroup, ", ")
+
+		if sessionGuild, ok := c.deps.BotSession().Guild(msg.GuildID()); ok {
+			if acID, ok := msghandler.ChannelID(&sessionGuild, trial.GetAnnounceChannelID(msg.Context()), trial.GetAnnounceChannel(msg.Context())); ok {
+				r.ToChannel = acID
+			}
+		}
+	}
+
+	return r, nil
+}
@@ -1,14 +1,14 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -31,7 +31,9 @@ func (c *adminCommands) open(msg cmdhandler.Message) (cmdhandler.Response, error
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -41,11 +43,11 @@ func (c *adminCommands) open(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	trialName := msg.Contents()[0]
@@ -56,12 +58,16 @@ func (c *adminCommands) open(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
 	trial.SetState(msg.Context(), storage.TrialStateOpen)
+	trial.SetRosterOrder(msg.Context(), nil)
+	trial.SetLockedRoster(msg.Context(), storage.LockedRoster{})
+	trial.SetCancelReason(msg.Context(), "")
 
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not open event")
@@ -72,7 +78,12 @@ func (c *adminCommands) open(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	level.Info(logger).Message("trial opened", "trial_name", trialName)
-	r.Description = fmt.Sprintf("Opened event %q", trialName)
+
+	ev := trialEvent(msg, webhook.EventOpen, trial.GetName(msg.Context()))
+	ev.State = string(trial.GetState(msg.Context()))
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	r.Description = p.Sprintf("admin.opened", trialName)
 
 	return r, nil
 }
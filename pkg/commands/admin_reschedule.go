@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+)
+
+func (c *adminCommands) reschedule(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.reschedule", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "reschedule", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return nil, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.need_event_name")
+	}
+
+	if len(msg.Contents()) < 2 {
+		return r, i18n.NewError("err.need_start")
+	}
+
+	// the start may be written with spaces, like 2026-11-01 18:00
+	trialName, start := msg.Contents()[0], strings.Join(msg.Contents()[1:], " ")
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
+	if err != nil {
+		return r, err
+	}
+	trialName = trial.GetName(msg.Context())
+
+	if trial.GetState(msg.Context()) == storage.TrialStateCanceled {
+		return r, i18n.NewError("err.canceled", trialName)
+	}
+
+	if err = setSchedule(msg.Context(), trial, map[string]string{"start": start}); err != nil {
+		return r, err
+	}
+	newStart := trial.GetStartTime(msg.Context())
+
+	now := time.Now()
+	trial.SetRescheduledAt(msg.Context(), now)
+
+	userMentions := groupingMentions(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, now))
+
+	if err = t.SaveTrial(msg.Context(), trial); err != nil {
+		return r, errors.Wrap(err, "could not reschedule event")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not reschedule event")
+	}
+
+	level.Info(logger).Message("trial rescheduled", "trial_name", trialName, "start", newStart, "signups", len(userMentions))
+
+	ev := trialEvent(msg, webhook.EventReschedule, trialName)
+	ev.Users = userMentions
+	ev.Start = newStart.Format(time.RFC3339)
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	phrase := p.Sprintf("admin.rescheduled", trialName, formatTime(newStart), trialName)
+	c.deps.Notifier().Notify(msg.Context(), TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindReschedule, phrase, userMentions)...)
+
+	pingSignups(msg, c.deps.BotSession(), r, trial, userMentions, phrase)
+
+	return r, nil
+}
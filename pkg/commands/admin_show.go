@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
-	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -29,7 +32,9 @@ func (c *adminCommands) show(msg cmdhandler.Message) (cmdhandler.Response, error
 		return r, err
 	}
 
-	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+	p := i18n.For(gsettings.Locale)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -39,11 +44,11 @@ func (c *adminCommands) show(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("need event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	trialName := msg.Contents()[0]
@@ -54,12 +59,22 @@ func (c *adminCommands) show(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
-	r.Description = trial.PrettySettings(msg.Context())
+	r.Description = trial.PrettySettings(msg.Context(), p)
+
+	if signups := trial.GetSignups(msg.Context()); len(signups) > 0 {
+		lines := make([]string, 0, len(signups)+1)
+		lines = append(lines, p.Sprintf("admin.signups"))
+		for _, su := range signups {
+			lines = append(lines, fmt.Sprintf("- %s (%s)", signupLine(su.GetName(msg.Context()), su.GetDetails(msg.Context())), su.GetRole(msg.Context())))
+		}
+		r.Description += strings.Join(lines, "\n")
+	}
 
 	level.Info(logger).Message("trial shown", "trial_name", trialName)
 
@@ -3,14 +3,18 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 	multierror "github.com/hashicorp/go-multierror"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -34,12 +38,14 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 		return r, err
 	}
 
+	p := i18n.For(gsettings.Locale)
+
 	if msg.ContentErr() != nil {
 		return r, msg.ContentErr()
 	}
 
 	if len(msg.Contents()) < 3 {
-		return r, errors.New("not enough arguments (need `event-name role user-mention(s)`")
+		return r, i18n.NewError("err.admin_signup_usage")
 	}
 
 	trialName := msg.Contents()[0]
@@ -50,17 +56,24 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
-	if !isSignupChannel(logger, msg, trial.GetSignupChannel(msg.Context()), gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+	if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin or signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
 		return nil, msghandler.ErrUnauthorized
 	}
 
-	role := msg.Contents()[1]
+	role, err := ResolveRole(msg.Context(), trial, msg.Contents()[1])
+	if err != nil {
+		return r, err
+	}
+
 	userMentions := make([]string, 0, len(msg.Contents())-2)
 
 	for _, m := range msg.Contents()[2:] {
@@ -79,11 +92,11 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	if len(userMentions) == 0 {
-		return r, errors.New("you must mention one or more users that you are trying to sign up (@...)")
+		return r, i18n.NewError("err.admin_signup_mentions")
 	}
 
 	if trial.GetState(msg.Context()) != storage.TrialStateOpen {
-		return r, errors.New("cannot sign up for a closed event")
+		return r, ErrSignupClosed
 	}
 
 	sessionGuild, ok := c.deps.BotSession().Guild(msg.GuildID())
@@ -92,7 +105,7 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 	}
 
 	var signupCid snowflake.Snowflake
-	if scID, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(msg.Context())); ok {
+	if scID, ok := msghandler.ChannelID(&sessionGuild, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context())); ok {
 		signupCid = scID
 	}
 
@@ -100,9 +113,10 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 	regularUsers := make([]string, 0, len(userMentions))
 	overflowUsers := make([]string, 0, len(userMentions))
 
+	rules := NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now())
 	for i, userMention := range userMentions {
 		var serr error
-		overflows[i], serr = signupUser(msg.Context(), trial, userMention, role)
+		overflows[i], serr = signupUser(msg.Context(), trial, userMention, role, rules)
 		if serr != nil {
 			err = multierror.Append(err, serr)
 			continue
@@ -127,22 +141,32 @@ func (c *adminCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, err
 		return r, errors.Wrap(err, "could not save event signup")
 	}
 
-	descStr := fmt.Sprintf("Signed up for %s in %s by %s\n\n", role, trialName, cmdhandler.UserMentionString(msg.UserID()))
+	ev := trialEvent(msg, webhook.EventSignup, trial.GetName(msg.Context()))
+	ev.Role = role
+	ev.Users = userMentions
+	ev.Overflow = overflowUsers
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+
+	notices := TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindSignup, SignupConfirmation(p, trialName, role, false), regularUsers)
+	notices = append(notices, TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindSignup, SignupConfirmation(p, trialName, role, true), overflowUsers)...)
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+
+	descStr := p.Sprintf("admin.signed_up", role, trialName, cmdhandler.UserMentionString(msg.UserID())) + "\n\n"
 	if len(regularUsers) > 0 {
-		descStr += fmt.Sprintf("**Main Group:** %s\n", strings.Join(regularUsers, ", "))
+		descStr += p.Sprintf("admin.main_group", strings.Join(regularUsers, ", ")) + "\n"
 	}
 	if len(overflowUsers) > 0 {
-		descStr += fmt.Sprintf("**Overflow:** %s\n", strings.Join(overflowUsers, ", "))
+		descStr += p.Sprintf("admin.overflow", strings.Join(overflowUsers, ", ")) + "\n"
 	}
 
 	if gsettings.ShowAfterSignup == "true" {
 		level.Debug(logger).Message("auto-show after signup", "trial_name", trialName)
 
-		r2 := formatTrialDisplay(msg.Context(), trial, true)
+		r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 		r2.To = strings.Join(userMentions, ", ")
 		r2.ToChannel = signupCid
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
-		return r2, nil
+		return withComponents(msg.Context(), p, r2, trial, false), nil
 	}
 
 	r.To = strings.Join(userMentions, ", ")
@@ -3,14 +3,17 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 	multierror "github.com/hashicorp/go-multierror"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -34,12 +37,14 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 		return r, err
 	}
 
+	p := i18n.For(gsettings.Locale)
+
 	if msg.ContentErr() != nil {
 		return r, msg.ContentErr()
 	}
 
 	if len(msg.Contents()) < 2 {
-		return r, errors.New("not enough arguments (need `trial-name user-mention(s)`")
+		return r, i18n.NewError("err.admin_withdraw_usage")
 	}
 
 	trialName := msg.Contents()[0]
@@ -50,12 +55,15 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
-	if !isSignupChannel(logger, msg, trial.GetSignupChannel(msg.Context()), gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+	if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in admin or signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
 		return nil, msghandler.ErrUnauthorized
 	}
@@ -78,11 +86,11 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 
 	if len(userMentions) == 0 {
-		return r, errors.New("you must mention one or more users that you are trying to withdraw (@...)")
+		return r, i18n.NewError("err.admin_withdraw_mentions")
 	}
 
-	if trial.GetState(msg.Context()) != storage.TrialStateOpen {
-		return r, errors.New("cannot withdraw from a closed event")
+	if !WithdrawAllowed(msg.Context(), trial) {
+		return r, ErrWithdrawClosed
 	}
 
 	sessionGuild, ok := c.deps.BotSession().Guild(msg.GuildID())
@@ -91,10 +99,12 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 	}
 
 	var signupCid snowflake.Snowflake
-	if scID, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(msg.Context())); ok {
+	if scID, ok := msghandler.ChannelID(&sessionGuild, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context())); ok {
 		signupCid = scID
 	}
 
+	overflowBefore := OverflowSignups(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+
 	for _, m := range userMentions {
 		userAcctMention, werr := cmdhandler.ForceUserAccountMention(m)
 		if err != nil {
@@ -110,6 +120,8 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 		return r, err
 	}
 
+	notices := PromotionNotices(msg.Context(), c.deps.BotSession(), p, msg.GuildID(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()), overflowBefore)
+
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not save event withdraw")
 	}
@@ -118,16 +130,21 @@ func (c *adminCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, e
 		return r, errors.Wrap(err, "could not save event withdraw")
 	}
 
-	descStr := fmt.Sprintf("Withdrawn from %s by %s", trialName, cmdhandler.UserMentionString(msg.UserID()))
+	ev := trialEvent(msg, webhook.EventWithdraw, trial.GetName(msg.Context()))
+	ev.Users = userMentions
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+
+	descStr := p.Sprintf("admin.withdrawn", trialName, cmdhandler.UserMentionString(msg.UserID()))
 
 	if gsettings.ShowAfterWithdraw == "true" {
 		level.Debug(logger).Message("auto-show after withdraw", "trial_name", trialName)
 
-		r2 := formatTrialDisplay(msg.Context(), trial, true)
+		r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 		r2.To = strings.Join(userMentions, ", ")
 		r2.ToChannel = signupCid
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
-		return r2, nil
+		return withComponents(msg.Context(), p, r2, trial, false), nil
 	}
 
 	r.To = strings.Join(userMentions, ", ")
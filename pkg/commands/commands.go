@@ -9,34 +9,47 @@ import (
 	"github.com/gsmcwhirter/go-util/v5/parser"
 	census "github.com/gsmcwhirter/go-util/v5/stats"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 )
 
 type dependencies interface {
 	Logger() logging.Logger
 	TrialAPI() storage.TrialAPI
 	GuildAPI() storage.GuildAPI
+	ProfileAPI() storage.ProfileAPI
 	BotSession() *etfapi.Session
+	Webhooks() webhook.Dispatcher
+	Notifier() notify.Notifier
 	Census() *census.Census
 }
 
 // Options is the way to specify the command indicator string
+//
+// CalendarURL is the public base url of the calendar feeds; !calendar can only attach
+// files when it is empty
 type Options struct {
 	CmdIndicator string
+	CalendarURL  string
 }
 
 // RootCommands holds the commands at the root level
 type userCommands struct {
-	deps dependencies
+	deps         dependencies
+	cmdIndicator string
+	calendarURL  string
 }
 
-// CommandHandler creates a new command handler for !list, !show, !signup, and !withdraw
+// CommandHandler creates a new command handler for !list, !show, !signup, !withdraw, !confirm, !calendar, !profile, !notify, !presets, and !help
 func CommandHandler(deps dependencies, versionStr string, opts Options) (*cmdhandler.CommandHandler, error) {
 	p := parser.NewParser(parser.Options{
 		CmdIndicator: opts.CmdIndicator,
 	})
 	rh := userCommands{
-		deps: deps,
+		deps:         deps,
+		cmdIndicator: opts.CmdIndicator,
+		calendarURL:  opts.CalendarURL,
 	}
 
 	ch, err := cmdhandler.NewCommandHandler(p, cmdhandler.Options{
@@ -46,12 +59,21 @@ func CommandHandler(deps dependencies, versionStr string, opts Options) (*cmdhan
 		return nil, err
 	}
 
-	ch.SetHandler("list", cmdhandler.NewMessageHandler(rh.list))
-	ch.SetHandler("show", cmdhandler.NewMessageHandler(rh.show))
-	ch.SetHandler("signup", cmdhandler.NewMessageHandler(rh.signup))
-	ch.SetHandler("su", cmdhandler.NewMessageHandler(rh.signup))
-	ch.SetHandler("withdraw", cmdhandler.NewMessageHandler(rh.withdraw))
-	ch.SetHandler("wd", cmdhandler.NewMessageHandler(rh.withdraw))
+	err = setHandlers(ch, userHelp, map[string]cmdhandler.MessageHandlerFunc{
+		"list":     rh.list,
+		"show":     rh.show,
+		"signup":   rh.signup,
+		"withdraw": rh.withdraw,
+		"confirm":  rh.confirm,
+		"calendar": rh.calendar,
+		"profile":  rh.profile,
+		"notify":   rh.notify,
+		"presets":  rh.presets,
+		"help":     rh.help,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return ch, nil
 }
@@ -61,6 +83,7 @@ type configDependencies interface {
 	GuildAPI() storage.GuildAPI
 	TrialAPI() storage.TrialAPI
 	BotSession() *etfapi.Session
+	Notifier() notify.Notifier
 	Census() *census.Census
 }
 
@@ -126,6 +149,8 @@ type adminDependencies interface {
 	GuildAPI() storage.GuildAPI
 	TrialAPI() storage.TrialAPI
 	BotSession() *etfapi.Session
+	Webhooks() webhook.Dispatcher
+	Notifier() notify.Notifier
 	Census() *census.Census
 }
 
@@ -1 +1,26 @@
 package commands
+
+import (
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+)
+
+func TestHelpIsInCatalog(t *testing.T) {
+	p := i18n.For(i18n.DefaultLocale)
+
+	for _, g := range helpGroups {
+		for _, h := range g.commands {
+			keys := []string{g.key(h) + ".summary", g.key(h) + ".examples"}
+			for _, a := range h.args {
+				keys = append(keys, a.key(g.key(h)))
+			}
+
+			for _, key := range keys {
+				if p.Sprintf(key) == key {
+					t.Errorf("command %q of %s has no catalog message %q", h.name, g.title, key)
+				}
+			}
+		}
+	}
+}
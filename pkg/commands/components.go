@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// discord limits on message components
+const (
+	maxButtonsPerRow = 5
+	maxButtonRows    = 4 // the fifth row is kept for the admin select menu
+	maxSelectOptions = 25
+	maxLabel         = 80
+)
+
+func truncateLabel(s string) string {
+	if r := []rune(s); len(r) > maxLabel {
+		return string(r[:maxLabel-1]) + "…"
+	}
+	return s
+}
+
+// signupButtons are a button per role and a withdraw button
+//
+// Roles that do not fit (or whose custom id would be too long) are left out; those
+// can still be signed up for with the text command
+func signupButtons(ctx context.Context, p i18n.Printer, trial storage.Trial) []msghandler.Component {
+	buttons := make([]msghandler.Component, 0, maxButtonsPerRow*maxButtonRows)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		if len(buttons) >= maxButtonsPerRow*maxButtonRows-1 {
+			break
+		}
+
+		id, ok := msghandler.ComponentID(msghandler.ActionSignup, trial.GetName(ctx), rc.GetRole(ctx))
+		if !ok {
+			continue
+		}
+
+		buttons = append(buttons, msghandler.Component{
+			Type:     msghandler.ComponentButton,
+			Style:    msghandler.ButtonPrimary,
+			Label:    truncateLabel(rc.GetRole(ctx)),
+			CustomID: id,
+		})
+	}
+
+	if id, ok := msghandler.ComponentID(msghandler.ActionWithdraw, trial.GetName(ctx)); ok {
+		buttons = append(buttons, msghandler.Component{
+			Type:     msghandler.ComponentButton,
+			Style:    msghandler.ButtonDanger,
+			Label:    p.Sprintf("button.withdraw"),
+			CustomID: id,
+		})
+	}
+
+	rows := make([]msghandler.Component, 0, maxButtonRows)
+	for len(buttons) > 0 {
+		n := maxButtonsPerRow
+		if len(buttons) < n {
+			n = len(buttons)
+		}
+
+		rows = append(rows, msghandler.Component{
+			Type:       msghandler.ComponentActionRow,
+			Components: buttons[:n],
+		})
+		buttons = buttons[n:]
+	}
+
+	return rows
+}
+
+// signupLabel is how a signup is shown in a select menu, which cannot render mentions
+func signupLabel(name string) string {
+	if acct, err := cmdhandler.ForceUserAccountMention(name); err == nil {
+		return "@" + strings.TrimSuffix(strings.TrimPrefix(acct, "<@"), ">")
+	}
+	return name
+}
+
+// removeMenu is a select menu of the trial's signups, for admins to remove people
+func removeMenu(ctx context.Context, p i18n.Printer, trial storage.Trial) (msghandler.Component, bool) {
+	id, ok := msghandler.ComponentID(msghandler.ActionRemove, trial.GetName(ctx))
+	if !ok {
+		return msghandler.Component{}, false
+	}
+
+	menu := msghandler.Component{
+		Type:        msghandler.ComponentSelectMenu,
+		CustomID:    id,
+		Placeholder: p.Sprintf("menu.remove"),
+		MinValues:   1,
+	}
+
+	for _, su := range trial.GetSignups(ctx) {
+		if len(menu.Options) >= maxSelectOptions {
+			break
+		}
+
+		menu.Options = append(menu.Options, msghandler.SelectOption{
+			Label:       truncateLabel(signupLabel(su.GetName(ctx))),
+			Value:       su.GetName(ctx),
+			Description: truncateLabel(su.GetRole(ctx)),
+		})
+	}
+
+	if len(menu.Options) == 0 {
+		return menu, false
+	}
+	menu.MaxValues = len(menu.Options)
+
+	return menu, true
+}
+
+// confirmButton is the button for the main group of a locked roster to confirm with
+func confirmButton(ctx context.Context, p i18n.Printer, trial storage.Trial) (msghandler.Component, bool) {
+	id, ok := msghandler.ComponentID(msghandler.ActionConfirm, trial.GetName(ctx))
+	if !ok {
+		return msghandler.Component{}, false
+	}
+
+	return msghandler.Component{
+		Type:     msghandler.ComponentButton,
+		Style:    msghandler.ButtonSuccess,
+		Label:    p.Sprintf("button.confirm"),
+		CustomID: id,
+	}, true
+}
+
+// withComponents attaches the roster components of an open trial to a response; withRemove
+// adds the admin select menu
+//
+// A locked trial only gets the confirm button, until its deadline passes
+func withComponents(ctx context.Context, p i18n.Printer, r *cmdhandler.EmbedResponse, trial storage.Trial, withRemove bool) cmdhandler.Response {
+	if trial.GetState(ctx) == storage.TrialStateCanceled {
+		return r
+	}
+
+	if lr := trial.GetLockedRoster(ctx); !lr.IsZero() {
+		button, ok := confirmButton(ctx, p, trial)
+		if !ok || !time.Now().Before(lr.Deadline) {
+			return r
+		}
+
+		return &msghandler.ComponentResponse{
+			EmbedResponse: *r,
+			Components: []msghandler.Component{{
+				Type:       msghandler.ComponentActionRow,
+				Components: []msghandler.Component{button},
+			}},
+		}
+	}
+
+	// every action on a closed trial would be refused
+	if trial.GetState(ctx) != storage.TrialStateOpen {
+		return r
+	}
+
+	rows := signupButtons(ctx, p, trial)
+
+	if withRemove {
+		if menu, ok := removeMenu(ctx, p, trial); ok {
+			rows = append(rows, msghandler.Component{
+				Type:       msghandler.ComponentActionRow,
+				Components: []msghandler.Component{menu},
+			})
+		}
+	}
+
+	if len(rows) == 0 {
+		return r
+	}
+
+	return &msghandler.ComponentResponse{
+		EmbedResponse: *r,
+		Components:    rows,
+	}
+}
@@ -38,14 +38,27 @@ func ConfigCommandHandler(deps configDependencies, versionStr, preCommand string
 		return nil, err
 	}
 
-	ch.SetHandler("list", cmdhandler.NewMessageHandler(cc.list))
-	ch.SetHandler("get", cmdhandler.NewMessageHandler(cc.get))
-	ch.SetHandler("set", cmdhandler.NewMessageHandler(cc.set))
-	ch.SetHandler("reset", cmdhandler.NewMessageHandler(cc.reset))
-	ch.SetHandler("version", cmdhandler.NewMessageHandler(cc.version))
-	ch.SetHandler("website", cmdhandler.NewMessageHandler(cc.website))
-	ch.SetHandler("discord", cmdhandler.NewMessageHandler(cc.discord))
-	ch.SetHandler("stats", cmdhandler.NewMessageHandler(cc.stats))
+	err = setHandlers(ch, configHelp, map[string]cmdhandler.MessageHandlerFunc{
+		"list":     cc.list,
+		"get":      cc.get,
+		"set":      cc.set,
+		"reset":    cc.reset,
+		"version":  cc.version,
+		"website":  cc.website,
+		"discord":  cc.discord,
+		"stats":    cc.stats,
+		"export":   cc.export,
+		"apitoken": cc.apitoken,
+		"webhook":  cc.webhook,
+		"preset":   cc.preset,
+		"doctor":   cc.doctor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch.SetHandler("", cmdhandler.NewMessageHandler(cc.help))
+	ch.SetHandler("help", cmdhandler.NewMessageHandler(cc.help))
 
 	return ch, err
 }
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func (c *configCommands) apitoken(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.apitoken", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "apitoken")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	revoke := false
+	switch {
+	case len(msg.Contents()) > 1:
+		return r, i18n.NewError("err.too_many_arguments")
+	case len(msg.Contents()) == 1 && strings.ToLower(msg.Contents()[0]) == "revoke":
+		revoke = true
+	case len(msg.Contents()) == 1:
+		return r, i18n.NewError("err.apitoken_argument", msg.Contents()[0])
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	var hash string
+	if !revoke {
+		var token string
+		var err error
+		token, hash, err = storage.NewAPIToken()
+		if err != nil {
+			return r, err
+		}
+
+		// the token can sign members up, so it goes to the admin by direct message, never to
+		// the channel, and is only saved once it arrived
+		err = c.deps.Notifier().Send(msg.Context(), notify.Notice{
+			GuildID: msg.GuildID(),
+			User:    cmdhandler.UserMentionString(msg.UserID()),
+			Kind:    notify.KindPrivate,
+			Text:    p.Sprintf("dm.apitoken", msg.GuildID().ToString(), token),
+		})
+		if err != nil {
+			level.Error(logger).Err("could not send api token", err)
+			return r, i18n.NewError("err.apitoken_undelivered")
+		}
+	}
+
+	t, err := c.deps.GuildAPI().NewTransaction(msg.Context(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	bGuild, err := t.AddGuild(msg.Context(), msg.GuildID().ToString())
+	if err != nil {
+		return r, errors.Wrap(err, "unable to find or add guild")
+	}
+
+	bGuild.SetAPITokenHash(msg.Context(), hash)
+
+	err = t.SaveGuild(msg.Context(), bGuild)
+	if err != nil {
+		return r, errors.Wrap(err, "could not save api token")
+	}
+
+	err = t.Commit(msg.Context())
+	if err != nil {
+		return r, errors.Wrap(err, "could not save api token")
+	}
+
+	if revoke {
+		r.Description = p.Sprintf("apitoken.revoked")
+		return r, nil
+	}
+
+	r.Description = p.Sprintf("apitoken.created")
+	return r, nil
+}
@@ -9,7 +9,8 @@ import (
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
-	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 )
 
 func (c *configCommands) debug(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -41,30 +42,14 @@ func (c *configCommands) debug(msg cmdhandler.Message) (cmdhandler.Response, err
 
 	s := bGuild.GetSettings(msg.Context())
 
-	var adminChannelID, announceChannelID, signupChannelID snowflake.Snowflake
-
 	g, ok := c.deps.BotSession().Guild(msg.GuildID())
 	if !ok {
 		return r, errors.New("could not find guild in session")
 	}
 
-	if s.AdminChannel != "" {
-		if cid, ok := g.ChannelWithName(s.AdminChannel); ok {
-			adminChannelID = cid
-		}
-	}
-
-	if s.AnnounceChannel != "" {
-		if cid, ok := g.ChannelWithName(s.AnnounceChannel); ok {
-			announceChannelID = cid
-		}
-	}
-
-	if s.SignupChannel != "" {
-		if cid, ok := g.ChannelWithName(s.SignupChannel); ok {
-			signupChannelID = cid
-		}
-	}
+	adminChannelID, _ := msghandler.ChannelID(&g, s.AdminChannelID, s.AdminChannel)
+	announceChannelID, _ := msghandler.ChannelID(&g, s.AnnounceChannelID, s.AnnounceChannel)
+	signupChannelID, _ := msghandler.ChannelID(&g, s.SignupChannelID, s.SignupChannel)
 
 	dbgString := fmt.Sprintf(`
 GuildSettings:
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// settingsProblems lists the guild settings that name channels or roles the guild no longer has
+func settingsProblems(p i18n.Printer, g *etfapi.Guild, s storage.GuildSettings) []string {
+	var problems []string
+
+	channels := []struct {
+		key, id, name string
+	}{
+		{"announcechannel", s.AnnounceChannelID, s.AnnounceChannel},
+		{"signupchannel", s.SignupChannelID, s.SignupChannel},
+		{"adminchannel", s.AdminChannelID, s.AdminChannel},
+	}
+
+	for _, ch := range channels {
+		if ch.id == "" && ch.name == "" {
+			continue
+		}
+
+		if _, ok := msghandler.ChannelID(g, ch.id, ch.name); !ok {
+			problems = append(problems, p.Sprintf("doctor.channel_missing", ch.key, ch.name))
+		}
+	}
+
+	// etfapi.Guild only looks roles up by name, so the admin role is checked by the name it
+	// was set with; it was saved as a name alone before role ids were kept
+	name, id := s.AdminRoleName, s.AdminRole
+	if _, err := snowflake.FromString(s.AdminRole); err != nil {
+		name, id = s.AdminRole, ""
+	}
+
+	if name != "" {
+		if rid, ok := g.RoleWithName(name); !ok || (id != "" && rid.ToString() != id) {
+			problems = append(problems, p.Sprintf("doctor.role_missing", name))
+		}
+	}
+
+	return problems
+}
+
+// settingsRolesByID lists the roles of the guild settings that are known only by id, which
+// doctor cannot look up; discord shows those that no longer exist as @deleted-role
+func settingsRolesByID(p i18n.Printer, s storage.GuildSettings) []string {
+	if s.AdminRoleName != "" {
+		return nil
+	}
+
+	if _, err := snowflake.FromString(s.AdminRole); err != nil {
+		return nil
+	}
+
+	return []string{p.Sprintf("doctor.admin_role", s.AdminRole)}
+}
+
+// trialRolesByID lists the reserve and tier roles of a trial, which are known only by id
+func trialRolesByID(ctx context.Context, p i18n.Printer, trial storage.Trial) []string {
+	var roles []string
+
+	name := trial.GetName(ctx)
+
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		if rid := rc.GetReservation(ctx).RoleID; rid != "" {
+			roles = append(roles, p.Sprintf("doctor.trial_reserve_role", name, rc.GetRole(ctx), rid))
+		}
+	}
+
+	for _, rid := range trial.GetPriorityRoles(ctx) {
+		roles = append(roles, p.Sprintf("doctor.trial_tier_role", name, rid))
+	}
+
+	return roles
+}
+
+// trialProblems lists the channels of a trial that the guild no longer has
+func trialProblems(ctx context.Context, p i18n.Printer, g *etfapi.Guild, trial storage.Trial) []string {
+	var problems []string
+
+	name := trial.GetName(ctx)
+
+	ac, acID := trial.GetAnnounceChannel(ctx), trial.GetAnnounceChannelID(ctx)
+	if ac != "" || acID != "" {
+		if _, ok := msghandler.ChannelID(g, acID, ac); !ok {
+			problems = append(problems, p.Sprintf("doctor.trial_channel_missing", name, "announcechannel", ac))
+		}
+	}
+
+	sc, scID := trial.GetSignupChannel(ctx), trial.GetSignupChannelID(ctx)
+	if sc == "" && scID == "" {
+		problems = append(problems, p.Sprintf("doctor.trial_no_signup_channel", name))
+	} else if _, ok := msghandler.ChannelID(g, scID, sc); !ok {
+		problems = append(problems, p.Sprintf("doctor.trial_channel_missing", name, "signupchannel", sc))
+	}
+
+	return problems
+}
+
+func formatProblems(p i18n.Printer, problems []string) string {
+	return p.Sprintf("doctor.problems") + "\n- " + strings.Join(problems, "\n- ")
+}
+
+func (c *configCommands) doctor(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.doctor", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "doctor")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) > 0 {
+		return r, i18n.NewError("err.too_many_arguments")
+	}
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	p := i18n.For(gsettings.Locale)
+
+	g, ok := c.deps.BotSession().Guild(msg.GuildID())
+	if !ok {
+		return r, ErrGuildNotFound
+	}
+
+	problems := settingsProblems(p, &g, gsettings)
+	roles := settingsRolesByID(p, gsettings)
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	for _, trial := range t.GetTrials(msg.Context()) {
+		problems = append(problems, trialProblems(msg.Context(), p, &g, trial)...)
+		roles = append(roles, trialRolesByID(msg.Context(), p, trial)...)
+	}
+
+	if len(problems) == 0 {
+		r.Description = p.Sprintf("doctor.ok")
+	} else {
+		r.Description = formatProblems(p, problems)
+	}
+
+	if len(roles) > 0 {
+		r.Description += "\n\n" + p.Sprintf("doctor.roles") + "\n- " + strings.Join(roles, "\n- ")
+	}
+
+	return r, nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func (c *configCommands) export(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.export", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &msghandler.FileResponse{
+		SimpleEmbedResponse: cmdhandler.SimpleEmbedResponse{
+			To: cmdhandler.UserMentionString(msg.UserID()),
+		},
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "export")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	doc, err := storage.ExportGuild(msg.Context(), c.deps.GuildAPI(), c.deps.TrialAPI(), msg.GuildID().ToString())
+	if err != nil {
+		return r, errors.Wrap(err, "could not export guild data")
+	}
+
+	r.FileData, err = doc.MarshalJSON()
+	if err != nil {
+		return r, errors.Wrap(err, "could not marshal guild data")
+	}
+
+	r.FileName = fmt.Sprintf("guild-%s-export.json", msg.GuildID().ToString())
+	r.Description = p.Sprintf("export.done", len(doc.Trials), doc.Version)
+	return r, nil
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 func (c *configCommands) get(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -29,11 +31,11 @@ func (c *configCommands) get(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("missing setting name")
+		return r, i18n.NewError("err.missing_setting")
 	}
 
 	if len(msg.Contents()) > 1 {
-		return r, errors.New("too many arguments")
+		return r, i18n.NewError("err.too_many_arguments")
 	}
 
 	settingName := strings.TrimSpace(msg.Contents()[0])
@@ -52,7 +54,7 @@ func (c *configCommands) get(msg cmdhandler.Message) (cmdhandler.Response, error
 	s := bGuild.GetSettings(msg.Context())
 	sVal, err := s.GetSettingString(msg.Context(), settingName)
 	if err != nil {
-		return r, fmt.Errorf("'%s' is not the name of a setting", settingName)
+		return r, i18n.NewError("err.unknown_setting", settingName)
 	}
 
 	r.Description = fmt.Sprintf("```\n%s: '%s'\n```", settingName, sVal)
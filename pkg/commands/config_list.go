@@ -7,6 +7,8 @@ import (
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 func (c *configCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -37,6 +39,15 @@ func (c *configCommands) list(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 
 	s := bGuild.GetSettings(msg.Context())
-	r.Description = s.PrettyString(msg.Context())
+	p := i18n.For(s.Locale)
+
+	r.Description = s.PrettyString(msg.Context(), p)
+
+	if g, ok := c.deps.BotSession().Guild(msg.GuildID()); ok {
+		if problems := settingsProblems(p, &g, s); len(problems) > 0 {
+			r.Description += "\n" + formatProblems(p, problems)
+		}
+	}
+
 	return r, nil
 }
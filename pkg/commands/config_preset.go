@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+const (
+	maxPresets          = 15
+	maxPresetNameLength = 32
+)
+
+// ErrTooManyPresets is the error returned when adding a preset to a guild that has the maximum number
+var ErrTooManyPresets = i18n.NewError("err.too_many_presets", maxPresets)
+
+// parsePreset builds a guild preset from `name roles=... description=...`, checking that
+// the roles can be used to create an event
+func parsePreset(args []string) (storage.Preset, error) {
+	if len(args) < 1 {
+		return storage.Preset{}, i18n.NewError("err.preset_need_name")
+	}
+
+	name := args[0]
+	if len([]rune(name)) > maxPresetNameLength {
+		return storage.Preset{}, i18n.NewError("err.preset_name_too_long", maxPresetNameLength)
+	}
+
+	argMap, err := parseSettingDescriptionArgs(args[1:])
+	if err != nil {
+		return storage.Preset{}, err
+	}
+
+	for k := range argMap {
+		if k != "roles" && k != "description" {
+			return storage.Preset{}, i18n.NewError("err.unknown_setting", k)
+		}
+	}
+
+	rces, err := parseRolesString(argMap["roles"])
+	if err != nil {
+		return storage.Preset{}, err
+	}
+
+	hasRole := false
+	for _, rce := range rces {
+		hasRole = hasRole || rce.ct != 0
+	}
+	if !hasRole {
+		return storage.Preset{}, i18n.NewError("err.preset_need_roles")
+	}
+
+	return storage.Preset{
+		Name:        name,
+		Description: argMap["description"],
+		Roles:       argMap["roles"],
+	}, nil
+}
+
+func presetIndex(presets []storage.Preset, name string) int {
+	for i, p := range presets {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *configCommands) preset(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.preset", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "preset", "args", msg.Contents())
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.preset_action")
+	}
+
+	action, args := strings.ToLower(msg.Contents()[0]), msg.Contents()[1:]
+
+	t, err := c.deps.GuildAPI().NewTransaction(msg.Context(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	bGuild, err := t.AddGuild(msg.Context(), msg.GuildID().ToString())
+	if err != nil {
+		return r, errors.Wrap(err, "unable to find or add guild")
+	}
+
+	p := i18n.For(bGuild.GetSettings(msg.Context()).Locale)
+	presets := bGuild.GetPresets(msg.Context())
+
+	switch action {
+	case "list":
+		r.Description = formatPresets(p, presets)
+		return r, nil
+
+	case "set":
+		preset, err := parsePreset(args)
+		if err != nil {
+			return r, err
+		}
+
+		if idx := presetIndex(presets, preset.Name); idx >= 0 {
+			presets[idx] = preset
+		} else if len(presets) >= maxPresets {
+			return r, ErrTooManyPresets
+		} else {
+			presets = append(presets, preset)
+		}
+		bGuild.SetPresets(msg.Context(), presets)
+
+		r.Description = p.Sprintf("presets.saved", formatPreset(preset))
+
+	case "remove":
+		if len(args) != 1 {
+			return r, i18n.NewError("err.preset_need_name")
+		}
+
+		idx := presetIndex(presets, args[0])
+		if idx < 0 {
+			return r, i18n.NewError("err.preset_not_found", args[0])
+		}
+
+		removed := presets[idx].Name
+		presets = append(presets[:idx], presets[idx+1:]...)
+		bGuild.SetPresets(msg.Context(), presets)
+
+		r.Description = p.Sprintf("presets.removed", removed)
+
+	default:
+		return r, i18n.NewError("err.preset_action")
+	}
+
+	if err = t.SaveGuild(msg.Context(), bGuild); err != nil {
+		return r, errors.Wrap(err, "could not save presets")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not save presets")
+	}
+
+	level.Info(logger).Message("presets updated", "action", action, "preset_ct", len(presets))
+
+	return r, nil
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
@@ -10,11 +9,9 @@ import (
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
-)
 
-type argPair struct {
-	key, val string
-}
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+)
 
 func (c *configCommands) set(msg cmdhandler.Message) (cmdhandler.Response, error) {
 	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.set", "guild_id", msg.GuildID().ToString())
@@ -32,43 +29,32 @@ func (c *configCommands) set(msg cmdhandler.Message) (cmdhandler.Response, error
 		return r, msg.ContentErr()
 	}
 
-	argPairs := make([]argPair, 0, len(msg.Contents()))
-
-	for _, arg := range msg.Contents() {
-		if arg == "" {
-			continue
-		}
-
-		argPairList := strings.SplitN(arg, "=", 2)
-		if len(argPairList) != 2 {
-			return r, fmt.Errorf("could not parse setting '%s'", arg)
-		}
-
-		ap := argPair{
-			key: argPairList[0],
-		}
+	argPairs, err := parseArgPairs(msg.Contents())
+	if err != nil {
+		return r, err
+	}
 
-		switch strings.ToLower(argPairList[0]) {
+	// channels and the admin role must exist in the guild, and are saved with their ids
+	ids := make([]string, len(argPairs))
+	for i, ap := range argPairs {
+		switch strings.ToLower(ap.key) {
 		case "adminrole":
-			g, ok := c.deps.BotSession().Guild(msg.GuildID())
-			if !ok {
-				return r, errors.New("could not find guild to look up role")
+			name, rid, err := resolveRole(c.deps.BotSession(), msg.GuildID(), ap.val)
+			if err != nil {
+				return r, err
 			}
-			rid, ok := g.RoleWithName(argPairList[1])
-			if !ok {
-				return r, fmt.Errorf("could not find role with name '%s'", argPairList[1])
+			argPairs[i].val, ids[i] = name, rid
+		case "announcechannel", "signupchannel", "adminchannel":
+			name, cid, err := resolveChannel(c.deps.BotSession(), msg.GuildID(), ap.val)
+			if err != nil {
+				return r, err
 			}
-
-			ap.val = rid.ToString()
-		default:
-			ap.val = argPairList[1]
+			argPairs[i].val, ids[i] = name, cid
 		}
-
-		argPairs = append(argPairs, ap)
 	}
 
 	if len(argPairs) == 0 {
-		return r, errors.New("no settings to save")
+		return r, i18n.NewError("err.no_settings")
 	}
 
 	t, err := c.deps.GuildAPI().NewTransaction(msg.Context(), true)
@@ -83,8 +69,15 @@ func (c *configCommands) set(msg cmdhandler.Message) (cmdhandler.Response, error
 	}
 
 	s := bGuild.GetSettings(msg.Context())
-	for _, ap := range argPairs {
-		err = s.SetSettingString(msg.Context(), ap.key, ap.val)
+	for i, ap := range argPairs {
+		switch strings.ToLower(ap.key) {
+		case "adminrole":
+			s.AdminRole, s.AdminRoleName = ids[i], ap.val
+		case "announcechannel", "signupchannel", "adminchannel":
+			err = s.SetChannel(msg.Context(), ap.key, ap.val, ids[i])
+		default:
+			err = s.SetSettingString(msg.Context(), ap.key, ap.val)
+		}
 		if err != nil {
 			return r, err
 		}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
@@ -26,10 +25,10 @@ func (c *configCommands) collectStats(ctx context.Context, gid string) (stat, er
 
 	for _, trial := range trials {
 		s.trials++
-		if trial.GetState(ctx) == storage.TrialStateClosed {
-			s.closed++
-		} else {
+		if trial.GetState(ctx) == storage.TrialStateOpen {
 			s.open++
+		} else {
+			s.closed++
 		}
 	}
 
@@ -52,6 +51,8 @@ func (c *configCommands) stats(msg cmdhandler.Message) (cmdhandler.Response, err
 		return r, msg.ContentErr()
 	}
 
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
 	allGuilds, err := c.deps.GuildAPI().AllGuilds(msg.Context())
 	if err != nil {
 		return r, err
@@ -70,6 +71,6 @@ func (c *configCommands) stats(msg cmdhandler.Message) (cmdhandler.Response, err
 		s.closed += st.closed
 	}
 
-	r.Description = fmt.Sprintf("Total guilds: %d\nTotal events: %d\nCurrently open: %d\nCurrently closed: %d\n", len(allGuilds), s.trials, s.open, s.closed)
+	r.Description = p.Sprintf("stats.summary", len(allGuilds), s.trials, s.open, s.closed)
 	return r, nil
 }
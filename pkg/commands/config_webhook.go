@@ -0,0 +1,276 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+)
+
+const maxWebhooks = 5
+
+// ErrTooManyWebhooks is the error returned when adding a webhook to a guild that has the maximum number
+var ErrTooManyWebhooks = i18n.NewError("err.too_many_webhooks", maxWebhooks)
+
+func parseWebhookURL(val string) (string, error) {
+	// discord users often wrap links in <> to suppress the preview
+	val = strings.TrimSuffix(strings.TrimPrefix(val, "<"), ">")
+
+	u, err := webhook.CheckURL(val)
+	switch err {
+	case nil:
+		return u.String(), nil
+	case webhook.ErrAddressHost:
+		return "", i18n.NewError("err.webhook_address_host", val)
+	case webhook.ErrForbiddenAddress:
+		return "", i18n.NewError("err.webhook_private_host", val)
+	default:
+		return "", i18n.NewError("err.webhook_url")
+	}
+}
+
+// checkWebhookHost makes sure the host of a webhook resolves only to public addresses
+func checkWebhookHost(ctx context.Context, hookURL string) error {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return errors.Wrap(err, "could not parse webhook url")
+	}
+
+	switch err = webhook.CheckHost(ctx, u.Hostname()); err {
+	case nil:
+		return nil
+	case webhook.ErrForbiddenAddress:
+		return i18n.NewError("err.webhook_private_host", u.Hostname())
+	default:
+		return i18n.NewError("err.webhook_unresolved_host", u.Hostname())
+	}
+}
+
+func (c *configCommands) webhook(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.webhook", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "webhook", "args", len(msg.Contents()))
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.webhook_action")
+	}
+
+	action, args := strings.ToLower(msg.Contents()[0]), msg.Contents()[1:]
+
+	t, err := c.deps.GuildAPI().NewTransaction(msg.Context(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	bGuild, err := t.AddGuild(msg.Context(), msg.GuildID().ToString())
+	if err != nil {
+		return r, errors.Wrap(err, "unable to find or add guild")
+	}
+
+	p := i18n.For(bGuild.GetSettings(msg.Context()).Locale)
+	hooks := bGuild.GetWebhooks(msg.Context())
+
+	// the signing secret goes to the admin by direct message, never to the channel
+	var secretNotice *notify.Notice
+
+	switch action {
+	case "list":
+		r.Description = formatWebhooks(p, hooks)
+		return r, nil
+
+	case "add":
+		if len(args) < 1 {
+			return r, i18n.NewError("err.webhook_need_url")
+		}
+
+		if len(args) > 2 {
+			return r, i18n.NewError("err.too_many_arguments")
+		}
+
+		if len(hooks) >= maxWebhooks {
+			return r, ErrTooManyWebhooks
+		}
+
+		hookURL, err := parseWebhookURL(args[0])
+		if err != nil {
+			return r, err
+		}
+
+		if err = checkWebhookHost(msg.Context(), hookURL); err != nil {
+			return r, err
+		}
+
+		for _, h := range hooks {
+			if h.URL == hookURL {
+				return r, i18n.NewError("err.webhook_exists")
+			}
+		}
+
+		argMap, err := parseSettingDescriptionArgs(args[1:])
+		if err != nil {
+			return r, err
+		}
+
+		events, err := webhook.ParseEvents(argMap["events"])
+		if err != nil {
+			return r, err
+		}
+
+		secret, err := webhook.NewSecret()
+		if err != nil {
+			return r, err
+		}
+
+		hooks = append(hooks, storage.Webhook{URL: hookURL, Events: events, Secret: secret})
+		bGuild.SetWebhooks(msg.Context(), hooks)
+
+		r.Description = p.Sprintf("webhook.added", hookURL, strings.Join(events, ", "), webhook.SignatureHeader)
+		secretNotice = &notify.Notice{
+			GuildID: msg.GuildID(),
+			User:    cmdhandler.UserMentionString(msg.UserID()),
+			Kind:    notify.KindPrivate,
+			Text:    p.Sprintf("dm.webhook_secret", hookURL, webhook.SignatureHeader, secret),
+		}
+
+	case "remove":
+		if len(args) != 1 {
+			return r, i18n.NewError("err.webhook_need_remove")
+		}
+
+		idx := webhookIndex(hooks, args[0])
+		if idx < 0 {
+			return r, i18n.NewError("err.webhook_not_found")
+		}
+
+		removed := hooks[idx].URL
+		hooks = append(hooks[:idx], hooks[idx+1:]...)
+		bGuild.SetWebhooks(msg.Context(), hooks)
+
+		r.Description = p.Sprintf("webhook.removed", removed)
+
+	default:
+		return r, i18n.NewError("err.webhook_unknown_action", action)
+	}
+
+	if err = t.SaveGuild(msg.Context(), bGuild); err != nil {
+		return r, errors.Wrap(err, "could not save webhooks")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not save webhooks")
+	}
+
+	level.Info(logger).Message("webhooks updated", "action", action, "webhook_ct", len(hooks))
+
+	if secretNotice == nil {
+		return r, nil
+	}
+
+	// a webhook whose secret never arrived cannot be verified, so it is not kept
+	if err = c.deps.Notifier().Send(msg.Context(), *secretNotice); err != nil {
+		level.Error(logger).Err("could not send webhook secret; removing webhook", err)
+
+		hookURL := hooks[len(hooks)-1].URL
+		if rerr := c.removeWebhook(msg.Context(), msg.GuildID(), hookURL); rerr != nil {
+			return r, errors.Wrap(rerr, "could not remove webhook after its secret was not sent")
+		}
+
+		return r, i18n.NewError("err.webhook_secret_undelivered", hookURL)
+	}
+
+	return r, nil
+}
+
+// removeWebhook removes the webhook of a guild with a url, if it is still there
+func (c *configCommands) removeWebhook(ctx context.Context, gid snowflake.Snowflake, hookURL string) error {
+	t, err := c.deps.GuildAPI().NewTransaction(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	bGuild, err := t.GetGuild(ctx, gid.ToString())
+	if err != nil {
+		return errors.Wrap(err, "unable to find guild")
+	}
+
+	hooks := bGuild.GetWebhooks(ctx)
+	for i, h := range hooks {
+		if h.URL == hookURL {
+			bGuild.SetWebhooks(ctx, append(hooks[:i], hooks[i+1:]...))
+			break
+		}
+	}
+
+	if err = t.SaveGuild(ctx, bGuild); err != nil {
+		return errors.Wrap(err, "could not save webhooks")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return errors.Wrap(err, "could not save webhooks")
+	}
+
+	return nil
+}
+
+// webhookIndex finds a webhook by its url or by its 1-based position in the list output
+func webhookIndex(hooks []storage.Webhook, val string) int {
+	if n, err := strconv.Atoi(val); err == nil {
+		if n < 1 || n > len(hooks) {
+			return -1
+		}
+		return n - 1
+	}
+
+	hookURL, err := parseWebhookURL(val)
+	if err != nil {
+		return -1
+	}
+
+	for i, h := range hooks {
+		if h.URL == hookURL {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func formatWebhooks(p i18n.Printer, hooks []storage.Webhook) string {
+	if len(hooks) == 0 {
+		return p.Sprintf("webhook.none")
+	}
+
+	lines := make([]string, 0, len(hooks))
+	for i, h := range hooks {
+		lines = append(lines, fmt.Sprintf("%d. %s (%s)", i+1, h.URL, strings.Join(h.Events, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
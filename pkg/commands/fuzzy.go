@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// maxSuggestions is the most names offered in a "did you mean" hint
+const maxSuggestions = 3
+
+// editDistance is the number of single-character insertions, deletions, and substitutions
+// between a and b, ignoring case
+func editDistance(a, b string) int {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// suggest picks the names closest to a misspelled one; names that differ in more than
+// about a third of their letters are not offered
+func suggest(typed string, names []string) []string {
+	maxDist := len([]rune(typed))/3 + 1
+	if maxDist > 3 {
+		maxDist = 3
+	}
+
+	type candidate struct {
+		name string
+		dist int
+	}
+
+	var cands []candidate
+	seen := map[string]bool{}
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		if seen[lower] {
+			continue
+		}
+		seen[lower] = true
+
+		if d := editDistance(typed, name); d <= maxDist {
+			cands = append(cands, candidate{name, d})
+		}
+	}
+
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].dist != cands[j].dist {
+			return cands[i].dist < cands[j].dist
+		}
+		return cands[i].name < cands[j].name
+	})
+
+	if len(cands) > maxSuggestions {
+		cands = cands[:maxSuggestions]
+	}
+
+	out := make([]string, 0, len(cands))
+	for _, c := range cands {
+		out = append(out, c.name)
+	}
+
+	return out
+}
+
+func formatNames(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		quoted = append(quoted, "`"+n+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func trialNames(ctx context.Context, trials []storage.Trial) []string {
+	names := make([]string, 0, len(trials))
+	for _, trial := range trials {
+		names = append(names, trial.GetName(ctx))
+	}
+	return names
+}
+
+// trialNotFound is the error for a trial name that matches nothing, suggesting similar names
+func trialNotFound(ctx context.Context, name string, trials []storage.Trial) error {
+	s := suggest(name, trialNames(ctx, trials))
+	if len(s) == 0 {
+		return storage.ErrTrialNotExist
+	}
+
+	return i18n.NewError("err.trial_not_exist_suggest", name, formatNames(s))
+}
+
+// FindTrial looks up a trial by its name, or by the start of the name if only one trial
+// starts that way
+func FindTrial(ctx context.Context, t storage.TrialAPITx, name string) (storage.Trial, error) {
+	trial, err := t.GetTrial(ctx, name)
+	if err != storage.ErrTrialNotExist {
+		return trial, err
+	}
+
+	trials := t.GetTrials(ctx)
+	if name == "" {
+		return nil, trialNotFound(ctx, name, trials)
+	}
+
+	lower := strings.ToLower(name)
+	var matches []storage.Trial
+	for _, trial := range trials {
+		if strings.HasPrefix(strings.ToLower(trial.GetName(ctx)), lower) {
+			matches = append(matches, trial)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, trialNotFound(ctx, name, trials)
+	case 1:
+		return matches[0], nil
+	default:
+		names := trialNames(ctx, matches)
+		sort.Strings(names)
+		return nil, i18n.NewError("err.ambiguous_trial", name, formatNames(names))
+	}
+}
+
+// getTrialExact looks up a trial by its full name only, for commands that should not guess
+// which trial was meant
+func getTrialExact(ctx context.Context, t storage.TrialAPITx, name string) (storage.Trial, error) {
+	trial, err := t.GetTrial(ctx, name)
+	if err == storage.ErrTrialNotExist {
+		return nil, trialNotFound(ctx, name, t.GetTrials(ctx))
+	}
+	return trial, err
+}
+
+// unknownRole is the error for a role a trial does not have, suggesting similar role
+// names and aliases
+func unknownRole(ctx context.Context, role string, roleCounts []storage.RoleCount) error {
+	names := make([]string, 0, len(roleCounts))
+	for _, rc := range roleCounts {
+		names = append(names, rc.GetRole(ctx))
+		names = append(names, rc.GetAliases(ctx)...)
+	}
+
+	s := suggest(role, names)
+	if len(s) == 0 {
+		return ErrUnknownRole
+	}
+
+	return i18n.NewError("err.unknown_role_suggest", role, formatNames(s))
+}
+
+// IsUnknownRole reports whether err is the error for signing up in a role the trial does
+// not have, with or without suggestions
+func IsUnknownRole(err error) bool {
+	if err == ErrUnknownRole {
+		return true
+	}
+
+	le, ok := err.(*i18n.Error)
+	return ok && le.Key() == "err.unknown_role_suggest"
+}
+
+// ResolveRole finds the name of the role of a trial given by name or alias
+func ResolveRole(ctx context.Context, trial storage.Trial, role string) (string, error) {
+	roleCounts := trial.GetRoleCounts(ctx)
+	rc, ok := roleCountByName(ctx, role, roleCounts)
+	if !ok {
+		return "", unknownRole(ctx, role, roleCounts)
+	}
+
+	return rc.GetRole(ctx), nil
+}
+
+// parseAliasesString parses `role:alias:alias,role:alias` into the aliases of each role;
+// a role without aliases (`role:`) has its aliases removed
+func parseAliasesString(args string) (map[string][]string, error) {
+	aliases := map[string][]string{}
+
+	for _, roleStr := range strings.Split(strings.TrimSpace(args), ",") {
+		if roleStr == "" {
+			continue
+		}
+
+		parts := strings.Split(roleStr, ":")
+		if len(parts) < 2 || parts[0] == "" {
+			return aliases, i18n.NewError("err.bad_aliases", roleStr)
+		}
+
+		roleAliases := make([]string, 0, len(parts)-1)
+		for _, a := range parts[1:] {
+			if a != "" {
+				roleAliases = append(roleAliases, strings.ToLower(a))
+			}
+		}
+
+		aliases[parts[0]] = roleAliases
+	}
+
+	return aliases, nil
+}
+
+// setAliases applies the aliases setting to a trial whose roles are already set, checking
+// that each name leads to a single role
+func setAliases(ctx context.Context, trial storage.Trial, settingMap map[string]string) error {
+	v, ok := settingMap["aliases"]
+	if !ok {
+		return nil
+	}
+
+	aliases, err := parseAliasesString(v)
+	if err != nil {
+		return err
+	}
+
+	for role, roleAliases := range aliases {
+		rc, ok := roleCountByName(ctx, role, trial.GetRoleCounts(ctx))
+		if !ok || !strings.EqualFold(rc.GetRole(ctx), role) {
+			return i18n.NewError("err.alias_unknown_role", role)
+		}
+
+		trial.SetRoleAliases(ctx, rc.GetRole(ctx), roleAliases)
+	}
+
+	owners := map[string]string{}
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		owners[strings.ToLower(rc.GetRole(ctx))] = rc.GetRole(ctx)
+	}
+
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		for _, a := range rc.GetAliases(ctx) {
+			if owner, taken := owners[a]; taken && owner != rc.GetRole(ctx) {
+				return i18n.NewError("err.alias_conflict", a, owner)
+			}
+			owners[a] = rc.GetRole(ctx)
+		}
+	}
+
+	return nil
+}
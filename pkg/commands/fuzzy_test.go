@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"vss", "vss", 0},
+		{"vAA", "VaA", 0},
+		{"vss", "vsa", 1},
+		{"vss", "vsss", 1},
+		{"kitten", "sitting", 3},
+		{"héal", "heal", 1},
+		{"Héal", "hÉal", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := editDistance(tt.b, tt.a); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed string
+		names []string
+		want  []string
+	}{
+		{
+			name:  "closest first, without names too far off",
+			typed: "vsss",
+			names: []string{"vmol", "vsa", "vaa", "vss"},
+			want:  []string{"vss", "vsa"},
+		},
+		{
+			name:  "names that differ only in case are offered once",
+			typed: "vsss",
+			names: []string{"VSS", "vss", "Vss"},
+			want:  []string{"VSS"},
+		},
+		{
+			name:  "short names allow a single change",
+			typed: "x",
+			names: []string{"xyz", "y", "X"},
+			want:  []string{"X", "y"},
+		},
+		{
+			name:  "long names allow at most three changes",
+			typed: "abcdefghijkl",
+			names: []string{"abcdefghzzzz", "abcdefghizzz", "abcdefghijkz"},
+			want:  []string{"abcdefghijkz", "abcdefghizzz"},
+		},
+		{
+			name:  "ties are in name order, up to three",
+			typed: "vaa",
+			names: []string{"vae", "vad", "vac", "vab"},
+			want:  []string{"vab", "vac", "vad"},
+		},
+		{
+			name:  "nothing close",
+			typed: "zzzz",
+			names: []string{"vaa", "vss"},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggest(tt.typed, tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggest(%q, %v) = %v, want %v", tt.typed, tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTrial(t *testing.T) {
+	ctx := context.Background()
+
+	tx, done := testTrials(t)
+	defer done()
+
+	for _, name := range []string{"vaa", "vaa-hm", "vss", "vmol"} {
+		addTestTrial(ctx, t, tx, name, storage.TrialStateOpen, time.Time{})
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "vaa", want: "vaa"},
+		{name: "VAA", want: "vaa"},
+		{name: "vaa-", want: "vaa-hm"},
+		{name: "vaa-H", want: "vaa-hm"},
+		{name: "VS", want: "vss"},
+		{name: "vm", want: "vmol"},
+		{name: "v", wantErr: i18n.NewError("err.ambiguous_trial", "v", "`vaa`, `vaa-hm`, `vmol`, `vss`")},
+		{name: "va", wantErr: i18n.NewError("err.ambiguous_trial", "va", "`vaa`, `vaa-hm`")},
+		{name: "vsx", wantErr: i18n.NewError("err.trial_not_exist_suggest", "vsx", "`vss`, `vaa`")},
+		{name: "zzzzzz", wantErr: storage.ErrTrialNotExist},
+		{name: "", wantErr: storage.ErrTrialNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trial, err := FindTrial(ctx, tx, tt.name)
+
+			switch {
+			case err == nil && tt.wantErr == nil:
+			case err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error():
+				t.Fatalf("FindTrial(%q) error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got := trial.GetName(ctx); got != tt.want {
+				t.Errorf("FindTrial(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRole(t *testing.T) {
+	ctx := context.Background()
+
+	tx, done := testTrials(t)
+	defer done()
+
+	trial := addTestTrial(ctx, t, tx, "vss", storage.TrialStateOpen, time.Time{})
+	trial.SetRoleCount(ctx, "healer", "", 2)
+	trial.SetRoleCount(ctx, "Tank", "", 2)
+	trial.SetRoleAliases(ctx, "dps", []string{"dd", "damage"})
+	trial.SetRoleAliases(ctx, "healer", []string{"heal"})
+	trial.SetRoleAliases(ctx, "Tank", []string{"tank"})
+
+	tests := []struct {
+		role    string
+		want    string
+		wantErr error
+	}{
+		{role: "dps", want: "dps"},
+		{role: "Healer", want: "healer"},
+		{role: "DD", want: "dps"},
+		{role: "tank", want: "Tank"},
+		{role: "heals", wantErr: i18n.NewError("err.unknown_role_suggest", "heals", "`heal`, `healer`")},
+		{role: "tanc", wantErr: i18n.NewError("err.unknown_role_suggest", "tanc", "`Tank`")},
+		{role: "support", wantErr: ErrUnknownRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			got, err := ResolveRole(ctx, trial, tt.role)
+
+			switch {
+			case err == nil && tt.wantErr == nil:
+			case err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error():
+				t.Fatalf("ResolveRole(%q) error = %v, want %v", tt.role, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if !IsUnknownRole(err) {
+					t.Errorf("IsUnknownRole(%v) = false, want true", err)
+				}
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ResolveRole(%q) = %q, want %q", tt.role, got, tt.want)
+			}
+		})
+	}
+}
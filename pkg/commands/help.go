@@ -0,0 +1,634 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// permission is who may run a command
+type permission int
+
+const (
+	// permEveryone commands can be run by anyone, although the event commands only answer
+	// in the event's signup channel
+	permEveryone permission = iota
+	// permAdmin commands can be run by bot admins (server admins or the adminrole) in the
+	// admin channel
+	permAdmin
+	// permConfig commands can be run by bot admins in any channel
+	permConfig
+)
+
+func (pm permission) key() string {
+	switch pm {
+	case permAdmin:
+		return "help.perm.admin"
+	case permConfig:
+		return "help.perm.config"
+	default:
+		return "help.perm.everyone"
+	}
+}
+
+// argHelp describes an argument of a command; a name ending in ... takes several words
+type argHelp struct {
+	name     string
+	optional bool
+}
+
+// key is the catalog key of the argument description, without the ... of its name
+func (a argHelp) key(prefix string) string {
+	return prefix + ".arg." + strings.TrimSuffix(a.name, "...")
+}
+
+// commandHelp is the documentation that each command declares where it is registered,
+// rendered by !help and in the README command reference
+//
+// The summary, argument descriptions, and examples are in the i18n catalogs under
+// <group title>.<name>.summary, .arg.<argument>, and .examples, one example per line.
+// Examples are written without the command indicator and group, like the name.
+type commandHelp struct {
+	name       string
+	aliases    []string
+	args       []argHelp
+	permission permission
+}
+
+// helpGroup is the commands of one command handler, run as <indicator><prefix> <command>
+type helpGroup struct {
+	prefix   string
+	title    string
+	commands []commandHelp
+}
+
+var userHelp = []commandHelp{
+	{
+		name: "list",
+	},
+	{
+		name: "show",
+		args: []argHelp{
+			{name: "event"},
+		},
+	},
+	{
+		name:    "signup",
+		aliases: []string{"su"},
+		args: []argHelp{
+			{name: "event"},
+			{name: "role", optional: true},
+			{name: "details...", optional: true},
+		},
+	},
+	{
+		name:    "withdraw",
+		aliases: []string{"wd"},
+		args: []argHelp{
+			{name: "event"},
+		},
+	},
+	{
+		name: "confirm",
+		args: []argHelp{
+			{name: "event"},
+		},
+	},
+	{
+		name: "profile",
+		args: []argHelp{
+			{name: "action", optional: true},
+			{name: "settings...", optional: true},
+		},
+	},
+	{
+		name: "notify",
+		args: []argHelp{
+			{name: "choice", optional: true},
+		},
+	},
+	{
+		name: "presets",
+		args: []argHelp{
+			{name: "preset", optional: true},
+		},
+	},
+	{
+		name: "calendar",
+		args: []argHelp{
+			{name: "me", optional: true},
+			{name: "file", optional: true},
+		},
+	},
+	{
+		name: "help",
+		args: []argHelp{
+			{name: "command...", optional: true},
+		},
+	},
+}
+
+var adminHelp = []commandHelp{
+	{
+		name:       "list",
+		permission: permAdmin,
+	},
+	{
+		name: "show",
+		args: []argHelp{
+			{name: "event"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "create",
+		args: []argHelp{
+			{name: "event"},
+			{name: "settings...", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "edit",
+		args: []argHelp{
+			{name: "event"},
+			{name: "settings...", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "open",
+		args: []argHelp{
+			{name: "event"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "close",
+		args: []argHelp{
+			{name: "event"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "lock",
+		args: []argHelp{
+			{name: "event"},
+			{name: "deadline", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "cancel",
+		args: []argHelp{
+			{name: "event"},
+			{name: "reason...", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "reschedule",
+		args: []argHelp{
+			{name: "event"},
+			{name: "start"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "delete",
+		args: []argHelp{
+			{name: "event"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "clear",
+		args: []argHelp{
+			{name: "event"},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "announce",
+		args: []argHelp{
+			{name: "event"},
+			{name: "message...", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name: "grouping",
+		args: []argHelp{
+			{name: "event"},
+			{name: "message...", optional: true},
+		},
+		permission: permAdmin,
+	},
+	{
+		name:    "signup",
+		aliases: []string{"su"},
+		args: []argHelp{
+			{name: "event"},
+			{name: "role"},
+			{name: "members..."},
+		},
+		permission: permAdmin,
+	},
+	{
+		name:    "withdraw",
+		aliases: []string{"wd"},
+		args: []argHelp{
+			{name: "event"},
+			{name: "members..."},
+		},
+		permission: permAdmin,
+	},
+}
+
+var configHelp = []commandHelp{
+	{
+		name:       "list",
+		permission: permConfig,
+	},
+	{
+		name: "get",
+		args: []argHelp{
+			{name: "setting"},
+		},
+		permission: permConfig,
+	},
+	{
+		name: "set",
+		args: []argHelp{
+			{name: "settings..."},
+		},
+		permission: permConfig,
+	},
+	{
+		name:       "reset",
+		permission: permConfig,
+	},
+	{
+		name:       "doctor",
+		permission: permConfig,
+	},
+	{
+		name:       "export",
+		permission: permConfig,
+	},
+	{
+		name: "apitoken",
+		args: []argHelp{
+			{name: "revoke", optional: true},
+		},
+		permission: permConfig,
+	},
+	{
+		name: "webhook",
+		args: []argHelp{
+			{name: "action"},
+			{name: "args...", optional: true},
+		},
+		permission: permConfig,
+	},
+	{
+		name: "preset",
+		args: []argHelp{
+			{name: "action"},
+			{name: "args...", optional: true},
+		},
+		permission: permConfig,
+	},
+	{
+		name:       "stats",
+		permission: permConfig,
+	},
+	{
+		name:       "version",
+		permission: permConfig,
+	},
+	{
+		name:       "website",
+		permission: permConfig,
+	},
+	{
+		name:       "discord",
+		permission: permConfig,
+	},
+}
+
+var helpGroups = []helpGroup{
+	{prefix: "", title: "help.user", commands: userHelp},
+	{prefix: "admin", title: "help.admin", commands: adminHelp},
+	{prefix: "config-su", title: "help.config", commands: configHelp},
+}
+
+// setHandlers registers the handler of each documented command under its name and aliases;
+// every command must be documented, and every documented command must have a handler
+func setHandlers(ch *cmdhandler.CommandHandler, docs []commandHelp, handlers map[string]cmdhandler.MessageHandlerFunc) error {
+	for _, h := range docs {
+		f, ok := handlers[h.name]
+		if !ok {
+			return fmt.Errorf("no handler for documented command '%s'", h.name)
+		}
+
+		mh := cmdhandler.NewMessageHandler(f)
+		ch.SetHandler(h.name, mh)
+		for _, a := range h.aliases {
+			ch.SetHandler(a, mh)
+		}
+	}
+
+	if len(handlers) != len(docs) {
+		for name := range handlers {
+			if _, ok := findCommand(docs, name); !ok {
+				return fmt.Errorf("command '%s' is not documented", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findCommand(docs []commandHelp, name string) (commandHelp, bool) {
+	name = strings.ToLower(name)
+	for _, h := range docs {
+		if h.name == name {
+			return h, true
+		}
+		for _, a := range h.aliases {
+			if a == name {
+				return h, true
+			}
+		}
+	}
+
+	return commandHelp{}, false
+}
+
+func (g helpGroup) command(indicator, name string) string {
+	if g.prefix == "" {
+		return indicator + name
+	}
+	return fmt.Sprintf("%s%s %s", indicator, g.prefix, name)
+}
+
+func (g helpGroup) usage(indicator string, h commandHelp) string {
+	parts := []string{g.command(indicator, h.name)}
+	for _, a := range h.args {
+		if a.optional {
+			parts = append(parts, "["+a.name+"]")
+		} else {
+			parts = append(parts, "<"+a.name+">")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// key is the catalog key prefix of the help of a command in the group
+func (g helpGroup) key(h commandHelp) string {
+	return g.title + "." + h.name
+}
+
+// examples are the examples of a command in the locale of p
+func (g helpGroup) examples(p i18n.Printer, h commandHelp) []string {
+	return strings.Split(p.Sprintf(g.key(h)+".examples"), "\n")
+}
+
+func (g helpGroup) index(p i18n.Printer, indicator string) cmdhandler.EmbedField {
+	lines := make([]string, 0, len(g.commands))
+	for _, h := range g.commands {
+		lines = append(lines, fmt.Sprintf("`%s` - %s", g.usage(indicator, h), p.Sprintf(g.key(h)+".summary")))
+	}
+
+	return cmdhandler.EmbedField{
+		Name: p.Sprintf(g.title),
+		Val:  strings.Join(lines, "\n"),
+	}
+}
+
+func (g helpGroup) detail(p i18n.Printer, indicator string, h commandHelp) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "`%s`\n%s\n", g.usage(indicator, h), p.Sprintf(g.key(h)+".summary"))
+
+	if len(h.args) > 0 {
+		fmt.Fprintf(&b, "\n**%s**\n", p.Sprintf("help.arguments"))
+		for _, a := range h.args {
+			fmt.Fprintf(&b, "- `%s`: %s\n", a.name, p.Sprintf(a.key(g.key(h))))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n**%s**\n", p.Sprintf("help.examples"))
+	for _, e := range g.examples(p, h) {
+		fmt.Fprintf(&b, "`%s`\n", g.command(indicator, e))
+	}
+
+	if len(h.aliases) > 0 {
+		aliases := make([]string, 0, len(h.aliases))
+		for _, a := range h.aliases {
+			aliases = append(aliases, "`"+g.command(indicator, a)+"`")
+		}
+		fmt.Fprintf(&b, "\n**%s** %s\n", p.Sprintf("help.aliases"), strings.Join(aliases, ", "))
+	}
+
+	fmt.Fprintf(&b, "\n**%s** %s", p.Sprintf("help.permission"), p.Sprintf(h.permission.key()))
+
+	return b.String()
+}
+
+// visibleGroups are the help groups with commands that a member may run
+func visibleGroups(admin bool) []helpGroup {
+	if admin {
+		return helpGroups
+	}
+	return helpGroups[:1]
+}
+
+// helpResponse renders the commands of the groups, or the details of the command named by
+// args, which can start with the group (admin create)
+func helpResponse(msg cmdhandler.Message, p i18n.Printer, indicator string, groups []helpGroup, args []string) (cmdhandler.Response, error) {
+	r := &cmdhandler.EmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	if len(args) > 0 {
+		args[0] = strings.TrimPrefix(args[0], indicator)
+	}
+
+	// a group name alone narrows the index to that group
+	if len(args) == 1 {
+		for _, g := range groups {
+			if g.prefix != "" && strings.EqualFold(g.prefix, args[0]) {
+				groups = []helpGroup{g}
+				args = nil
+				break
+			}
+		}
+	}
+
+	if len(args) == 0 {
+		for _, g := range groups {
+			r.Fields = append(r.Fields, g.index(p, indicator))
+		}
+		r.FooterText = p.Sprintf("help.more", indicator+"help")
+		return r, nil
+	}
+
+	name := args[len(args)-1]
+	if len(args) > 1 {
+		var narrowed []helpGroup
+		for _, g := range groups {
+			if strings.EqualFold(g.prefix, args[0]) {
+				narrowed = append(narrowed, g)
+			}
+		}
+		groups = narrowed
+	}
+
+	details := make([]string, 0, 1)
+	for _, g := range groups {
+		if h, ok := findCommand(g.commands, name); ok {
+			details = append(details, g.detail(p, indicator, h))
+		}
+	}
+
+	if len(details) == 0 || len(args) > 2 {
+		return r, i18n.NewError("err.unknown_help_topic", strings.Join(args, " "))
+	}
+
+	r.Description = strings.Join(details, "\n\n")
+	return r, nil
+}
+
+// commandIndicator is the indicator of a guild's commands, as shown in help
+func commandIndicator(s storage.GuildSettings, defaultIndicator string) string {
+	if s.ControlSequence == "" {
+		return defaultIndicator
+	}
+	return s.ControlSequence
+}
+
+func (c *userCommands) help(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.help", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "help")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	admin := msghandler.IsAdminAuthorized(logger, msg, gsettings.AdminRole, c.deps.BotSession())
+
+	return helpResponse(msg, i18n.For(gsettings.Locale), commandIndicator(gsettings, c.cmdIndicator), visibleGroups(admin), msg.Contents())
+}
+
+func (c *adminCommands) help(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.help", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "help")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	indicator := commandIndicator(gsettings, strings.TrimSuffix(c.preCommand, "admin"))
+	return helpResponse(msg, i18n.For(gsettings.Locale), indicator, helpGroups[1:2], msg.Contents())
+}
+
+func (c *configCommands) help(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "configCommands.help", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling configCommand", "command", "help")
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	indicator := commandIndicator(gsettings, strings.TrimSuffix(c.preCommand, "config-su"))
+	return helpResponse(msg, i18n.For(gsettings.Locale), indicator, helpGroups[2:3], msg.Contents())
+}
+
+// Reference renders the commands as markdown, for the README command reference
+func Reference() string {
+	p := i18n.For(i18n.DefaultLocale)
+
+	var b strings.Builder
+
+	for i, g := range helpGroups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n\n%s.\n\n", p.Sprintf(g.title), p.Sprintf(g.commands[0].permission.key()))
+
+		for _, h := range g.commands {
+			fmt.Fprintf(&b, "- `%s`: %s\n", g.usage("!", h), p.Sprintf(g.key(h)+".summary"))
+
+			for _, a := range h.args {
+				fmt.Fprintf(&b, "  - `%s`: %s\n", a.name, p.Sprintf(a.key(g.key(h))))
+			}
+
+			if len(h.aliases) > 0 {
+				aliases := make([]string, 0, len(h.aliases))
+				for _, a := range h.aliases {
+					aliases = append(aliases, "`"+g.command("!", a)+"`")
+				}
+				sort.Strings(aliases)
+				fmt.Fprintf(&b, "  - %s %s\n", p.Sprintf("help.aliases"), strings.Join(aliases, ", "))
+			}
+
+			var examples []string
+			for _, e := range g.examples(p, h) {
+				examples = append(examples, "`"+g.command("!", e)+"`")
+			}
+			fmt.Fprintf(&b, "  - %s: %s\n", p.Sprintf("help.examples"), strings.Join(examples, ", "))
+		}
+	}
+
+	return b.String()
+}
@@ -3,85 +3,275 @@ package commands
 import (
 	"context"
 	"fmt"
-	"sort"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
-	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 )
 
-var ErrUnknownRole = errors.New("unknown role")
+var ErrUnknownRole = i18n.NewError("err.unknown_role")
+
+// ErrSignupClosed is the error returned when signing up for a trial that is not open
+var ErrSignupClosed = i18n.NewError("err.signup_closed")
+
+// ErrWithdrawClosed is the error returned when withdrawing from a trial that is not open
+var ErrWithdrawClosed = i18n.NewError("err.withdraw_closed")
 
 var isAdminAuthorized = msghandler.IsAdminAuthorized
 var isAdminChannel = msghandler.IsAdminChannel
 
-func isSignupChannel(logger logging.Logger, msg cmdhandler.Message, signupChannel, adminChannel, adminRole string, session *etfapi.Session) bool {
-	if msghandler.IsSignupChannel(msg, signupChannel, session) {
+func isSignupChannel(logger logging.Logger, msg cmdhandler.Message, signupChannelID, signupChannel, adminChannelID, adminChannel, adminRole string, session *etfapi.Session) bool {
+	if msghandler.IsSignupChannel(msg, signupChannelID, signupChannel, session) {
 		return true
 	}
 
-	if !isAdminChannel(logger, msg, adminChannel, session) {
+	if !isAdminChannel(logger, msg, adminChannelID, adminChannel, session) {
 		return false
 	}
 
 	return isAdminAuthorized(logger, msg, adminRole, session)
 }
 
-func signupsForRole(ctx context.Context, role string, signups []storage.TrialSignup, sorted bool) []string {
+// roleCountByName finds a role by its name, or else by one of its aliases
+func roleCountByName(ctx context.Context, role string, roleCounts []storage.RoleCount) (storage.RoleCount, bool) {
 	roleLower := strings.ToLower(role)
-	t := make([]string, 0, len(signups))
-	for _, s := range signups {
-		if strings.ToLower(s.GetRole(ctx)) == roleLower {
-			t = append(t, s.GetName(ctx))
+	for _, rc := range roleCounts {
+		if strings.ToLower(rc.GetRole(ctx)) == roleLower {
+			return rc, true
 		}
 	}
 
-	if sorted {
-		sort.Strings(t)
+	for _, rc := range roleCounts {
+		for _, a := range rc.GetAliases(ctx) {
+			if a == roleLower {
+				return rc, true
+			}
+		}
 	}
 
-	return t
+	return nil, false
 }
 
-func roleCountByName(ctx context.Context, role string, roleCounts []storage.RoleCount) (storage.RoleCount, bool) {
-	roleLower := strings.ToLower(role)
-	for _, rc := range roleCounts {
-		if strings.ToLower(rc.GetRole(ctx)) == roleLower {
-			return rc, true
+// argPair is a key=value argument, with the key as it was typed
+type argPair struct {
+	key, val string
+}
+
+// parseArgPairs splits key=value arguments; values with spaces arrive here already
+// joined by the tokenizer, so each argument must contain an '='
+func parseArgPairs(args []string) ([]argPair, error) {
+	pairs := make([]argPair, 0, len(args))
+
+	for _, arg := range args {
+		if arg == "" {
+			continue
+		}
+
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) < 2 || parts[0] == "" {
+			return pairs, i18n.NewError("err.bad_argument", arg)
 		}
+
+		pairs = append(pairs, argPair{key: parts[0], val: parts[1]})
 	}
 
-	return nil, false
+	return pairs, nil
 }
 
 func parseSettingDescriptionArgs(args []string) (map[string]string, error) {
 	argMap := map[string]string{}
 
-	for _, pair := range args {
-		if pair == "" {
-			continue
+	pairs, err := parseArgPairs(args)
+	if err != nil {
+		return argMap, err
+	}
+
+	for _, ap := range pairs {
+		argMap[strings.ToLower(ap.key)] = ap.val
+	}
+
+	return argMap, nil
+}
+
+// joinPhrase joins the free-form words of a command; a trailing message: argument is
+// used verbatim, so that it can contain quotes
+func joinPhrase(args []string) string {
+	if n := len(args); n > 0 {
+		args = append(args[:n-1:n-1], strings.TrimPrefix(args[n-1], "message="))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// channelMention matches a #channel mention, as Discord sends it
+var channelMention = regexp.MustCompile(`^<#(\d+)>$`)
+
+// resolveChannel finds the channel that a setting names, as a #channel mention or by name,
+// returning its name for display and its id; an empty value clears the channel
+func resolveChannel(session *etfapi.Session, gid snowflake.Snowflake, val string) (string, string, error) {
+	if val == "" {
+		return "", "", nil
+	}
+
+	g, ok := session.Guild(gid)
+	if !ok {
+		return "", "", ErrGuildNotFound
+	}
+
+	if m := channelMention.FindStringSubmatch(val); m != nil {
+		cid, err := snowflake.FromString(m[1])
+		if err != nil || !g.OwnsChannel(cid) {
+			return "", "", i18n.NewError("err.unknown_channel_mention", val)
 		}
 
-		pairParts := strings.SplitN(pair, "=", 2)
-		if len(pairParts) < 2 {
-			return argMap, errors.New("could not parse arguments")
+		name, _ := session.ChannelName(cid)
+		return name, cid.ToString(), nil
+	}
+
+	name := strings.TrimLeft(val, "#")
+	cid, ok := g.ChannelWithName(name)
+	if !ok {
+		return "", "", i18n.NewError("err.unknown_channel", name)
+	}
+
+	return name, cid.ToString(), nil
+}
+
+// resolveRole finds the role that a setting names, as a @role mention, by name, or by id,
+// returning its name, when it was given by name, and its id; an empty value clears the role
+//
+// etfapi.Guild only looks roles up by name, so a mention or id is taken as it is.
+func resolveRole(session *etfapi.Session, gid snowflake.Snowflake, val string) (string, string, error) {
+	if val == "" {
+		return "", "", nil
+	}
+
+	g, ok := session.Guild(gid)
+	if !ok {
+		return "", "", ErrGuildNotFound
+	}
+
+	if m := mentionedRole.FindStringSubmatch(val); m != nil {
+		rid, err := snowflake.FromString(m[1])
+		if err != nil {
+			return "", "", i18n.NewError("err.unknown_role_mention", val)
 		}
+		return "", rid.ToString(), nil
+	}
 
-		// if strings.ToLower(pairParts[0]) == "description" {
-		// 	argMap[strings.ToLower(pairParts[0])] = fmt.Sprintf("%s %s", pairParts[1], strings.Join(parts[i+1:], " "))
-		// 	break
-		// }
+	name := strings.TrimPrefix(val, "@")
+	if rid, ok := g.RoleWithName(name); ok {
+		return name, rid.ToString(), nil
+	}
 
-		argMap[strings.ToLower(pairParts[0])] = pairParts[1]
+	if rid, err := snowflake.FromString(val); err == nil {
+		return "", rid.ToString(), nil
 	}
 
-	return argMap, nil
+	return "", "", i18n.NewError("err.unknown_guild_role", name)
+}
+
+// startTimeLayouts are the accepted formats for an event start; those without an offset are UTC
+var startTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// spacedStartTime matches a start written with spaces, like "2026-11-01 18:00 +01:00",
+// which is rewritten into the layouts startTimeLayouts accepts
+var spacedStartTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+(\d{1,2}:\d{2}(?::\d{2})?)\s*(Z|[+-]\d{2}:\d{2})?$`)
+
+// parseStartTime parses the start= setting; an empty value unschedules the event
+func parseStartTime(val string) (time.Time, error) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return time.Time{}, nil
+	}
+
+	if m := spacedStartTime.FindStringSubmatch(val); m != nil {
+		val = m[1] + "T" + m[2] + m[3]
+	}
+
+	for _, layout := range startTimeLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, i18n.NewError("err.bad_start", val)
+}
+
+// parseDuration parses the duration= setting, like 90m or 2h
+func parseDuration(val string) (time.Duration, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil || d < 0 {
+		return 0, i18n.NewError("err.bad_duration", val)
+	}
+
+	return d, nil
+}
+
+// setSchedule applies the start= and duration= settings to a trial, if they are present
+func setSchedule(ctx context.Context, trial storage.Trial, settingMap map[string]string) error {
+	if v, ok := settingMap["start"]; ok {
+		start, err := parseStartTime(v)
+		if err != nil {
+			return err
+		}
+		trial.SetStartTime(ctx, start)
+		// members are reminded again of a new start
+		trial.SetRemindedAt(ctx, time.Time{})
+	}
+
+	if v, ok := settingMap["duration"]; ok {
+		d, err := parseDuration(v)
+		if err != nil {
+			return err
+		}
+		trial.SetDuration(ctx, d)
+	}
+
+	return nil
+}
+
+// setChannels applies the announcechannel= and signupchannel= settings to a trial, if they
+// are present
+func setChannels(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, trial storage.Trial, settingMap map[string]string) error {
+	if v, ok := settingMap["announcechannel"]; ok {
+		name, cid, err := resolveChannel(session, gid, v)
+		if err != nil {
+			return err
+		}
+		trial.SetAnnounceChannel(ctx, name)
+		trial.SetAnnounceChannelID(ctx, cid)
+	}
+
+	if v, ok := settingMap["signupchannel"]; ok {
+		name, cid, err := resolveChannel(session, gid, v)
+		if err != nil {
+			return err
+		}
+		trial.SetSignupChannel(ctx, name)
+		trial.SetSignupChannelID(ctx, cid)
+	}
+
+	return nil
 }
 
 type roleCtEmo struct {
@@ -101,12 +291,12 @@ func parseRolesString(args string) ([]roleCtEmo, error) {
 
 		roleParts := strings.SplitN(roleStr, ":", 3)
 		if len(roleParts) < 2 {
-			return roleEmoCt, errors.New("could not parse roles")
+			return roleEmoCt, i18n.NewError("err.bad_roles")
 		}
 
 		roleCt, err := strconv.Atoi(roleParts[1])
 		if err != nil {
-			return roleEmoCt, err
+			return roleEmoCt, i18n.NewError("err.bad_role_count", roleParts[1])
 		}
 
 		var emo string
@@ -124,60 +314,246 @@ func parseRolesString(args string) ([]roleCtEmo, error) {
 	return roleEmoCt, nil
 }
 
-func getTrialRoleSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount) ([]string, []string) {
-	lowerRole := strings.ToLower(rc.GetRole(ctx))
-	suNames := make([]string, 0, len(signups))
-	ofNames := make([]string, 0, len(signups))
-	for _, su := range signups {
-		if strings.ToLower(su.GetRole(ctx)) != lowerRole {
+// signupDetailLimits are the details that can follow a signup (`class=nb`), with the most
+// characters each may have, so that rosters stay within discord's embed limits
+var signupDetailLimits = map[string]int{
+	"character": 32,
+	"class":     32,
+	"note":      100,
+}
+
+// signupArgs is one event and role of a signup command, with the details given after them
+type signupArgs struct {
+	trialName string
+	role      string
+	details   map[string]string
+}
+
+func isSignupDetail(arg string) (string, string, bool) {
+	parts := strings.SplitN(arg, "=", 2)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	key := strings.ToLower(parts[0])
+	if _, ok := signupDetailLimits[key]; !ok {
+		return "", "", false
+	}
+
+	return key, parts[1], true
+}
+
+// parseSignupArgs splits `event role [key=value ...] [event role [key=value ...] ...]`; a
+// single event may leave out the role, to be taken from the member's profile
+func parseSignupArgs(args []string) ([]signupArgs, error) {
+	var out []signupArgs
+	var positional []string
+
+	for _, arg := range args {
+		key, val, ok := isSignupDetail(arg)
+		if !ok {
+			positional = append(positional, arg)
+			if len(positional)%2 == 1 {
+				out = append(out, signupArgs{trialName: arg, details: map[string]string{}})
+			} else {
+				out[len(out)-1].role = arg
+			}
 			continue
 		}
 
-		if uint64(len(suNames)) < rc.GetCount(ctx) {
-			suNames = append(suNames, su.GetName(ctx))
-		} else {
-			ofNames = append(ofNames, su.GetName(ctx))
+		if len(positional) == 0 || (len(positional)%2 == 1 && len(positional) > 1) {
+			return out, i18n.NewError("err.signup_arguments")
 		}
+
+		if n := len([]rune(val)); n > signupDetailLimits[key] {
+			return out, i18n.NewError("err.signup_detail_too_long", key, signupDetailLimits[key])
+		}
+
+		out[len(out)-1].details[key] = val
+	}
+
+	switch {
+	case len(positional) == 0:
+		return out, i18n.NewError("err.need_event_name")
+	case len(positional) == 1:
+	case len(positional)%2 != 0:
+		return out, i18n.NewError("err.signup_arguments")
+	}
+
+	return out, nil
+}
+
+// setSignupDetails merges the details given with a signup into the user's signup; an empty
+// value clears that detail, and details the signup does not have yet come from defaults
+func setSignupDetails(ctx context.Context, trial storage.Trial, userMentionStr string, given map[string]string, defaults storage.SignupDetails) {
+	if len(given) == 0 && defaults.IsZero() {
+		return
+	}
+
+	var d storage.SignupDetails
+	for _, su := range trial.GetSignups(ctx) {
+		if su.GetName(ctx) == userMentionStr {
+			d = su.GetDetails(ctx)
+		}
+	}
+
+	if d.Character == "" {
+		d.Character = defaults.Character
+	}
+	if d.Class == "" {
+		d.Class = defaults.Class
+	}
+
+	for key, val := range given {
+		switch key {
+		case "character":
+			d.Character = val
+		case "class":
+			d.Class = val
+		case "note":
+			d.Note = val
+		}
+	}
+
+	trial.SetSignupDetails(ctx, userMentionStr, d)
+}
+
+// signupLine is a member as listed in a roster, followed by the details of their signup
+func signupLine(mention string, d storage.SignupDetails) string {
+	var who []string
+	if d.Character != "" {
+		who = append(who, d.Character)
+	}
+	if d.Class != "" {
+		who = append(who, d.Class)
+	}
+
+	line := mention
+	if len(who) > 0 {
+		line += " " + strings.Join(who, ", ")
+	}
+	if d.Note != "" {
+		line += " - _" + d.Note + "_"
+	}
+
+	return line
+}
+
+func signupLines(names []string, details map[string]storage.SignupDetails) []string {
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, signupLine(name, details[name]))
 	}
+	return lines
+}
+
+// getTrialRoleSignups splits the signups for a role into the main group, reserved slots
+// first, and the overflow
+func getTrialRoleSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount, rules RosterRules) ([]string, []string) {
+	roster := splitRoleSignups(ctx, signups, rc, rules)
+	return append(roster.reserved, roster.main...), roster.overflow
+}
 
-	return suNames, ofNames
+// TrialRoleSignups splits the signups for a role into the main group and the overflow,
+// in the same way as the roster display
+func TrialRoleSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount, rules RosterRules) ([]string, []string) {
+	return getTrialRoleSignups(ctx, signups, rc, rules)
 }
 
-func formatTrialDisplay(ctx context.Context, trial storage.Trial, withState bool) *cmdhandler.EmbedResponse {
+// stateName is the display name of a trial state
+func stateName(p i18n.Printer, state storage.TrialState) string {
+	switch state {
+	case storage.TrialStateOpen:
+		return p.Sprintf("state.open")
+	case storage.TrialStateClosed:
+		return p.Sprintf("state.closed")
+	case storage.TrialStateCanceled:
+		return p.Sprintf("state.canceled")
+	default:
+		return string(state)
+	}
+}
+
+func formatTrialDisplay(ctx context.Context, p i18n.Printer, trial storage.Trial, withState bool, rules RosterRules) *cmdhandler.EmbedResponse {
 	r := &cmdhandler.EmbedResponse{}
 
 	if withState {
-		r.Title = fmt.Sprintf("__%s__ (%s)", trial.GetName(ctx), string(trial.GetState(ctx)))
+		r.Title = fmt.Sprintf("__%s__ (%s)", trial.GetName(ctx), stateName(p, trial.GetState(ctx)))
 	} else {
 		r.Title = fmt.Sprintf("__%s__", trial.GetName(ctx))
 	}
 	r.Description = trial.GetDescription(ctx)
 	r.Fields = []cmdhandler.EmbedField{}
 
+	var statusLine string
+	switch {
+	case trial.GetState(ctx) == storage.TrialStateCanceled && trial.GetCancelReason(ctx) != "":
+		statusLine = p.Sprintf("roster.canceled_reason", trial.GetCancelReason(ctx))
+	case trial.GetState(ctx) == storage.TrialStateCanceled:
+		statusLine = p.Sprintf("roster.canceled")
+	case !rules.Lock.IsZero() && rules.Confirming:
+		statusLine = p.Sprintf("roster.confirm_by", trial.GetName(ctx), formatTime(rules.Lock.Deadline))
+	case !rules.Lock.IsZero():
+		statusLine = p.Sprintf("roster.confirm_closed", formatTime(rules.Lock.Deadline))
+	}
+	if statusLine != "" {
+		r.Description = strings.TrimSpace(r.Description + "\n\n" + statusLine)
+	}
+
 	overflowFields := []cmdhandler.EmbedField{}
 
 	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
 	signups := trial.GetSignups(ctx)
 
+	details := make(map[string]storage.SignupDetails, len(signups))
+	for _, su := range signups {
+		details[su.GetName(ctx)] = su.GetDetails(ctx)
+	}
+
 	for _, rc := range roleCounts {
-		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc)
+		roster := splitRoleSignups(ctx, signups, rc, rules)
+		suNames, ofNames := append(roster.reserved, roster.main...), roster.overflow
+		suLines, ofLines := signupLines(suNames, details), signupLines(ofNames, details)
+		for i := range roster.reserved {
+			suLines[i] = p.Sprintf("roster.reserved", suLines[i])
+		}
+		if roster.locked {
+			for i, name := range suNames {
+				switch {
+				case roster.promoted[name]:
+					suLines[i] = p.Sprintf("roster.promoted", suLines[i])
+				case roster.confirmed[name]:
+					suLines[i] = p.Sprintf("roster.confirmed", suLines[i])
+				default:
+					suLines[i] = p.Sprintf("roster.pending", suLines[i])
+				}
+			}
+		}
+
+		if len(suNames) > 0 || roster.unclaimed > 0 {
+			val := ""
+			if len(suLines) > 0 {
+				val = rc.GetEmoji(ctx) + strings.Join(suLines, fmt.Sprintf("\n%s", rc.GetEmoji(ctx))) + "\n"
+			}
+			if roster.unclaimed > 0 {
+				val += p.Sprintf("roster.unclaimed", roster.unclaimed) + "\n"
+			}
 
-		if len(suNames) > 0 {
 			r.Fields = append(r.Fields, cmdhandler.EmbedField{
 				Name: fmt.Sprintf("*%s* (%d/%d)", rc.GetRole(ctx), len(suNames), rc.GetCount(ctx)),
-				Val:  rc.GetEmoji(ctx) + strings.Join(suNames, fmt.Sprintf("\n%s", rc.GetEmoji(ctx))) + "\n_ _\n",
+				Val:  val + "_ _\n",
 			})
 		} else {
 			r.Fields = append(r.Fields, cmdhandler.EmbedField{
 				Name: fmt.Sprintf("*%s* (%d/%d)", rc.GetRole(ctx), len(suNames), rc.GetCount(ctx)),
-				Val:  "(empty)\n_ _\n",
+				Val:  p.Sprintf("roster.empty") + "\n_ _\n",
 			})
 		}
 
 		if len(ofNames) > 0 {
 			overflowFields = append(overflowFields, cmdhandler.EmbedField{
-				Name: fmt.Sprintf("*Overflow %s* (%d)", rc.GetRole(ctx), len(ofNames)),
-				Val:  rc.GetEmoji(ctx) + strings.Join(ofNames, fmt.Sprintf("\n%s", rc.GetEmoji(ctx))) + "\n_ _\n",
+				Name: fmt.Sprintf("*%s* (%d)", p.Sprintf("roster.overflow", rc.GetRole(ctx)), len(ofNames)),
+				Val:  rc.GetEmoji(ctx) + strings.Join(ofLines, fmt.Sprintf("\n%s", rc.GetEmoji(ctx))) + "\n_ _\n",
 			})
 		}
 	}
@@ -187,19 +563,89 @@ func formatTrialDisplay(ctx context.Context, trial storage.Trial, withState bool
 	return r
 }
 
-func signupUser(ctx context.Context, trial storage.Trial, userMentionStr, role string) (bool, error) {
+// trialEvent builds the webhook event for a change to a trial made by the author of msg
+func trialEvent(msg cmdhandler.Message, eventType, trialName string) webhook.Event {
+	return webhook.Event{
+		Type:    eventType,
+		GuildID: msg.GuildID().ToString(),
+		Trial:   trialName,
+		Actor:   cmdhandler.UserMentionString(msg.UserID()),
+	}
+}
+
+func signupUser(ctx context.Context, trial storage.Trial, userMentionStr, role string, rules RosterRules) (bool, error) {
 	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
 	rc, known := roleCountByName(ctx, role, roleCounts)
 	if !known {
-		return false, ErrUnknownRole
+		return false, unknownRole(ctx, role, roleCounts)
 	}
 
+	role = rc.GetRole(ctx)
 	trial.AddSignup(ctx, userMentionStr, role)
 
-	signups := trial.GetSignups(ctx)
-	roleSignups := signupsForRole(ctx, role, signups, false)
+	_, ofNames := getTrialRoleSignups(ctx, trial.GetSignups(ctx), rc, rules)
+	for _, name := range ofNames {
+		if name == userMentionStr {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SignupOpenTrial performs the checks and changes of the signup command for a single trial and role
+//
+// The returned bool is true when the signup landed in the overflow for the role. The caller
+// is responsible for saving the trial.
+func SignupOpenTrial(ctx context.Context, trial storage.Trial, userMentionStr, role string, rules RosterRules) (bool, error) {
+	if trial.GetState(ctx) != storage.TrialStateOpen {
+		return false, ErrSignupClosed
+	}
 
-	overflow := uint64(len(roleSignups)) > rc.GetCount(ctx)
+	return signupUser(ctx, trial, userMentionStr, role, rules)
+}
+
+// WithdrawAllowed reports whether signups can be withdrawn from a trial: while it is open,
+// or once it has been rescheduled until it is closed again, so that those who cannot make
+// the new start can leave
+func WithdrawAllowed(ctx context.Context, trial storage.Trial) bool {
+	switch trial.GetState(ctx) {
+	case storage.TrialStateOpen:
+		return true
+	case storage.TrialStateCanceled:
+		return false
+	default:
+		return !trial.GetRescheduledAt(ctx).IsZero()
+	}
+}
+
+// WithdrawOpenTrial performs the checks and changes of the withdraw command for a single trial
+//
+// The caller is responsible for saving the trial.
+func WithdrawOpenTrial(ctx context.Context, trial storage.Trial, userMentionStr string) error {
+	if !WithdrawAllowed(ctx, trial) {
+		return ErrWithdrawClosed
+	}
+
+	trial.RemoveSignup(ctx, userMentionStr)
+	return nil
+}
+
+// SignupConfirmation is the text reported to a user after signing up for a role
+func SignupConfirmation(p i18n.Printer, trialName, role string, overflow bool) string {
+	if overflow {
+		return p.Sprintf("signup.overflow", role, trialName)
+	}
+	return p.Sprintf("signup.done", role, trialName)
+}
+
+// WithdrawConfirmation is the text reported to a user after withdrawing from a trial
+func WithdrawConfirmation(p i18n.Printer, trialName string) string {
+	return p.Sprintf("withdraw.done", trialName)
+}
 
-	return overflow, nil
+// RosterConfirmation is the text reported to a user after confirming their place in a
+// locked roster
+func RosterConfirmation(p i18n.Printer, trialName string) string {
+	return p.Sprintf("confirm.done", trialName)
 }
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// noticeChannel is where the notices of a trial are posted for members who do not accept
+// direct messages: its signup channel in the guild, or nowhere if that is not found
+func noticeChannel(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, trial storage.Trial) snowflake.Snowflake {
+	if session == nil {
+		return 0
+	}
+
+	g, ok := session.Guild(gid)
+	if !ok {
+		return 0
+	}
+
+	cid, ok := msghandler.ChannelID(&g, trial.GetSignupChannelID(ctx), trial.GetSignupChannel(ctx))
+	if !ok {
+		return 0
+	}
+	return cid
+}
+
+// TrialNotices are a notice of a kind with the same text for each of the members
+func TrialNotices(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, trial storage.Trial, kind, text string, users []string) []notify.Notice {
+	fallback := noticeChannel(ctx, session, gid, trial)
+
+	notices := make([]notify.Notice, 0, len(users))
+	for _, u := range users {
+		notices = append(notices, notify.Notice{
+			GuildID:         gid,
+			User:            u,
+			Kind:            kind,
+			Text:            text,
+			FallbackChannel: fallback,
+		})
+	}
+	return notices
+}
+
+// OverflowSignups are the members in the overflow of any role of a trial, to find who
+// a change promotes with PromotionNotices
+func OverflowSignups(ctx context.Context, trial storage.Trial, rules RosterRules) map[string]bool {
+	overflow := map[string]bool{}
+
+	signups := trial.GetSignups(ctx)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		_, ofNames := getTrialRoleSignups(ctx, signups, rc, rules)
+		for _, name := range ofNames {
+			overflow[name] = true
+		}
+	}
+
+	return overflow
+}
+
+// PromotionNotices tell the members in the main group of a trial who were in the overflow
+// before a change that they moved up
+func PromotionNotices(ctx context.Context, session *etfapi.Session, p i18n.Printer, gid snowflake.Snowflake, trial storage.Trial, rules RosterRules, overflowBefore map[string]bool) []notify.Notice {
+	var notices []notify.Notice
+
+	signups := trial.GetSignups(ctx)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		var promoted []string
+
+		suNames, _ := getTrialRoleSignups(ctx, signups, rc, rules)
+		for _, name := range suNames {
+			if overflowBefore[name] {
+				promoted = append(promoted, name)
+			}
+		}
+
+		text := p.Sprintf("dm.promoted", trial.GetName(ctx), rc.GetRole(ctx))
+		notices = append(notices, TrialNotices(ctx, session, gid, trial, notify.KindPromotion, text, promoted)...)
+	}
+
+	return notices
+}
+
+// DeadlineNotices tell the members promoted when the confirmation deadline of a locked
+// roster passed that they moved up
+func DeadlineNotices(ctx context.Context, session *etfapi.Session, p i18n.Printer, gid snowflake.Snowflake, trial storage.Trial, rules RosterRules) []notify.Notice {
+	var notices []notify.Notice
+
+	signups := trial.GetSignups(ctx)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		var promoted []string
+
+		roster := splitRoleSignups(ctx, signups, rc, rules)
+		for _, name := range roster.main {
+			if roster.promoted[name] {
+				promoted = append(promoted, name)
+			}
+		}
+
+		text := p.Sprintf("dm.promoted", trial.GetName(ctx), rc.GetRole(ctx))
+		notices = append(notices, TrialNotices(ctx, session, gid, trial, notify.KindPromotion, text, promoted)...)
+	}
+
+	return notices
+}
+
+// ReminderNotices remind the main group of each role of a trial that it starts soon
+func ReminderNotices(ctx context.Context, session *etfapi.Session, p i18n.Printer, gid snowflake.Snowflake, trial storage.Trial, rules RosterRules) []notify.Notice {
+	var notices []notify.Notice
+
+	start := formatTime(trial.GetStartTime(ctx))
+	signups := trial.GetSignups(ctx)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		suNames, _ := getTrialRoleSignups(ctx, signups, rc, rules)
+
+		text := p.Sprintf("dm.reminder", trial.GetName(ctx), start, rc.GetRole(ctx))
+		notices = append(notices, TrialNotices(ctx, session, gid, trial, notify.KindReminder, text, suNames)...)
+	}
+
+	return notices
+}
+
+// DueReminder reports whether the members of a trial are to be reminded now that it starts
+// within lead, if they were not already and it is not canceled
+func DueReminder(ctx context.Context, trial storage.Trial, now time.Time, lead time.Duration) bool {
+	start := trial.GetStartTime(ctx)
+	if start.IsZero() || !trial.GetRemindedAt(ctx).IsZero() || trial.GetState(ctx) == storage.TrialStateCanceled {
+		return false
+	}
+
+	return start.After(now) && !start.After(now.Add(lead))
+}
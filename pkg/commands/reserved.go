@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+var (
+	mentionedUser = regexp.MustCompile(`^<@!?(\d+)>$`)
+	mentionedRole = regexp.MustCompile(`^<@&(\d+)>$`)
+)
+
+// mentionID is the id in a user mention, including the negative ids of old signups
+func mentionID(mention string) string {
+	return strings.TrimLeft(strings.TrimSuffix(mention, ">"), "<@!")
+}
+
+// mayClaim reports whether a member may take one of the reserved slots of a role
+func (r RosterRules) mayClaim(res storage.Reservation, mention string) bool {
+	id := mentionID(mention)
+	for _, u := range res.Users {
+		if mentionID(u) == id {
+			return true
+		}
+	}
+
+	return res.RoleID != "" && r.HasRole != nil && r.HasRole(mention, res.RoleID)
+}
+
+// roleRoster is the signups of a role split into the reserved slots, the rest of the main
+// group, and the overflow, with the number of reserved slots still held open
+//
+// The split of a locked roster also has the main group members who confirmed and those
+// promoted from the overflow once the deadline passed
+type roleRoster struct {
+	reserved  []string
+	main      []string
+	overflow  []string
+	unclaimed uint64
+
+	locked    bool
+	confirmed map[string]bool
+	promoted  map[string]bool
+}
+
+// splitRoleSignups fills the reserved slots of a role with the first members who may claim
+// them, wherever they are in roster order, then the remaining slots in roster order; a
+// locked roster is split as it was when locked
+func splitRoleSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount, rules RosterRules) roleRoster {
+	if !rules.Lock.IsZero() {
+		return splitLockedSignups(ctx, signups, rc, rules)
+	}
+
+	var roster roleRoster
+
+	signups = rules.order(ctx, signups)
+
+	count := rc.GetCount(ctx)
+	res := rc.GetReservation(ctx)
+
+	var held uint64
+	if !rules.Released {
+		held = res.Slots
+		if held > count {
+			held = count
+		}
+	}
+
+	lowerRole := strings.ToLower(rc.GetRole(ctx))
+	rest := make([]string, 0, len(signups))
+	for _, su := range signups {
+		if strings.ToLower(su.GetRole(ctx)) != lowerRole {
+			continue
+		}
+
+		if uint64(len(roster.reserved)) < held && rules.mayClaim(res, su.GetName(ctx)) {
+			roster.reserved = append(roster.reserved, su.GetName(ctx))
+			continue
+		}
+
+		rest = append(rest, su.GetName(ctx))
+	}
+
+	roster.unclaimed = held - uint64(len(roster.reserved))
+
+	open := count - held
+	for _, name := range rest {
+		if uint64(len(roster.main)) < open {
+			roster.main = append(roster.main, name)
+		} else {
+			roster.overflow = append(roster.overflow, name)
+		}
+	}
+
+	return roster
+}
+
+// parseReserveString parses `role:slots:who:who,role:slots:who` into the reservation of
+// each role, where each who is a user mention or a discord role mention or name; a role
+// with 0 slots has its reservation removed
+func parseReserveString(session *etfapi.Session, gid snowflake.Snowflake, args string) (map[string]storage.Reservation, error) {
+	reservations := map[string]storage.Reservation{}
+
+	for _, roleStr := range strings.Split(strings.TrimSpace(args), ",") {
+		if roleStr == "" {
+			continue
+		}
+
+		parts := strings.Split(roleStr, ":")
+		if len(parts) < 2 || parts[0] == "" {
+			return reservations, i18n.NewError("err.bad_reserve", roleStr)
+		}
+
+		slots, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return reservations, i18n.NewError("err.bad_role_count", parts[1])
+		}
+
+		if slots == 0 {
+			reservations[parts[0]] = storage.Reservation{}
+			continue
+		}
+
+		res := storage.Reservation{Slots: slots}
+		for _, who := range parts[2:] {
+			switch {
+			case who == "":
+				continue
+
+			case mentionedUser.MatchString(who):
+				uid, err := snowflake.FromString(mentionedUser.FindStringSubmatch(who)[1])
+				if err != nil {
+					return reservations, i18n.NewError("err.bad_reserve", roleStr)
+				}
+				res.Users = append(res.Users, cmdhandler.UserMentionString(uid))
+
+			case res.RoleID != "":
+				return reservations, i18n.NewError("err.reserve_one_role", parts[0])
+
+			case mentionedRole.MatchString(who):
+				res.RoleID = mentionedRole.FindStringSubmatch(who)[1]
+
+			default:
+				rid, err := guildRoleID(session, gid, strings.TrimPrefix(who, "@"))
+				if err != nil {
+					return reservations, err
+				}
+				res.RoleID = rid
+			}
+		}
+
+		if len(res.Users) == 0 && res.RoleID == "" {
+			return reservations, i18n.NewError("err.bad_reserve", roleStr)
+		}
+
+		reservations[parts[0]] = res
+	}
+
+	return reservations, nil
+}
+
+func guildRoleID(session *etfapi.Session, gid snowflake.Snowflake, name string) (string, error) {
+	g, ok := session.Guild(gid)
+	if !ok {
+		return "", ErrGuildNotFound
+	}
+
+	rid, ok := g.RoleWithName(name)
+	if !ok {
+		return "", i18n.NewError("err.unknown_guild_role", name)
+	}
+
+	return rid.ToString(), nil
+}
+
+// setReservations applies the reserve= and release= settings to a trial whose roles are
+// already set
+func setReservations(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, trial storage.Trial, settingMap map[string]string) error {
+	if v, ok := settingMap["release"]; ok {
+		d, err := parseDuration(v)
+		if err != nil {
+			return err
+		}
+		trial.SetReserveRelease(ctx, d)
+	}
+
+	v, ok := settingMap["reserve"]
+	if !ok {
+		return nil
+	}
+
+	reservations, err := parseReserveString(session, gid, v)
+	if err != nil {
+		return err
+	}
+
+	for role, res := range reservations {
+		rc, ok := roleCountByName(ctx, role, trial.GetRoleCounts(ctx))
+		if !ok {
+			return i18n.NewError("err.reserve_unknown_role", role)
+		}
+
+		if res.Slots > rc.GetCount(ctx) {
+			return i18n.NewError("err.reserve_too_many", rc.GetRole(ctx), rc.GetCount(ctx))
+		}
+
+		trial.SetRoleReservation(ctx, rc.GetRole(ctx), res)
+	}
+
+	return nil
+}
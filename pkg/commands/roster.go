@@ -0,0 +1,459 @@
+package commands
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// defaultConfirmWindow is how long the main group of a locked roster has to confirm when no
+// deadline is given
+const defaultConfirmWindow = 24 * time.Hour
+
+// RosterRules are what the split of a role's signups depends on besides the signups
+// themselves: who may claim the reserved slots, whether unclaimed ones are released, and
+// the order the trial's policy puts the signups in
+type RosterRules struct {
+	// HasRole reports whether the member with a user mention has the discord role with an id
+	HasRole  func(mention, roleID string) bool
+	Released bool
+
+	Policy storage.RosterPolicy
+	Tiers  []string
+	Seed   int64
+	// Attendance is the number of past events each member, by mention id, stayed signed up for
+	Attendance map[string]int
+	// Order is the stored order of a locked roster, which takes the place of the policy
+	Order []string
+
+	// Lock is the snapshot taken by !admin lock, which takes the place of the split of the
+	// signups, and Confirming is whether its deadline has yet to pass
+	Lock       storage.LockedRoster
+	Confirming bool
+}
+
+// NewRosterRules builds the roster rules of a trial as of now, checking roles against the
+// guild as the session sees it; unscheduled trials hold their reservations until changed
+//
+// att should be shared by every call in a transaction, so that past attendance is only
+// counted once
+func NewRosterRules(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, att *PastAttendance, trial storage.Trial, now time.Time) RosterRules {
+	rules := RosterRules{
+		HasRole: func(mention, roleID string) bool {
+			if session == nil {
+				return false
+			}
+
+			g, ok := session.Guild(gid)
+			if !ok {
+				return false
+			}
+
+			uid, err := snowflake.FromString(mentionID(mention))
+			if err != nil {
+				return false
+			}
+
+			rid, err := snowflake.FromString(roleID)
+			if err != nil {
+				return false
+			}
+
+			return g.HasRole(uid, rid)
+		},
+		Policy: trial.GetRosterPolicy(ctx),
+		Tiers:  trial.GetPriorityRoles(ctx),
+		Seed:   trial.GetLotterySeed(ctx),
+		Order:  trial.GetRosterOrder(ctx),
+		Lock:   trial.GetLockedRoster(ctx),
+	}
+	rules.Confirming = now.Before(rules.Lock.Deadline)
+
+	if start := trial.GetStartTime(ctx); !start.IsZero() {
+		rules.Released = !now.Before(start.Add(-trial.GetReserveRelease(ctx)))
+	}
+
+	if rules.Policy == storage.RosterAttendance && len(rules.Order) == 0 {
+		rules.Attendance = att.except(ctx, trial.GetName(ctx), now)
+	}
+
+	return rules
+}
+
+// PastAttendance counts, for each member by mention id, the closed events of a guild that
+// have started (or were never scheduled) and that the member did not withdraw from
+//
+// The events are only read the first time a roster needs the counts
+type PastAttendance struct {
+	t       storage.TrialAPITx
+	counted bool
+	scores  map[string]int
+	byTrial map[string][]string
+}
+
+// NewPastAttendance creates a new PastAttendance object for the trials in a transaction
+func NewPastAttendance(t storage.TrialAPITx) *PastAttendance {
+	return &PastAttendance{t: t}
+}
+
+func (a *PastAttendance) count(ctx context.Context, now time.Time) {
+	a.counted = true
+	a.scores = map[string]int{}
+	a.byTrial = map[string][]string{}
+
+	for _, trial := range a.t.GetTrials(ctx) {
+		if trial.GetState(ctx) != storage.TrialStateClosed {
+			continue
+		}
+
+		if start := trial.GetStartTime(ctx); !start.IsZero() && start.After(now) {
+			continue
+		}
+
+		ids := make([]string, 0, len(trial.GetSignups(ctx)))
+		for _, su := range trial.GetSignups(ctx) {
+			id := mentionID(su.GetName(ctx))
+			a.scores[id]++
+			ids = append(ids, id)
+		}
+		a.byTrial[trial.GetName(ctx)] = ids
+	}
+}
+
+// except is the attendance of the members at every event but one, as the event itself
+// does not count toward its own roster
+func (a *PastAttendance) except(ctx context.Context, name string, now time.Time) map[string]int {
+	if a == nil {
+		return nil
+	}
+
+	if !a.counted {
+		a.count(ctx, now)
+	}
+
+	own := a.byTrial[name]
+	if len(own) == 0 {
+		return a.scores
+	}
+
+	scores := make(map[string]int, len(a.scores))
+	for id, n := range a.scores {
+		scores[id] = n
+	}
+	for _, id := range own {
+		scores[id]--
+	}
+
+	return scores
+}
+
+// tier is the position of the first priority role a member has, or the number of roles
+// for members without any
+func (r RosterRules) tier(mention string) int {
+	for i, rid := range r.Tiers {
+		if r.HasRole != nil && r.HasRole(mention, rid) {
+			return i
+		}
+	}
+	return len(r.Tiers)
+}
+
+// order puts signups in roster order: the stored order of a locked roster, with later
+// signups after it, or else the order of the policy, keeping signup order among equals
+func (r RosterRules) order(ctx context.Context, signups []storage.TrialSignup) []storage.TrialSignup {
+	ordered := append([]storage.TrialSignup(nil), signups...)
+
+	var rank func(su storage.TrialSignup) int
+	switch {
+	case len(r.Order) > 0:
+		pos := make(map[string]int, len(r.Order))
+		for i, name := range r.Order {
+			pos[mentionID(name)] = i
+		}
+		rank = func(su storage.TrialSignup) int {
+			if i, ok := pos[mentionID(su.GetName(ctx))]; ok {
+				return i
+			}
+			return len(r.Order)
+		}
+
+	case r.Policy == storage.RosterTiers:
+		tiers := make(map[string]int, len(ordered))
+		for _, su := range ordered {
+			tiers[su.GetName(ctx)] = r.tier(su.GetName(ctx))
+		}
+		rank = func(su storage.TrialSignup) int {
+			return tiers[su.GetName(ctx)]
+		}
+
+	case r.Policy == storage.RosterAttendance:
+		rank = func(su storage.TrialSignup) int {
+			return -r.Attendance[mentionID(su.GetName(ctx))]
+		}
+
+	case r.Policy == storage.RosterLottery && r.Seed != 0:
+		// the signups are shuffled in signup order, so the same seed draws the same roster
+		rng := rand.New(rand.NewSource(r.Seed))
+		rng.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+		return ordered
+
+	default:
+		return ordered
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+
+	return ordered
+}
+
+// lockRoster stores the order of a trial's signups under its policy, drawing the lottery
+// first if it has not been, so that the roster no longer changes with roles or attendance
+func lockRoster(ctx context.Context, trial storage.Trial, rules RosterRules) {
+	if rules.Policy == storage.RosterLottery && rules.Seed == 0 {
+		rules.Seed = newLotterySeed()
+		trial.SetLotterySeed(ctx, rules.Seed)
+	}
+
+	rules.Order = nil
+	signups := rules.order(ctx, trial.GetSignups(ctx))
+
+	names := make([]string, 0, len(signups))
+	for _, su := range signups {
+		names = append(names, su.GetName(ctx))
+	}
+
+	trial.SetRosterOrder(ctx, names)
+}
+
+// splitLockedSignups splits the signups for a role as the locked roster had them, with later
+// signups at the end of the overflow; once the deadline passes, the main group members who
+// did not confirm go to the end of the overflow and the first of the overflow fill the slots
+func splitLockedSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount, rules RosterRules) roleRoster {
+	roster := roleRoster{
+		locked:    true,
+		confirmed: map[string]bool{},
+		promoted:  map[string]bool{},
+	}
+
+	lowerRole := strings.ToLower(rc.GetRole(ctx))
+	signedUp := map[string]bool{}
+	for _, su := range signups {
+		if strings.ToLower(su.GetRole(ctx)) == lowerRole {
+			signedUp[su.GetName(ctx)] = true
+		}
+	}
+
+	locked := map[string]bool{}
+	var dropped []string
+	for _, ls := range rules.Lock.Signups {
+		if strings.ToLower(ls.Role) != lowerRole || !signedUp[ls.Name] {
+			continue
+		}
+		locked[ls.Name] = true
+
+		switch {
+		case ls.Overflow:
+			roster.overflow = append(roster.overflow, ls.Name)
+		case rules.Lock.HasConfirmed(ls.Name):
+			roster.confirmed[ls.Name] = true
+			roster.main = append(roster.main, ls.Name)
+		case rules.Confirming:
+			roster.main = append(roster.main, ls.Name)
+		default:
+			dropped = append(dropped, ls.Name)
+		}
+	}
+
+	for _, su := range rules.order(ctx, signups) {
+		if name := su.GetName(ctx); signedUp[name] && !locked[name] {
+			roster.overflow = append(roster.overflow, name)
+		}
+	}
+
+	if rules.Confirming {
+		return roster
+	}
+
+	for uint64(len(roster.main)) < rc.GetCount(ctx) && len(roster.overflow) > 0 {
+		roster.promoted[roster.overflow[0]] = true
+		roster.main = append(roster.main, roster.overflow[0])
+		roster.overflow = roster.overflow[1:]
+	}
+	roster.overflow = append(roster.overflow, dropped...)
+
+	return roster
+}
+
+// snapshotRoster is the locked roster of a trial as the rules split it now
+func snapshotRoster(ctx context.Context, trial storage.Trial, rules RosterRules, now, deadline time.Time) storage.LockedRoster {
+	lr := storage.LockedRoster{
+		LockedAt: now,
+		Deadline: deadline,
+	}
+
+	signups := trial.GetSignups(ctx)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc, rules)
+		for _, name := range suNames {
+			lr.Signups = append(lr.Signups, storage.LockedSignup{Name: name, Role: rc.GetRole(ctx)})
+		}
+		for _, name := range ofNames {
+			lr.Signups = append(lr.Signups, storage.LockedSignup{Name: name, Role: rc.GetRole(ctx), Overflow: true})
+		}
+	}
+
+	return lr
+}
+
+// lockDeadline is when the main group must confirm by: the given duration from now or
+// time, or else a day from now, or the start if that is sooner
+func lockDeadline(ctx context.Context, trial storage.Trial, val string, now time.Time) (time.Time, error) {
+	if val == "" {
+		deadline := now.Add(defaultConfirmWindow)
+		if start := trial.GetStartTime(ctx); start.After(now) && start.Before(deadline) {
+			deadline = start
+		}
+		return deadline, nil
+	}
+
+	if d, err := time.ParseDuration(val); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+
+	deadline, err := parseStartTime(val)
+	if err != nil || !deadline.After(now) {
+		return time.Time{}, i18n.NewError("err.bad_deadline", val)
+	}
+
+	return deadline, nil
+}
+
+// ConfirmLockedTrial performs the checks and changes of the confirm command for a single trial
+//
+// The caller is responsible for saving the trial.
+func ConfirmLockedTrial(ctx context.Context, trial storage.Trial, userMentionStr string, now time.Time) error {
+	if trial.GetState(ctx) == storage.TrialStateCanceled {
+		return i18n.NewError("err.canceled", trial.GetName(ctx))
+	}
+
+	lr := trial.GetLockedRoster(ctx)
+	if lr.IsZero() {
+		return i18n.NewError("err.not_locked", trial.GetName(ctx))
+	}
+
+	if !now.Before(lr.Deadline) {
+		return i18n.NewError("err.confirm_closed", trial.GetName(ctx))
+	}
+
+	signedUp := false
+	for _, su := range trial.GetSignups(ctx) {
+		signedUp = signedUp || su.GetName(ctx) == userMentionStr
+	}
+
+	inMain := false
+	for _, ls := range lr.Signups {
+		inMain = inMain || (ls.Name == userMentionStr && !ls.Overflow)
+	}
+
+	if !signedUp || !inMain {
+		return i18n.NewError("err.not_in_main_group", trial.GetName(ctx))
+	}
+
+	if !lr.HasConfirmed(userMentionStr) {
+		lr.Confirmed = append(lr.Confirmed, userMentionStr)
+		trial.SetLockedRoster(ctx, lr)
+	}
+
+	return nil
+}
+
+// formatTime is how deadlines and starts are shown in messages, as in the event settings
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
+// newLotterySeed is never 0, which marks a lottery that has not been drawn
+func newLotterySeed() int64 {
+	if seed := time.Now().UnixNano(); seed != 0 {
+		return seed
+	}
+	return 1
+}
+
+// parsePolicy parses the policy= setting
+func parsePolicy(val string) (storage.RosterPolicy, error) {
+	switch policy := storage.RosterPolicy(strings.ToLower(val)); policy {
+	case storage.RosterFirstCome, storage.RosterTiers, storage.RosterAttendance, storage.RosterLottery:
+		return policy, nil
+	case "":
+		return storage.RosterFirstCome, nil
+	default:
+		return "", i18n.NewError("err.unknown_policy", val)
+	}
+}
+
+// parseTiers parses the tiers= setting, discord roles by mention or name from the highest
+// priority down, into role ids
+func parseTiers(session *etfapi.Session, gid snowflake.Snowflake, val string) ([]string, error) {
+	var roleIDs []string
+
+	for _, who := range strings.Split(strings.TrimSpace(val), ",") {
+		who = strings.TrimSpace(who)
+		if who == "" {
+			continue
+		}
+
+		if m := mentionedRole.FindStringSubmatch(who); m != nil {
+			roleIDs = append(roleIDs, m[1])
+			continue
+		}
+
+		rid, err := guildRoleID(session, gid, strings.TrimPrefix(who, "@"))
+		if err != nil {
+			return nil, err
+		}
+		roleIDs = append(roleIDs, rid)
+	}
+
+	return roleIDs, nil
+}
+
+// setPolicy applies the policy= and tiers= settings to a trial, reporting whether either
+// was given so that a locked roster can be ordered again
+func setPolicy(ctx context.Context, session *etfapi.Session, gid snowflake.Snowflake, trial storage.Trial, settingMap map[string]string) (bool, error) {
+	v, hasPolicy := settingMap["policy"]
+	if hasPolicy {
+		policy, err := parsePolicy(v)
+		if err != nil {
+			return false, err
+		}
+		trial.SetRosterPolicy(ctx, policy)
+	}
+
+	v, hasTiers := settingMap["tiers"]
+	if hasTiers {
+		roleIDs, err := parseTiers(session, gid, v)
+		if err != nil {
+			return false, err
+		}
+		trial.SetPriorityRoles(ctx, roleIDs)
+	}
+
+	if trial.GetRosterPolicy(ctx) == storage.RosterTiers && len(trial.GetPriorityRoles(ctx)) == 0 {
+		return false, i18n.NewError("err.policy_needs_tiers")
+	}
+
+	return hasPolicy || hasTiers, nil
+}
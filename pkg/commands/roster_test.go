@@ -0,0 +1,712 @@
+package commands
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type testSignup struct {
+	name string
+	role string
+}
+
+func (s testSignup) GetName(context.Context) string                   { return s.name }
+func (s testSignup) GetRole(context.Context) string                   { return s.role }
+func (s testSignup) IsCanceled(context.Context) bool                  { return false }
+func (s testSignup) GetDetails(context.Context) storage.SignupDetails { return storage.SignupDetails{} }
+
+type testRoleCount struct {
+	role  string
+	count uint64
+	res   storage.Reservation
+}
+
+func (rc testRoleCount) GetRole(context.Context) string                     { return rc.role }
+func (rc testRoleCount) GetCount(context.Context) uint64                    { return rc.count }
+func (rc testRoleCount) GetEmoji(context.Context) string                    { return "" }
+func (rc testRoleCount) GetAliases(context.Context) []string                { return nil }
+func (rc testRoleCount) GetReservation(context.Context) storage.Reservation { return rc.res }
+
+// signups are the members in signup order, all for the same role
+func signups(role string, names ...string) []storage.TrialSignup {
+	sus := make([]storage.TrialSignup, 0, len(names))
+	for _, name := range names {
+		sus = append(sus, testSignup{name: name, role: role})
+	}
+	return sus
+}
+
+func signupNames(ctx context.Context, sus []storage.TrialSignup) []string {
+	names := make([]string, 0, len(sus))
+	for _, su := range sus {
+		names = append(names, su.GetName(ctx))
+	}
+	return names
+}
+
+// hasRoles is a RosterRules.HasRole with the discord role ids of each member by mention
+func hasRoles(roles map[string][]string) func(mention, roleID string) bool {
+	return func(mention, roleID string) bool {
+		for _, rid := range roles[mention] {
+			if rid == roleID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// testTrials is a writable transaction on the trials of a guild in a new database, with a
+// function to close it all
+func testTrials(t *testing.T) (storage.TrialAPITx, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "commands-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		os.RemoveAll(dir) // nolint: errcheck
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	closeAll := func() {
+		db.Close()        // nolint: errcheck
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+
+	api, err := storage.NewBoltTrialAPI(db, nil)
+	if err != nil {
+		closeAll()
+		t.Fatalf("could not create trial api: %v", err)
+	}
+
+	tx, err := api.NewTransaction(context.Background(), "1", true)
+	if err != nil {
+		closeAll()
+		t.Fatalf("could not start transaction: %v", err)
+	}
+
+	return tx, func() {
+		tx.Rollback(context.Background()) // nolint: errcheck
+		closeAll()
+	}
+}
+
+// addTestTrial saves a trial with a single dps role and the members signed up for it
+func addTestTrial(ctx context.Context, t *testing.T, tx storage.TrialAPITx, name string, state storage.TrialState, start time.Time, names ...string) storage.Trial {
+	t.Helper()
+
+	trial, err := tx.AddTrial(ctx, name)
+	if err != nil {
+		t.Fatalf("could not add trial %q: %v", name, err)
+	}
+
+	trial.SetState(ctx, state)
+	trial.SetStartTime(ctx, start)
+	trial.SetRoleCount(ctx, "dps", "", 1)
+	for _, n := range names {
+		trial.AddSignup(ctx, n, "dps")
+	}
+
+	if err = tx.SaveTrial(ctx, trial); err != nil {
+		t.Fatalf("could not save trial %q: %v", name, err)
+	}
+
+	return trial
+}
+
+func errorKey(err error) string {
+	if err == nil {
+		return ""
+	}
+	if le, ok := err.(*i18n.Error); ok {
+		return le.Key()
+	}
+	return err.Error()
+}
+
+func TestRosterRulesOrder(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		rules RosterRules
+		want  []string
+	}{
+		{
+			name:  "first come keeps signup order",
+			rules: RosterRules{Policy: storage.RosterFirstCome},
+			want:  []string{"<@1>", "<@2>", "<@3>", "<@4>"},
+		},
+		{
+			name: "tiers put the first priority role first, then signup order",
+			rules: RosterRules{
+				Policy:  storage.RosterTiers,
+				Tiers:   []string{"10", "20"},
+				HasRole: hasRoles(map[string][]string{"<@2>": {"20"}, "<@3>": {"10"}, "<@4>": {"20", "10"}}),
+			},
+			want: []string{"<@3>", "<@4>", "<@2>", "<@1>"},
+		},
+		{
+			name:  "tiers without a role check keep signup order",
+			rules: RosterRules{Policy: storage.RosterTiers, Tiers: []string{"10"}},
+			want:  []string{"<@1>", "<@2>", "<@3>", "<@4>"},
+		},
+		{
+			name: "attendance puts the most events first, then signup order",
+			rules: RosterRules{
+				Policy:     storage.RosterAttendance,
+				Attendance: map[string]int{"2": 1, "3": 3, "4": 1},
+			},
+			want: []string{"<@3>", "<@2>", "<@4>", "<@1>"},
+		},
+		{
+			name:  "an undrawn lottery keeps signup order",
+			rules: RosterRules{Policy: storage.RosterLottery},
+			want:  []string{"<@1>", "<@2>", "<@3>", "<@4>"},
+		},
+		{
+			name: "a stored order wins over the policy, with later signups after it",
+			rules: RosterRules{
+				Policy: storage.RosterTiers,
+				Tiers:  []string{"10"},
+				Order:  []string{"<@4>", "<@!2>"},
+			},
+			want: []string{"<@4>", "<@2>", "<@1>", "<@3>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := signupNames(ctx, tt.rules.order(ctx, signups("dps", "<@1>", "<@2>", "<@3>", "<@4>")))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRosterRulesOrderLottery(t *testing.T) {
+	ctx := context.Background()
+
+	names := []string{"<@1>", "<@2>", "<@3>", "<@4>", "<@5>", "<@6>", "<@7>", "<@8>"}
+	sus := signups("dps", names...)
+
+	draw := func(seed int64) []string {
+		return signupNames(ctx, RosterRules{Policy: storage.RosterLottery, Seed: seed}.order(ctx, sus))
+	}
+
+	first := draw(42)
+	if again := draw(42); !reflect.DeepEqual(first, again) {
+		t.Errorf("the same seed drew %v, then %v", first, again)
+	}
+
+	sorted := append([]string(nil), first...)
+	sort.Strings(sorted)
+	if !reflect.DeepEqual(sorted, names) {
+		t.Errorf("draw %v is not a shuffle of %v", first, names)
+	}
+
+	if got := signupNames(ctx, sus); !reflect.DeepEqual(got, names) {
+		t.Errorf("the draw reordered the signups themselves: %v", got)
+	}
+
+	differs := false
+	for seed := int64(1); seed <= 10 && !differs; seed++ {
+		differs = !reflect.DeepEqual(draw(seed), first)
+	}
+	if !differs {
+		t.Errorf("ten seeds all drew %v", first)
+	}
+}
+
+func TestSplitRoleSignups(t *testing.T) {
+	ctx := context.Background()
+
+	sus := append(signups("dps", "<@1>", "<@2>", "<@3>", "<@4>"), testSignup{name: "<@5>", role: "tank"})
+
+	tests := []struct {
+		name          string
+		rc            testRoleCount
+		rules         RosterRules
+		wantReserved  []string
+		wantMain      []string
+		wantOverflow  []string
+		wantUnclaimed uint64
+	}{
+		{
+			name:         "no reservation fills in roster order",
+			rc:           testRoleCount{role: "DPS", count: 3},
+			wantMain:     []string{"<@1>", "<@2>", "<@3>"},
+			wantOverflow: []string{"<@4>"},
+		},
+		{
+			name:         "reserved slots fill before open slots whatever the signup order",
+			rc:           testRoleCount{role: "dps", count: 3, res: storage.Reservation{Slots: 1, Users: []string{"<@!4>"}}},
+			wantReserved: []string{"<@4>"},
+			wantMain:     []string{"<@1>", "<@2>"},
+			wantOverflow: []string{"<@3>"},
+		},
+		{
+			name: "members of a discord role claim reserved slots",
+			rc:   testRoleCount{role: "dps", count: 2, res: storage.Reservation{Slots: 1, RoleID: "10"}},
+			rules: RosterRules{
+				HasRole: hasRoles(map[string][]string{"<@3>": {"10"}, "<@4>": {"10"}}),
+			},
+			wantReserved: []string{"<@3>"},
+			wantMain:     []string{"<@1>"},
+			wantOverflow: []string{"<@2>", "<@4>"},
+		},
+		{
+			name:          "unclaimed slots are held open",
+			rc:            testRoleCount{role: "dps", count: 3, res: storage.Reservation{Slots: 2, Users: []string{"<@3>"}}},
+			wantReserved:  []string{"<@3>"},
+			wantMain:      []string{"<@1>"},
+			wantOverflow:  []string{"<@2>", "<@4>"},
+			wantUnclaimed: 1,
+		},
+		{
+			name:         "released slots are open to everyone",
+			rc:           testRoleCount{role: "dps", count: 3, res: storage.Reservation{Slots: 2, Users: []string{"<@4>"}}},
+			rules:        RosterRules{Released: true},
+			wantMain:     []string{"<@1>", "<@2>", "<@3>"},
+			wantOverflow: []string{"<@4>"},
+		},
+		{
+			name:         "reserved slots are capped at the role count",
+			rc:           testRoleCount{role: "dps", count: 1, res: storage.Reservation{Slots: 3, Users: []string{"<@2>"}}},
+			wantReserved: []string{"<@2>"},
+			wantOverflow: []string{"<@1>", "<@3>", "<@4>"},
+		},
+		{
+			name: "reserved slots fill in the order of the policy",
+			rc:   testRoleCount{role: "dps", count: 2, res: storage.Reservation{Slots: 1, Users: []string{"<@2>", "<@4>"}}},
+			rules: RosterRules{
+				Policy:     storage.RosterAttendance,
+				Attendance: map[string]int{"4": 2},
+			},
+			wantReserved: []string{"<@4>"},
+			wantMain:     []string{"<@1>"},
+			wantOverflow: []string{"<@2>", "<@3>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitRoleSignups(ctx, sus, tt.rc, tt.rules)
+
+			if !reflect.DeepEqual(got.reserved, tt.wantReserved) {
+				t.Errorf("reserved = %v, want %v", got.reserved, tt.wantReserved)
+			}
+			if !reflect.DeepEqual(got.main, tt.wantMain) {
+				t.Errorf("main = %v, want %v", got.main, tt.wantMain)
+			}
+			if !reflect.DeepEqual(got.overflow, tt.wantOverflow) {
+				t.Errorf("overflow = %v, want %v", got.overflow, tt.wantOverflow)
+			}
+			if got.unclaimed != tt.wantUnclaimed {
+				t.Errorf("unclaimed = %d, want %d", got.unclaimed, tt.wantUnclaimed)
+			}
+		})
+	}
+}
+
+func TestParseReserveString(t *testing.T) {
+	tests := []struct {
+		args    string
+		want    map[string]storage.Reservation
+		wantErr string
+	}{
+		{
+			args: "dps:2:<@1>:<@!2>,tank:1:<@&10>",
+			want: map[string]storage.Reservation{
+				"dps":  {Slots: 2, Users: []string{"<@!1>", "<@!2>"}},
+				"tank": {Slots: 1, RoleID: "10"},
+			},
+		},
+		{
+			args: "dps:1:<@1>:<@&10>:",
+			want: map[string]storage.Reservation{
+				"dps": {Slots: 1, Users: []string{"<@!1>"}, RoleID: "10"},
+			},
+		},
+		{
+			args: "dps:0",
+			want: map[string]storage.Reservation{"dps": {}},
+		},
+		{
+			args: " ",
+			want: map[string]storage.Reservation{},
+		},
+		{args: "dps", wantErr: "err.bad_reserve"},
+		{args: ":2:<@1>", wantErr: "err.bad_reserve"},
+		{args: "dps:2", wantErr: "err.bad_reserve"},
+		{args: "dps:2::", wantErr: "err.bad_reserve"},
+		{args: "dps:two:<@1>", wantErr: "err.bad_role_count"},
+		{args: "dps:-1:<@1>", wantErr: "err.bad_role_count"},
+		{args: "dps:2:<@&10>:<@&20>", wantErr: "err.reserve_one_role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, err := parseReserveString(nil, 0, tt.args)
+			if key := errorKey(err); key != tt.wantErr {
+				t.Fatalf("parseReserveString(%q) error = %q, want %q", tt.args, key, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseReserveString(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPastAttendance(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	tx, done := testTrials(t)
+	defer done()
+
+	addTestTrial(ctx, t, tx, "past1", storage.TrialStateClosed, now.Add(-48*time.Hour), "<@2>", "<@3>")
+	addTestTrial(ctx, t, tx, "past2", storage.TrialStateClosed, time.Time{}, "<@!3>")
+	addTestTrial(ctx, t, tx, "future", storage.TrialStateClosed, now.Add(time.Hour), "<@1>", "<@4>")
+	addTestTrial(ctx, t, tx, "other", storage.TrialStateOpen, now.Add(-time.Hour), "<@1>")
+	addTestTrial(ctx, t, tx, "gone", storage.TrialStateCanceled, now.Add(-time.Hour), "<@1>")
+	trial := addTestTrial(ctx, t, tx, "next", storage.TrialStateOpen, now.Add(24*time.Hour), "<@1>", "<@2>", "<@3>")
+	trial.SetRosterPolicy(ctx, storage.RosterAttendance)
+
+	att := NewPastAttendance(tx)
+
+	rules := NewRosterRules(ctx, nil, 1, att, trial, now)
+	if want := map[string]int{"2": 1, "3": 2}; !reflect.DeepEqual(rules.Attendance, want) {
+		t.Errorf("Attendance = %v, want %v", rules.Attendance, want)
+	}
+
+	if got, want := signupNames(ctx, rules.order(ctx, trial.GetSignups(ctx))), []string{"<@3>", "<@2>", "<@1>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order() = %v, want %v", got, want)
+	}
+
+	// an event does not count toward its own roster
+	past1, err := tx.GetTrial(ctx, "past1")
+	if err != nil {
+		t.Fatalf("could not get trial: %v", err)
+	}
+	past1.SetRosterPolicy(ctx, storage.RosterAttendance)
+	if got, want := NewRosterRules(ctx, nil, 1, att, past1, now).Attendance, map[string]int{"2": 0, "3": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Attendance of past1 = %v, want %v", got, want)
+	}
+
+	// the events are counted once per transaction, so a later change is not seen
+	addTestTrial(ctx, t, tx, "past3", storage.TrialStateClosed, now.Add(-time.Hour), "<@1>", "<@4>")
+	if got, want := NewRosterRules(ctx, nil, 1, att, trial, now).Attendance, map[string]int{"2": 1, "3": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Attendance after a change = %v, want %v", got, want)
+	}
+
+	if got, want := NewRosterRules(ctx, nil, 1, NewPastAttendance(tx), trial, now).Attendance, map[string]int{"1": 1, "2": 1, "3": 2, "4": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Attendance counted again = %v, want %v", got, want)
+	}
+
+	// rosters with another policy or a stored order do not count at all
+	trial.SetRosterPolicy(ctx, storage.RosterFirstCome)
+	if got := NewRosterRules(ctx, nil, 1, nil, trial, now).Attendance; got != nil {
+		t.Errorf("Attendance of a first come roster = %v, want nil", got)
+	}
+
+	trial.SetRosterPolicy(ctx, storage.RosterAttendance)
+	trial.SetRosterOrder(ctx, []string{"<@1>", "<@2>", "<@3>"})
+	rules = NewRosterRules(ctx, nil, 1, nil, trial, now)
+	if rules.Attendance != nil {
+		t.Errorf("Attendance of a stored order = %v, want nil", rules.Attendance)
+	}
+	if got, want := signupNames(ctx, rules.order(ctx, trial.GetSignups(ctx))), []string{"<@1>", "<@2>", "<@3>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order() of a stored order = %v, want %v", got, want)
+	}
+}
+
+func TestSplitLockedSignups(t *testing.T) {
+	ctx := context.Background()
+
+	lock := storage.LockedRoster{
+		LockedAt: time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC),
+		Deadline: time.Date(2026, 5, 2, 20, 0, 0, 0, time.UTC),
+		Signups: []storage.LockedSignup{
+			{Name: "<@1>", Role: "dps"},
+			{Name: "<@2>", Role: "dps"},
+			{Name: "<@3>", Role: "dps", Overflow: true},
+			{Name: "<@4>", Role: "dps", Overflow: true},
+			{Name: "<@9>", Role: "tank"},
+		},
+	}
+	rc := testRoleCount{role: "DPS", count: 2}
+
+	tests := []struct {
+		name          string
+		signups       []storage.TrialSignup
+		confirmed     []string
+		confirming    bool
+		wantMain      []string
+		wantOverflow  []string
+		wantConfirmed []string
+		wantPromoted  []string
+	}{
+		{
+			name:          "the locked split holds until the deadline, with later signups at the end",
+			signups:       signups("dps", "<@5>", "<@1>", "<@2>", "<@3>", "<@4>"),
+			confirmed:     []string{"<@2>"},
+			confirming:    true,
+			wantMain:      []string{"<@1>", "<@2>"},
+			wantOverflow:  []string{"<@3>", "<@4>", "<@5>"},
+			wantConfirmed: []string{"<@2>"},
+		},
+		{
+			name:          "after the deadline the overflow moves up in place of those who did not confirm",
+			signups:       signups("dps", "<@1>", "<@2>", "<@3>", "<@4>", "<@5>"),
+			confirmed:     []string{"<@1>"},
+			wantMain:      []string{"<@1>", "<@3>"},
+			wantOverflow:  []string{"<@4>", "<@5>", "<@2>"},
+			wantConfirmed: []string{"<@1>"},
+			wantPromoted:  []string{"<@3>"},
+		},
+		{
+			name:          "nobody moves up when the main group confirmed",
+			signups:       signups("dps", "<@1>", "<@2>", "<@3>"),
+			confirmed:     []string{"<@1>", "<@2>"},
+			wantMain:      []string{"<@1>", "<@2>"},
+			wantOverflow:  []string{"<@3>"},
+			wantConfirmed: []string{"<@1>", "<@2>"},
+		},
+		{
+			name:          "withdrawals leave the roster and their slots are filled",
+			signups:       signups("dps", "<@1>", "<@3>", "<@5>"),
+			confirmed:     []string{"<@1>", "<@2>"},
+			wantMain:      []string{"<@1>", "<@3>"},
+			wantOverflow:  []string{"<@5>"},
+			wantConfirmed: []string{"<@1>"},
+			wantPromoted:  []string{"<@3>"},
+		},
+		{
+			name:         "later signups move up when the overflow runs out",
+			signups:      signups("dps", "<@1>", "<@2>", "<@5>"),
+			wantMain:     []string{"<@5>"},
+			wantOverflow: []string{"<@1>", "<@2>"},
+			wantPromoted: []string{"<@5>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := lock
+			lr.Confirmed = tt.confirmed
+
+			got := splitRoleSignups(ctx, tt.signups, rc, RosterRules{Lock: lr, Confirming: tt.confirming})
+
+			if !got.locked {
+				t.Errorf("locked = false, want true")
+			}
+			if !reflect.DeepEqual(got.main, tt.wantMain) {
+				t.Errorf("main = %v, want %v", got.main, tt.wantMain)
+			}
+			if !reflect.DeepEqual(got.overflow, tt.wantOverflow) {
+				t.Errorf("overflow = %v, want %v", got.overflow, tt.wantOverflow)
+			}
+			if want := nameSet(tt.wantConfirmed); !reflect.DeepEqual(got.confirmed, want) {
+				t.Errorf("confirmed = %v, want %v", got.confirmed, want)
+			}
+			if want := nameSet(tt.wantPromoted); !reflect.DeepEqual(got.promoted, want) {
+				t.Errorf("promoted = %v, want %v", got.promoted, want)
+			}
+		})
+	}
+}
+
+func nameSet(names []string) map[string]bool {
+	set := map[string]bool{}
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+func TestLockedRosterDeadline(t *testing.T) {
+	ctx := context.Background()
+	deadline := time.Date(2026, 5, 2, 20, 0, 0, 0, time.UTC)
+
+	tx, done := testTrials(t)
+	defer done()
+
+	trial := addTestTrial(ctx, t, tx, "vss", storage.TrialStateClosed, time.Time{}, "<@1>", "<@2>")
+	trial.SetLockedRoster(ctx, storage.LockedRoster{
+		LockedAt: deadline.Add(-24 * time.Hour),
+		Deadline: deadline,
+		Signups: []storage.LockedSignup{
+			{Name: "<@1>", Role: "dps"},
+			{Name: "<@2>", Role: "dps", Overflow: true},
+		},
+	})
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		wantMain     []string
+		wantPromoted []string
+	}{
+		{
+			name:     "just before the deadline",
+			now:      deadline.Add(-time.Nanosecond),
+			wantMain: []string{"<@1>"},
+		},
+		{
+			name:         "exactly at the deadline",
+			now:          deadline,
+			wantMain:     []string{"<@2>"},
+			wantPromoted: []string{"<@2>"},
+		},
+		{
+			name:         "after the deadline",
+			now:          deadline.Add(time.Hour),
+			wantMain:     []string{"<@2>"},
+			wantPromoted: []string{"<@2>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := NewRosterRules(ctx, nil, 1, nil, trial, tt.now)
+			got := splitRoleSignups(ctx, trial.GetSignups(ctx), trial.GetRoleCounts(ctx)[0], rules)
+
+			if !reflect.DeepEqual(got.main, tt.wantMain) {
+				t.Errorf("main = %v, want %v", got.main, tt.wantMain)
+			}
+			if want := nameSet(tt.wantPromoted); !reflect.DeepEqual(got.promoted, want) {
+				t.Errorf("promoted = %v, want %v", got.promoted, want)
+			}
+		})
+	}
+}
+
+func TestLockDeadline(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		val     string
+		want    time.Time
+		wantErr string
+	}{
+		{name: "a day by default", want: now.Add(24 * time.Hour)},
+		{name: "the start when it is sooner", start: now.Add(2 * time.Hour), want: now.Add(2 * time.Hour)},
+		{name: "a day when the start is later", start: now.Add(48 * time.Hour), want: now.Add(24 * time.Hour)},
+		{name: "a day when the start has passed", start: now.Add(-time.Hour), want: now.Add(24 * time.Hour)},
+		{name: "a duration", val: "90m", start: now.Add(time.Hour), want: now.Add(90 * time.Minute)},
+		{name: "a time", val: "2026-05-03T18:00", want: time.Date(2026, 5, 3, 18, 0, 0, 0, time.UTC)},
+		{name: "a time with an offset", val: "2026-05-03 18:00 +02:00", want: time.Date(2026, 5, 3, 16, 0, 0, 0, time.UTC)},
+		{name: "a zero duration", val: "0s", wantErr: "err.bad_deadline"},
+		{name: "a negative duration", val: "-1h", wantErr: "err.bad_deadline"},
+		{name: "a time that has passed", val: "2026-05-01T19:00", wantErr: "err.bad_deadline"},
+		{name: "the time now", val: "2026-05-01T20:00", wantErr: "err.bad_deadline"},
+		{name: "not a time", val: "tomorrow", wantErr: "err.bad_deadline"},
+	}
+
+	tx, done := testTrials(t)
+	defer done()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trial := addTestTrial(ctx, t, tx, tt.name, storage.TrialStateOpen, tt.start)
+
+			got, err := lockDeadline(ctx, trial, tt.val, now)
+			if key := errorKey(err); key != tt.wantErr {
+				t.Fatalf("lockDeadline(%q) error = %q, want %q", tt.val, key, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("lockDeadline(%q) = %v, want %v", tt.val, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmLockedTrial(t *testing.T) {
+	ctx := context.Background()
+	deadline := time.Date(2026, 5, 2, 20, 0, 0, 0, time.UTC)
+	before := deadline.Add(-time.Hour)
+
+	lock := storage.LockedRoster{
+		LockedAt: deadline.Add(-24 * time.Hour),
+		Deadline: deadline,
+		Signups: []storage.LockedSignup{
+			{Name: "<@!1>", Role: "dps"},
+			{Name: "<@!2>", Role: "dps"},
+			{Name: "<@!3>", Role: "dps", Overflow: true},
+		},
+		Confirmed: []string{"<@!2>"},
+	}
+
+	tests := []struct {
+		name          string
+		state         storage.TrialState
+		unlocked      bool
+		user          string
+		now           time.Time
+		wantErr       string
+		wantConfirmed []string
+	}{
+		{name: "a main group member", user: "<@!1>", now: before, wantConfirmed: []string{"<@!2>", "<@!1>"}},
+		{name: "confirming again", user: "<@!2>", now: before, wantConfirmed: []string{"<@!2>"}},
+		{name: "another form of the mention", user: "<@1>", now: before, wantErr: "err.not_in_main_group"},
+		{name: "an overflow member", user: "<@!3>", now: before, wantErr: "err.not_in_main_group"},
+		{name: "a member who withdrew", user: "<@!4>", now: before, wantErr: "err.not_in_main_group"},
+		{name: "exactly at the deadline", user: "<@!1>", now: deadline, wantErr: "err.confirm_closed"},
+		{name: "after the deadline", user: "<@!1>", now: deadline.Add(time.Minute), wantErr: "err.confirm_closed"},
+		{name: "an unlocked event", unlocked: true, user: "<@!1>", now: before, wantErr: "err.not_locked"},
+		{name: "a canceled event", state: storage.TrialStateCanceled, user: "<@!1>", now: before, wantErr: "err.canceled"},
+	}
+
+	tx, done := testTrials(t)
+	defer done()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			if state == "" {
+				state = storage.TrialStateClosed
+			}
+
+			trial := addTestTrial(ctx, t, tx, tt.name, state, time.Time{}, "<@!1>", "<@!2>", "<@!3>")
+			if !tt.unlocked {
+				trial.SetLockedRoster(ctx, lock)
+			}
+
+			err := ConfirmLockedTrial(ctx, trial, tt.user, tt.now)
+			if key := errorKey(err); key != tt.wantErr {
+				t.Fatalf("ConfirmLockedTrial(%q) error = %q, want %q", tt.user, key, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+
+			if got := trial.GetLockedRoster(ctx).Confirmed; !reflect.DeepEqual(got, tt.wantConfirmed) {
+				t.Errorf("Confirmed = %v, want %v", got, tt.wantConfirmed)
+			}
+		})
+	}
+}
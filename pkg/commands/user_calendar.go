@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/calendar"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func (c *userCommands) calendar(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.calendar", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "calendar", "args", msg.Contents())
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	var mine, file bool
+	for _, arg := range msg.Contents() {
+		switch strings.ToLower(arg) {
+		case "me":
+			mine = true
+		case "file":
+			file = true
+		default:
+			return r, i18n.NewError("err.calendar_argument", arg)
+		}
+	}
+
+	// without a public url for the feeds, a file is the only thing we can offer
+	if file || c.calendarURL == "" {
+		return c.calendarFile(msg, p, mine)
+	}
+
+	key, err := ensureCalendarKey(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	if mine {
+		r.Description = p.Sprintf("calendar.user_feed", calendar.UserFeedURL(c.calendarURL, msg.GuildID(), msg.UserID(), key))
+	} else {
+		r.Description = p.Sprintf("calendar.guild_feed", calendar.GuildFeedURL(c.calendarURL, msg.GuildID(), key))
+	}
+
+	return r, nil
+}
+
+func (c *userCommands) calendarFile(msg cmdhandler.Message, p i18n.Printer, mine bool) (cmdhandler.Response, error) {
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	calName := p.Sprintf("calendar.guild_name")
+	fileName := fmt.Sprintf("events-%s.ics", msg.GuildID().ToString())
+
+	trials := make([]storage.Trial, 0)
+	for _, trial := range t.GetTrials(msg.Context()) {
+		if !calendar.Scheduled(msg.Context(), trial) {
+			continue
+		}
+		if mine && !calendar.SignedUp(msg.Context(), trial, msg.UserID()) {
+			continue
+		}
+		trials = append(trials, trial)
+	}
+
+	if mine {
+		calName = p.Sprintf("calendar.user_name")
+		fileName = fmt.Sprintf("my-events-%s.ics", msg.GuildID().ToString())
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Render(msg.Context(), &buf, msg.GuildID().ToString(), calName, trials, time.Now()); err != nil {
+		return r, errors.Wrap(err, "could not render calendar")
+	}
+
+	fr := &msghandler.FileResponse{
+		SimpleEmbedResponse: *r,
+		FileName:            fileName,
+		FileData:            buf.Bytes(),
+	}
+	fr.Description = p.Sprintf("calendar.file", len(trials))
+
+	return fr, nil
+}
+
+// ensureCalendarKey returns the guild's calendar key, generating one the first time it is needed
+func ensureCalendarKey(ctx context.Context, gapi storage.GuildAPI, gid snowflake.Snowflake) (string, error) {
+	t, err := gapi.NewTransaction(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	bGuild, err := t.AddGuild(ctx, gid.ToString())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to find or add guild")
+	}
+
+	if key := bGuild.GetCalendarKey(ctx); key != "" {
+		return key, nil
+	}
+
+	key, err := calendar.NewKey()
+	if err != nil {
+		return "", err
+	}
+	bGuild.SetCalendarKey(ctx, key)
+
+	if err = t.SaveGuild(ctx, bGuild); err != nil {
+		return "", errors.Wrap(err, "could not save calendar key")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "could not save calendar key")
+	}
+
+	return key, nil
+}
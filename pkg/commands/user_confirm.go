@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+)
+
+func (c *userCommands) confirm(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.confirm", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "confirm", "trial_name", msg.Contents())
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError("err.need_event_name")
+	}
+
+	trialName := strings.TrimSpace(msg.Contents()[0])
+
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	p := i18n.For(gsettings.Locale)
+
+	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
+	if err != nil {
+		return r, err
+	}
+	trialName = trial.GetName(msg.Context())
+
+	if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
+		return r, msghandler.ErrNoResponse
+	}
+
+	if err = ConfirmLockedTrial(msg.Context(), trial, cmdhandler.UserMentionString(msg.UserID()), time.Now()); err != nil {
+		return r, err
+	}
+
+	if err = t.SaveTrial(msg.Context(), trial); err != nil {
+		return r, errors.Wrap(err, "could not save confirmation")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not save confirmation")
+	}
+
+	level.Info(logger).Message("confirmed", "trial_name", trialName)
+
+	descStr := RosterConfirmation(p, trialName)
+
+	if gsettings.ShowAfterSignup == "true" {
+		level.Debug(logger).Message("auto-show after confirm", "trial_name", trialName)
+
+		r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+		r2.To = cmdhandler.UserMentionString(msg.UserID())
+		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
+		return withComponents(msg.Context(), p, r2, trial, false), nil
+	}
+
+	r.Description = descStr
+
+	return r, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
@@ -30,6 +31,8 @@ func (c *userCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error)
 		return r, msg.ContentErr()
 	}
 
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
 	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
 	if err != nil {
 		return r, err
@@ -44,8 +47,8 @@ func (c *userCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error)
 	trials := t.GetTrials(msg.Context())
 	tNames := make([]string, 0, len(trials))
 	for _, trial := range trials {
-		if trial.GetState(msg.Context()) != storage.TrialStateClosed {
-			if tscID, ok := g.ChannelWithName(trial.GetSignupChannel(msg.Context())); ok {
+		if trial.GetState(msg.Context()) == storage.TrialStateOpen {
+			if tscID, ok := msghandler.ChannelID(&g, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context())); ok {
 				tNames = append(tNames, fmt.Sprintf("%s (%s)", trial.GetName(msg.Context()), cmdhandler.ChannelMentionString(tscID)))
 			} else {
 				tNames = append(tNames, trial.GetName(msg.Context()))
@@ -58,12 +61,12 @@ func (c *userCommands) list(msg cmdhandler.Message) (cmdhandler.Response, error)
 	if len(tNames) > 0 {
 		listContent = strings.Join(tNames, "\n")
 	} else {
-		listContent = "(none yet)"
+		listContent = p.Sprintf("list.none")
 	}
 
 	r.Fields = []cmdhandler.EmbedField{
 		{
-			Name: "*Available Trials*",
+			Name: p.Sprintf("list.open"),
 			Val:  listContent,
 		},
 	}
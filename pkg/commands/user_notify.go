@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// notifyChoices are the preferences !notify accepts, with the message explaining each
+var notifyChoices = map[storage.NotifyPreference]string{
+	storage.NotifyOn:         "notify.on",
+	storage.NotifyOff:        "notify.off",
+	storage.NotifyPromotions: "notify.promotions_only",
+}
+
+func formatNotifyPreference(p i18n.Printer, pref storage.NotifyPreference) string {
+	return p.Sprintf("notify.current", pref) + "\n" + p.Sprintf(notifyChoices[pref])
+}
+
+func (c *userCommands) notify(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.notify", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "notify", "args", msg.Contents())
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) > 1 {
+		return r, i18n.NewError("err.too_many_arguments")
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	t, err := c.deps.ProfileAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), len(msg.Contents()) > 0)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	uid := msg.UserID().ToString()
+
+	prof, err := t.GetProfile(msg.Context(), uid)
+	if err != nil {
+		return r, err
+	}
+
+	if len(msg.Contents()) == 0 {
+		r.Description = formatNotifyPreference(p, notifyPreference(prof))
+		return r, nil
+	}
+
+	pref := storage.NotifyPreference(strings.ToLower(msg.Contents()[0]))
+	if _, ok := notifyChoices[pref]; !ok {
+		return r, i18n.NewError("err.notify_choice", msg.Contents()[0])
+	}
+
+	// off is the default, so it is not stored
+	prof.Notify = pref
+	if pref == storage.NotifyOff {
+		prof.Notify = ""
+	}
+
+	if prof.IsZero() {
+		err = t.DeleteProfile(msg.Context(), uid)
+	} else {
+		err = t.SaveProfile(msg.Context(), uid, prof)
+	}
+	if err != nil {
+		return r, errors.Wrap(err, "could not save notify preference")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not save notify preference")
+	}
+
+	level.Info(logger).Message("notify preference saved", "notify", pref)
+	r.Description = formatNotifyPreference(p, pref)
+
+	return r, nil
+}
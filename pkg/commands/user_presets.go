@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// role layouts of the built-in presets, with the emoji shown before each signup
+const (
+	trialRoles      = "tank:2:🛡️,healer:2:💚,dps:8:⚔️"
+	brpRoles        = "tank:1:🛡️,healer:1:💚,dps:2:⚔️"
+	dragonstarRoles = "tank:1:🛡️,dps:1:⚔️"
+)
+
+// presetFamily is a trial or arena of the built-in catalog, with a preset for normal and
+// veteran runs of it, and for veteran hardmode if it has one
+type presetFamily struct {
+	code     string
+	name     string
+	roles    string
+	hardmode bool
+}
+
+var presetFamilies = []presetFamily{
+	{"aa", "Aetherian Archive", trialRoles, true},
+	{"hrc", "Hel Ra Citadel", trialRoles, true},
+	{"so", "Sanctum Ophidia", trialRoles, true},
+	{"mol", "Maw of Lorkhaj", trialRoles, true},
+	{"hof", "Halls of Fabrication", trialRoles, true},
+	{"as", "Asylum Sanctorium", trialRoles, true},
+	{"cr", "Cloudrest", trialRoles, true},
+	{"ss", "Sunspire", trialRoles, true},
+	{"ka", "Kyne's Aegis", trialRoles, true},
+	{"rg", "Rockgrove", trialRoles, true},
+	{"dsr", "Dreadsail Reef", trialRoles, true},
+	{"se", "Sanity's Edge", trialRoles, true},
+	{"lc", "Lucent Citadel", trialRoles, true},
+	{"oc", "Ossein Cage", trialRoles, true},
+	{"brp", "Blackrose Prison", brpRoles, false},
+	{"dsa", "Dragonstar Arena", dragonstarRoles, false},
+}
+
+func (f presetFamily) presets() []storage.Preset {
+	presets := []storage.Preset{
+		{Name: "n" + f.code, Description: "Normal " + f.name, Roles: f.roles},
+		{Name: "v" + f.code, Description: "Veteran " + f.name, Roles: f.roles},
+	}
+
+	if f.hardmode {
+		presets = append(presets, storage.Preset{Name: "v" + f.code + "-hm", Description: "Veteran " + f.name + " Hardmode", Roles: f.roles})
+	}
+
+	return presets
+}
+
+func builtinPresets() []storage.Preset {
+	var presets []storage.Preset
+	for _, f := range presetFamilies {
+		presets = append(presets, f.presets()...)
+	}
+	return presets
+}
+
+// findPreset looks up a preset by name, ignoring case; a guild's own preset takes the place
+// of a built-in one with the same name
+func findPreset(name string, guildPresets []storage.Preset) (storage.Preset, bool) {
+	for _, presets := range [][]storage.Preset{guildPresets, builtinPresets()} {
+		for _, p := range presets {
+			if strings.EqualFold(p.Name, name) {
+				return p, true
+			}
+		}
+	}
+
+	return storage.Preset{}, false
+}
+
+// presetNotFound is the error for a preset name that matches nothing, suggesting similar names
+func presetNotFound(name string, guildPresets []storage.Preset) error {
+	var names []string
+	for _, presets := range [][]storage.Preset{guildPresets, builtinPresets()} {
+		for _, p := range presets {
+			names = append(names, p.Name)
+		}
+	}
+
+	s := suggest(name, names)
+	if len(s) == 0 {
+		return i18n.NewError("err.unknown_preset", name)
+	}
+
+	return i18n.NewError("err.unknown_preset_suggest", name, formatNames(s))
+}
+
+// applyPreset fills in the roles and description of a new event from its preset setting;
+// roles given along with the preset change its counts, keeping the preset's emoji
+//
+// NOTE: this cannot be called after another transaction has been started
+func applyPreset(ctx context.Context, gapi storage.GuildAPI, gid string, settingMap map[string]string) error {
+	name, ok := settingMap["preset"]
+	if !ok {
+		return nil
+	}
+
+	guildPresets, err := storage.GetPresets(ctx, gapi, gid)
+	if err != nil {
+		return err
+	}
+
+	preset, ok := findPreset(name, guildPresets)
+	if !ok {
+		return presetNotFound(name, guildPresets)
+	}
+
+	roles, err := mergeRoles(preset.Roles, settingMap["roles"])
+	if err != nil {
+		return err
+	}
+	settingMap["roles"] = roles
+
+	if _, ok := settingMap["description"]; !ok {
+		settingMap["description"] = preset.Description
+	}
+
+	return nil
+}
+
+// mergeRoles appends the overrides to the base roles setting, giving overridden roles the
+// base emoji when they have none of their own
+func mergeRoles(base, overrides string) (string, error) {
+	baseRoles, err := parseRolesString(base)
+	if err != nil {
+		return "", err
+	}
+
+	overRoles, err := parseRolesString(overrides)
+	if err != nil {
+		return "", err
+	}
+
+	emoji := map[string]string{}
+	for _, rce := range baseRoles {
+		emoji[strings.ToLower(rce.role)] = rce.emo
+	}
+
+	parts := make([]string, 0, len(baseRoles)+len(overRoles))
+	for _, rce := range append(baseRoles, overRoles...) {
+		if rce.emo == "" {
+			rce.emo = emoji[strings.ToLower(rce.role)]
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d:%s", rce.role, rce.ct, rce.emo))
+	}
+
+	return strings.Join(parts, ","), nil
+}
+
+// formatPresetRoles renders the roles setting of a preset like "🛡️tank 2, 💚healer 2"
+func formatPresetRoles(roles string) string {
+	rces, err := parseRolesString(roles)
+	if err != nil {
+		return roles
+	}
+
+	parts := make([]string, 0, len(rces))
+	for _, rce := range rces {
+		parts = append(parts, fmt.Sprintf("%s%s %d", rce.emo, rce.role, rce.ct))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatPreset(p storage.Preset) string {
+	if p.Description == "" {
+		return fmt.Sprintf("`%s`: %s", p.Name, formatPresetRoles(p.Roles))
+	}
+
+	return fmt.Sprintf("`%s` %s: %s", p.Name, p.Description, formatPresetRoles(p.Roles))
+}
+
+// formatPresets lists the guild's presets, then the built-in catalog with one line per trial
+// or arena so that it fits in a single message
+func formatPresets(p i18n.Printer, guildPresets []storage.Preset) string {
+	var b strings.Builder
+
+	if len(guildPresets) > 0 {
+		b.WriteString(p.Sprintf("presets.guild"))
+		for _, gp := range guildPresets {
+			b.WriteString("\n" + formatPreset(gp))
+		}
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(p.Sprintf("presets.builtin"))
+	for _, f := range presetFamilies {
+		var names []string
+		for _, fp := range f.presets() {
+			names = append(names, fp.Name)
+		}
+
+		b.WriteString(fmt.Sprintf("\n**%s**: %s (%s)", f.name, formatNames(names), formatPresetRoles(f.roles)))
+	}
+
+	return b.String()
+}
+
+func (c *userCommands) presets(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.presets", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "presets", "args", msg.Contents())
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) > 1 {
+		return r, i18n.NewError("err.too_many_arguments")
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	guildPresets, err := storage.GetPresets(msg.Context(), c.deps.GuildAPI(), msg.GuildID().ToString())
+	if err != nil {
+		return r, err
+	}
+
+	if len(msg.Contents()) == 0 {
+		r.Description = formatPresets(p, guildPresets)
+		return r, nil
+	}
+
+	preset, ok := findPreset(msg.Contents()[0], guildPresets)
+	if !ok {
+		return r, presetNotFound(msg.Contents()[0], guildPresets)
+	}
+
+	r.Description = formatPreset(preset)
+	return r, nil
+}
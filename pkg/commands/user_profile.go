@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+const maxProfileRoleLength = 32
+
+// parseProfileArgs applies `role=... character=... class=...` to a profile; char is short
+// for character, and an empty value clears a field
+func parseProfileArgs(args []string, prof storage.Profile) (storage.Profile, error) {
+	pairs, err := parseArgPairs(args)
+	if err != nil {
+		return prof, err
+	}
+
+	if len(pairs) == 0 {
+		return prof, i18n.NewError("err.no_settings")
+	}
+
+	for _, ap := range pairs {
+		key := strings.ToLower(ap.key)
+		if key == "char" {
+			key = "character"
+		}
+
+		limit := signupDetailLimits[key]
+		if key == "role" {
+			limit = maxProfileRoleLength
+		}
+		if len([]rune(ap.val)) > limit {
+			return prof, i18n.NewError("err.signup_detail_too_long", key, limit)
+		}
+
+		switch key {
+		case "role":
+			prof.Role = ap.val
+		case "character":
+			prof.Character = ap.val
+		case "class":
+			prof.Class = ap.val
+		default:
+			return prof, i18n.NewError("err.unknown_profile_field", ap.key)
+		}
+	}
+
+	return prof, nil
+}
+
+func formatProfile(p i18n.Printer, prof storage.Profile) string {
+	if prof.IsZero() {
+		return p.Sprintf("profile.none")
+	}
+
+	return p.Sprintf("profile.show", prof.Role, prof.Character, prof.Class, notifyPreference(prof))
+}
+
+// notifyPreference is the notify preference of a profile, off when none was chosen
+func notifyPreference(prof storage.Profile) storage.NotifyPreference {
+	if prof.Notify == "" {
+		return storage.NotifyOff
+	}
+	return prof.Notify
+}
+
+// profileRole is the role of a trial to sign up for when the member gave none
+func profileRole(ctx context.Context, trial storage.Trial, prof storage.Profile) (string, error) {
+	if prof.Role == "" {
+		return "", i18n.NewError("err.missing_role")
+	}
+
+	rc, ok := roleCountByName(ctx, prof.Role, trial.GetRoleCounts(ctx))
+	if !ok {
+		return "", i18n.NewError("err.profile_role_not_in_trial", prof.Role, trial.GetName(ctx))
+	}
+
+	return rc.GetRole(ctx), nil
+}
+
+// profileDetails are the details a profile prefills on a signup
+func profileDetails(prof storage.Profile) storage.SignupDetails {
+	return storage.SignupDetails{
+		Character: prof.Character,
+		Class:     prof.Class,
+	}
+}
+
+func (c *userCommands) profile(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.profile", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To: cmdhandler.UserMentionString(msg.UserID()),
+	}
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "profile", "args", len(msg.Contents()))
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	action, args := "show", []string(nil)
+	if len(msg.Contents()) > 0 {
+		action, args = strings.ToLower(msg.Contents()[0]), msg.Contents()[1:]
+	}
+
+	p := storage.GetPrinter(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+
+	t, err := c.deps.ProfileAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), action != "show")
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
+
+	uid := msg.UserID().ToString()
+
+	prof, err := t.GetProfile(msg.Context(), uid)
+	if err != nil {
+		return r, err
+	}
+
+	switch action {
+	case "show":
+		if len(args) > 0 {
+			return r, i18n.NewError("err.too_many_arguments")
+		}
+
+		r.Description = formatProfile(p, prof)
+		return r, nil
+
+	case "set":
+		prof, err = parseProfileArgs(args, prof)
+		if err != nil {
+			return r, err
+		}
+
+		if prof.IsZero() {
+			err = t.DeleteProfile(msg.Context(), uid)
+		} else {
+			err = t.SaveProfile(msg.Context(), uid, prof)
+		}
+
+	case "clear":
+		if len(args) > 0 {
+			return r, i18n.NewError("err.too_many_arguments")
+		}
+
+		// the notify preference has its own command, so it is kept
+		prof = storage.Profile{Notify: prof.Notify}
+		if prof.IsZero() {
+			err = t.DeleteProfile(msg.Context(), uid)
+		} else {
+			err = t.SaveProfile(msg.Context(), uid, prof)
+		}
+
+	default:
+		return r, i18n.NewError("err.profile_action", action)
+	}
+
+	if err != nil {
+		return r, errors.Wrap(err, "could not save profile")
+	}
+
+	if err = t.Commit(msg.Context()); err != nil {
+		return r, errors.Wrap(err, "could not save profile")
+	}
+
+	level.Info(logger).Message("profile saved", "action", action)
+	r.Description = p.Sprintf("profile.saved") + "\n\n" + formatProfile(p, prof)
+
+	return r, nil
+}
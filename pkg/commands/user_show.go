@@ -2,11 +2,12 @@ package commands
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
-	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -31,7 +32,7 @@ func (c *userCommands) show(msg cmdhandler.Message) (cmdhandler.Response, error)
 	}
 
 	if len(msg.Contents()) != 1 {
-		return r, errors.New("you must supply exactly 1 argument -- trial name; are you missing quotes?")
+		return r, i18n.NewError("err.show_usage")
 	}
 
 	trialName := strings.TrimSpace(msg.Contents()[0])
@@ -41,24 +42,32 @@ func (c *userCommands) show(msg cmdhandler.Message) (cmdhandler.Response, error)
 		return r, err
 	}
 
+	p := i18n.For(gsettings.Locale)
+
 	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), false)
 	if err != nil {
 		return r, err
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
 
-	if !isSignupChannel(logger, msg, trial.GetSignupChannel(msg.Context()), gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+	if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
 		return r, msghandler.ErrNoResponse
 	}
 
-	r2 := formatTrialDisplay(msg.Context(), trial, true)
+	r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 	r2.To = cmdhandler.UserMentionString(msg.UserID())
 
-	return r2, nil
+	// admins looking at a roster get the menu to remove signups as well
+	withRemove := isAdminChannel(logger, msg, gsettings.AdminChannelID, gsettings.AdminChannel, c.deps.BotSession()) &&
+		msghandler.IsAdminAuthorized(logger, msg, gsettings.AdminRole, c.deps.BotSession())
+
+	return withComponents(msg.Context(), p, r2, trial, withRemove), nil
 }
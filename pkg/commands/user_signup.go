@@ -2,13 +2,17 @@ package commands
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -30,15 +34,19 @@ func (c *userCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, erro
 		return r, msg.ContentErr()
 	}
 
-	if len(msg.Contents()) < 2 {
-		return r, errors.New("missing role")
+	signups, err := parseSignupArgs(msg.Contents())
+	if err != nil {
+		return r, err
 	}
 
-	if len(msg.Contents()) > 2 && len(msg.Contents())%2 != 0 {
-		return r, errors.New("incorrect number of arguments")
+	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
 	}
 
-	gsettings, err := storage.GetSettings(msg.Context(), c.deps.GuildAPI(), msg.GuildID())
+	p := i18n.For(gsettings.Locale)
+
+	prof, err := storage.GetProfile(msg.Context(), c.deps.ProfileAPI(), msg.GuildID(), msg.UserID())
 	if err != nil {
 		return r, err
 	}
@@ -49,58 +57,77 @@ func (c *userCommands) signup(msg cmdhandler.Message) (cmdhandler.Response, erro
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
+	att := NewPastAttendance(t)
+
 	var descStr string
 	var trial storage.Trial
+	events := make([]webhook.Event, 0, len(signups))
+	var notices []notify.Notice
 
-	for i := 0; i < len(msg.Contents()); i += 2 {
-		trialName, role := msg.Contents()[i], msg.Contents()[i+1]
+	for _, su := range signups {
+		trialName, role := su.trialName, su.role
 
-		trial, err = t.GetTrial(msg.Context(), trialName)
+		trial, err = FindTrial(msg.Context(), t, trialName)
 		if err != nil {
 			return r, err
 		}
+		trialName = trial.GetName(msg.Context())
 
-		if !isSignupChannel(logger, msg, trial.GetSignupChannel(msg.Context()), gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+		if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
 			level.Info(logger).Message("command not in signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
 			return r, msghandler.ErrNoResponse
 		}
 
-		if trial.GetState(msg.Context()) != storage.TrialStateOpen {
-			return r, errors.New("cannot sign up for a closed trial")
+		if role == "" {
+			role, err = profileRole(msg.Context(), trial, prof)
+		} else {
+			role, err = ResolveRole(msg.Context(), trial, role)
+		}
+		if err != nil {
+			return r, err
 		}
 
-		overflow, err := signupUser(msg.Context(), trial, cmdhandler.UserMentionString(msg.UserID()), role)
+		overflow, err := SignupOpenTrial(msg.Context(), trial, cmdhandler.UserMentionString(msg.UserID()), role, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 		if err != nil {
 			return r, err
 		}
+		setSignupDetails(msg.Context(), trial, cmdhandler.UserMentionString(msg.UserID()), su.details, profileDetails(prof))
 
 		if err = t.SaveTrial(msg.Context(), trial); err != nil {
 			return r, errors.Wrap(err, "could not save trial signup")
 		}
 
+		level.Info(logger).Message("signed up", "overflow", overflow, "role", role, "trial_name", trialName)
+		descStr += SignupConfirmation(p, trialName, role, overflow) + "\n"
+
+		ev := trialEvent(msg, webhook.EventSignup, trial.GetName(msg.Context()))
+		ev.Role = role
+		ev.Users = []string{cmdhandler.UserMentionString(msg.UserID())}
 		if overflow {
-			level.Info(logger).Message("signed up", "overflow", true, "role", role, "trial_name", trialName)
-			descStr += fmt.Sprintf("Signed up as OVERFLOW for %s in %s\n", role, trialName)
-		} else {
-			level.Info(logger).Message("signed up", "overflow", false, "role", role, "trial_name", trialName)
-			descStr += fmt.Sprintf("Signed up for %s in %s\n", role, trialName)
+			ev.Overflow = ev.Users
 		}
-
+		events = append(events, ev)
+		notices = append(notices, TrialNotices(msg.Context(), c.deps.BotSession(), msg.GuildID(), trial, notify.KindSignup, SignupConfirmation(p, trialName, role, overflow), ev.Users)...)
 	}
 
 	if err = t.Commit(msg.Context()); err != nil {
 		return r, errors.Wrap(err, "could not save trial signup")
 	}
 
+	for _, ev := range events {
+		c.deps.Webhooks().Notify(msg.Context(), ev)
+	}
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+
 	if gsettings.ShowAfterSignup == "true" {
-		if len(msg.Contents()) > 2 {
-			descStr += "\n(only showing last trial details)"
+		if len(signups) > 1 {
+			descStr += "\n" + p.Sprintf("signup.last_only")
 		}
 
-		r2 := formatTrialDisplay(msg.Context(), trial, true)
+		r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 		r2.To = cmdhandler.UserMentionString(msg.UserID())
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
-		return r2, nil
+		return withComponents(msg.Context(), p, r2, trial, false), nil
 	}
 
 	r.Description = descStr
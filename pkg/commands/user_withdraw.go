@@ -3,13 +3,16 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v5/deferutil"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
@@ -32,7 +35,7 @@ func (c *userCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, er
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("missing event name")
+		return r, i18n.NewError("err.need_event_name")
 	}
 
 	trialName := strings.TrimSpace(msg.Contents()[0])
@@ -42,27 +45,34 @@ func (c *userCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, er
 		return r, err
 	}
 
+	p := i18n.For(gsettings.Locale)
+
 	t, err := c.deps.TrialAPI().NewTransaction(msg.Context(), msg.GuildID().ToString(), true)
 	if err != nil {
 		return r, err
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(msg.Context()) })
 
-	trial, err := t.GetTrial(msg.Context(), trialName)
+	att := NewPastAttendance(t)
+
+	trial, err := FindTrial(msg.Context(), t, trialName)
 	if err != nil {
 		return r, err
 	}
+	trialName = trial.GetName(msg.Context())
 
-	if !isSignupChannel(logger, msg, trial.GetSignupChannel(msg.Context()), gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
+	if !isSignupChannel(logger, msg, trial.GetSignupChannelID(msg.Context()), trial.GetSignupChannel(msg.Context()), gsettings.AdminChannelID, gsettings.AdminChannel, gsettings.AdminRole, c.deps.BotSession()) {
 		level.Info(logger).Message("command not in signup channel", "signup_channel", trial.GetSignupChannel(msg.Context()))
 		return r, msghandler.ErrNoResponse
 	}
 
-	if trial.GetState(msg.Context()) != storage.TrialStateOpen {
-		return r, errors.New("cannot withdraw from a closed trial")
+	overflowBefore := OverflowSignups(msg.Context(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
+
+	if err = WithdrawOpenTrial(msg.Context(), trial, cmdhandler.UserMentionString(msg.UserID())); err != nil {
+		return r, err
 	}
 
-	trial.RemoveSignup(msg.Context(), cmdhandler.UserMentionString(msg.UserID()))
+	notices := PromotionNotices(msg.Context(), c.deps.BotSession(), p, msg.GuildID(), trial, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()), overflowBefore)
 
 	if err = t.SaveTrial(msg.Context(), trial); err != nil {
 		return r, errors.Wrap(err, "could not save trial withdraw")
@@ -73,15 +83,20 @@ func (c *userCommands) withdraw(msg cmdhandler.Message) (cmdhandler.Response, er
 	}
 
 	level.Info(logger).Message("withdrew", "trial_name", trialName)
-	descStr := fmt.Sprintf("Withdrew from %s", trialName)
+
+	ev := trialEvent(msg, webhook.EventWithdraw, trial.GetName(msg.Context()))
+	ev.Users = []string{cmdhandler.UserMentionString(msg.UserID())}
+	c.deps.Webhooks().Notify(msg.Context(), ev)
+	c.deps.Notifier().Notify(msg.Context(), notices...)
+	descStr := WithdrawConfirmation(p, trialName)
 
 	if gsettings.ShowAfterWithdraw == "true" {
 		level.Debug(logger).Message("auto-show after withdraw", "trial_name", trialName)
 
-		r2 := formatTrialDisplay(msg.Context(), trial, true)
+		r2 := formatTrialDisplay(msg.Context(), p, trial, true, NewRosterRules(msg.Context(), c.deps.BotSession(), msg.GuildID(), att, trial, time.Now()))
 		r2.To = cmdhandler.UserMentionString(msg.UserID())
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
-		return r2, nil
+		return withComponents(msg.Context(), p, r2, trial, false), nil
 	}
 
 	r.Description = descStr
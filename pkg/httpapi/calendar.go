@@ -0,0 +1,160 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/calendar"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// CalendarServer is the http handler for the iCalendar feeds
+//
+// It should be mounted at /calendar/ and serves:
+//
+// - GET /calendar/{id}/{token}.ics with every scheduled open event in the guild
+// - GET /calendar/{id}/{user}/{token}.ics with only the events the user signed up for
+//
+// Calendar apps cannot send headers, so the feeds are authorized by the token in the
+// url (see the calendar package) instead of the api token
+type CalendarServer interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type calendarServer struct {
+	deps dependencies
+}
+
+// NewCalendarServer creates a new CalendarServer object
+func NewCalendarServer(deps dependencies) CalendarServer {
+	return &calendarServer{
+		deps: deps,
+	}
+}
+
+type calendarRoute struct {
+	gid   snowflake.Snowflake
+	uid   snowflake.Snowflake
+	token string
+}
+
+func parseCalendarRoute(path string) (calendarRoute, bool) {
+	var rt calendarRoute
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "calendar" {
+		return rt, false
+	}
+
+	gid, err := snowflake.FromString(parts[1])
+	if err != nil || gid == 0 {
+		return rt, false
+	}
+	rt.gid = gid
+
+	if len(parts) == 4 {
+		uid, err := snowflake.FromString(parts[2])
+		if err != nil || uid == 0 {
+			return rt, false
+		}
+		rt.uid = uid
+	}
+
+	last := parts[len(parts)-1]
+	if !strings.HasSuffix(last, ".ics") {
+		return rt, false
+	}
+	rt.token = strings.TrimSuffix(last, ".ics")
+
+	return rt, rt.token != ""
+}
+
+func (s *calendarServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.deps.Census().StartSpan(r.Context(), "httpapi.calendar")
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rt, ok := parseCalendarRoute(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	key, err := storage.GetCalendarKey(ctx, s.deps.GuildAPI(), rt.gid.ToString())
+	if err != nil {
+		level.Error(logger).Err("could not get calendar key", err, "guild_id", rt.gid.ToString())
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	expected := calendar.GuildToken(key)
+	if rt.uid != 0 {
+		expected = calendar.UserToken(key, rt.uid)
+	}
+
+	// an unknown guild and a bad token look the same, so feeds cannot be probed
+	if key == "" || !calendar.CheckToken(expected, rt.token) {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := s.render(ctx, rt)
+	if err != nil {
+		level.Error(logger).Err("could not render calendar", err, "guild_id", rt.gid.ToString())
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write(body); err != nil {
+		level.Error(logger).Err("could not write response", err)
+	}
+}
+
+func (s *calendarServer) render(ctx context.Context, rt calendarRoute) ([]byte, error) {
+	t, err := s.deps.TrialAPI().NewTransaction(ctx, rt.gid.ToString(), false)
+	if err != nil {
+		return nil, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	calName := "Discord events"
+	trials := t.GetTrials(ctx)
+	if rt.uid != 0 {
+		calName = "My Discord signups"
+
+		mine := make([]storage.Trial, 0, len(trials))
+		for _, trial := range trials {
+			if calendar.SignedUp(ctx, trial, rt.uid) {
+				mine = append(mine, trial)
+			}
+		}
+		trials = mine
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Render(ctx, &buf, rt.gid.ToString(), calName, trials, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,309 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+	"github.com/mailru/easyjson"
+	"golang.org/x/time/rate"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/bot"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/httpclient"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+)
+
+type dependencies interface {
+	Logger() logging.Logger
+	GuildAPI() storage.GuildAPI
+	TrialAPI() storage.TrialAPI
+	BotSession() *etfapi.Session
+	MessageRateLimiter() *rate.Limiter
+	HTTPClient() httpclient.HTTPClient
+	Webhooks() webhook.Dispatcher
+	Notifier() notify.Notifier
+	Census() *census.Census
+}
+
+// Server is the http handler for the guild events api
+//
+// It should be mounted at /guilds/ and serves:
+//
+// - GET /guilds/{id}/trials (optionally ?state=open, ?state=closed, or ?state=canceled)
+// - GET /guilds/{id}/trials/{name}
+// - POST /guilds/{id}/trials/{name} with a SignupRequest body signs a user up
+// - DELETE /guilds/{id}/trials/{name}?user_id={user} withdraws a user
+//
+// Every request needs the guild's api token as "Authorization: Bearer <token>"
+//
+// Signups and withdrawals are confirmed in the trial's signup channel, which needs
+// the server to be connected to the bot with ConnectToBot. Signups also need it to
+// check that the user is a member of the guild.
+type Server interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	ConnectToBot(bot.DiscordBot)
+}
+
+type server struct {
+	bot          bot.DiscordBot
+	deps         dependencies
+	successColor int
+}
+
+// Options provides a way to pass configuration to NewServer
+type Options struct {
+	SuccessColor int
+}
+
+// NewServer creates a new Server object
+func NewServer(deps dependencies, opts Options) Server {
+	return &server{
+		deps:         deps,
+		successColor: opts.SuccessColor,
+	}
+}
+
+func (s *server) ConnectToBot(b bot.DiscordBot) {
+	s.bot = b
+}
+
+type route struct {
+	gid       snowflake.Snowflake
+	trialName string
+}
+
+func parseRoute(path string) (route, bool) {
+	var rt route
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "guilds" || parts[2] != "trials" {
+		return rt, false
+	}
+
+	gid, err := snowflake.FromString(parts[1])
+	if err != nil || gid == 0 {
+		return rt, false
+	}
+	rt.gid = gid
+
+	if len(parts) == 4 {
+		if parts[3] == "" {
+			return rt, false
+		}
+		rt.trialName = parts[3]
+	}
+
+	return rt, true
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(h[7:])
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.deps.Census().StartSpan(r.Context(), "httpapi.ServeHTTP")
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	rt, ok := parseRoute(r.URL.Path)
+	if !ok {
+		s.writeError(ctx, w, http.StatusNotFound, "not found")
+		return
+	}
+
+	authorized, err := storage.CheckAPIToken(ctx, s.deps.GuildAPI(), rt.gid.ToString(), bearerToken(r))
+	if err != nil {
+		level.Error(logger).Err("could not check api token", err, "guild_id", rt.gid.ToString())
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	if !authorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		s.writeError(ctx, w, http.StatusUnauthorized, "invalid or missing api token")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && rt.trialName == "":
+		s.listTrials(ctx, w, r, rt)
+	case r.Method == http.MethodGet:
+		s.showTrial(ctx, w, rt)
+	case r.Method == http.MethodPost && rt.trialName != "":
+		s.signup(ctx, w, r, rt)
+	case r.Method == http.MethodDelete && rt.trialName != "":
+		s.withdraw(ctx, w, r, rt)
+	default:
+		s.writeError(ctx, w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *server) listTrials(ctx context.Context, w http.ResponseWriter, r *http.Request, rt route) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "httpapi.listTrials", "guild_id", rt.gid.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	state := storage.TrialState(strings.ToLower(r.URL.Query().Get("state")))
+
+	t, err := s.deps.TrialAPI().NewTransaction(ctx, rt.gid.ToString(), false)
+	if err != nil {
+		level.Error(logger).Err("could not start trials transaction", err)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	resp := TrialsResponse{
+		GuildID: rt.gid.ToString(),
+		Trials:  []TrialResponse{},
+	}
+
+	for _, trial := range t.GetTrials(ctx) {
+		if state != "" && trial.GetState(ctx) != state {
+			continue
+		}
+
+		resp.Trials = append(resp.Trials, trialResponse(ctx, trial, s.rosterRules(ctx, rt.gid, att, trial)))
+	}
+
+	s.writeJSON(ctx, w, http.StatusOK, resp)
+}
+
+func (s *server) showTrial(ctx context.Context, w http.ResponseWriter, rt route) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "httpapi.showTrial", "guild_id", rt.gid.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	t, err := s.deps.TrialAPI().NewTransaction(ctx, rt.gid.ToString(), false)
+	if err != nil {
+		level.Error(logger).Err("could not start trials transaction", err)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, err := t.GetTrial(ctx, rt.trialName)
+	if err == storage.ErrTrialNotExist {
+		s.writeError(ctx, w, http.StatusNotFound, "event not found")
+		return
+	}
+	if err != nil {
+		level.Error(logger).Err("could not get trial", err, "trial_name", rt.trialName)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	s.writeJSON(ctx, w, http.StatusOK, trialResponse(ctx, trial, s.rosterRules(ctx, rt.gid, att, trial)))
+}
+
+// rosterRules are the rules that split the rosters of a trial as of now
+func (s *server) rosterRules(ctx context.Context, gid snowflake.Snowflake, att *commands.PastAttendance, trial storage.Trial) commands.RosterRules {
+	return commands.NewRosterRules(ctx, s.deps.BotSession(), gid, att, trial, time.Now())
+}
+
+func trialResponse(ctx context.Context, trial storage.Trial, rules commands.RosterRules) TrialResponse {
+	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
+	signups := trial.GetSignups(ctx)
+
+	details := make(map[string]storage.SignupDetails, len(signups))
+	for _, su := range signups {
+		details[su.GetName(ctx)] = su.GetDetails(ctx)
+	}
+
+	resp := TrialResponse{
+		Name:            trial.GetName(ctx),
+		State:           string(trial.GetState(ctx)),
+		Description:     trial.GetDescription(ctx),
+		AnnounceChannel: trial.GetAnnounceChannel(ctx),
+		SignupChannel:   trial.GetSignupChannel(ctx),
+		DurationSeconds: int64(trial.GetDuration(ctx) / time.Second),
+		Roles:           make([]RoleRoster, 0, len(roleCounts)),
+	}
+
+	if start := trial.GetStartTime(ctx); !start.IsZero() {
+		resp.StartTime = start.UTC().Format(time.RFC3339)
+	}
+
+	for _, rc := range roleCounts {
+		mainNames, ofNames := commands.TrialRoleSignups(ctx, signups, rc, rules)
+		resp.Roles = append(resp.Roles, RoleRoster{
+			Name:     rc.GetRole(ctx),
+			Emoji:    rc.GetEmoji(ctx),
+			Count:    rc.GetCount(ctx),
+			Main:     members(mainNames, details),
+			Overflow: members(ofNames, details),
+		})
+	}
+
+	return resp
+}
+
+func members(mentions []string, details map[string]storage.SignupDetails) []Member {
+	m := make([]Member, 0, len(mentions))
+	for _, mention := range mentions {
+		d := details[mention]
+		m = append(m, Member{
+			Mention:   mention,
+			UserID:    mentionUserID(mention),
+			Character: d.Character,
+			Class:     d.Class,
+			Note:      d.Note,
+		})
+	}
+	return m
+}
+
+func mentionUserID(mention string) string {
+	if !strings.HasPrefix(mention, "<@") || !strings.HasSuffix(mention, ">") {
+		return ""
+	}
+
+	id := strings.TrimPrefix(mention[2:len(mention)-1], "!")
+	uid, err := snowflake.FromString(id)
+	if err != nil {
+		return ""
+	}
+
+	return uid.ToString()
+}
+
+func (s *server) writeJSON(ctx context.Context, w http.ResponseWriter, status int, v easyjson.Marshaler) {
+	b, err := easyjson.Marshal(v)
+	if err != nil {
+		level.Error(logging.WithContext(ctx, s.deps.Logger())).Err("could not marshal response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(b); err != nil {
+		level.Error(logging.WithContext(ctx, s.deps.Logger())).Err("could not write response", err)
+	}
+}
+
+func (s *server) writeError(ctx context.Context, w http.ResponseWriter, status int, msg string) {
+	s.writeJSON(ctx, w, status, ErrorResponse{Error: msg})
+}
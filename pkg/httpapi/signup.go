@@ -0,0 +1,338 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	"github.com/mailru/easyjson"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+)
+
+const maxRequestBody = 4096
+
+// ErrNotConnected is the error logged when a confirmation cannot be posted because
+// the server was never connected to the bot
+var ErrNotConnected = errors.New("not connected to a bot")
+
+// ErrMemberUnknown is the error logged when discord does not say whether a user is a
+// member of a guild
+var ErrMemberUnknown = errors.New("could not check guild membership")
+
+func parseUserID(val string) (snowflake.Snowflake, bool) {
+	uid, err := snowflake.FromString(strings.TrimSpace(val))
+	if err != nil || uid == 0 {
+		return 0, false
+	}
+	return uid, true
+}
+
+// isMember asks discord whether a user is a member of a guild the bot is in. The
+// session only holds the members discord sent it, so it cannot tell on its own.
+func (s *server) isMember(ctx context.Context, gid, uid snowflake.Snowflake) (bool, error) {
+	if s.bot == nil {
+		return false, ErrNotConnected
+	}
+
+	if _, ok := s.deps.BotSession().Guild(gid); !ok {
+		return false, nil
+	}
+
+	if err := s.deps.MessageRateLimiter().Wait(ctx); err != nil {
+		return false, errors.Wrap(err, "error waiting for ratelimiting")
+	}
+
+	resp, body, err := s.deps.HTTPClient().GetBody(ctx, fmt.Sprintf("%s/guilds/%d/members/%d", s.bot.Config().APIURL, gid, uid), nil)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get guild member")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Wrap(ErrMemberUnknown, "non-200 response", "status_code", resp.StatusCode, "resp_body", string(body))
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v easyjson.Unmarshaler) error {
+	b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		return errors.Wrap(err, "could not read request body")
+	}
+
+	return easyjson.Unmarshal(b, v)
+}
+
+func (s *server) signup(ctx context.Context, w http.ResponseWriter, r *http.Request, rt route) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "httpapi.signup", "guild_id", rt.gid.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	var req SignupRequest
+	if err := readJSON(w, r, &req); err != nil {
+		s.writeError(ctx, w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	uid, ok := parseUserID(req.UserID)
+	if !ok {
+		s.writeError(ctx, w, http.StatusBadRequest, "invalid or missing user_id")
+		return
+	}
+
+	role := strings.TrimSpace(req.Role)
+	if role == "" {
+		s.writeError(ctx, w, http.StatusBadRequest, "missing role")
+		return
+	}
+
+	level.Info(logger).Message("handling api signup", "trial_name", rt.trialName, "user_id", uid.ToString(), "role", role)
+
+	member, err := s.isMember(ctx, rt.gid, uid)
+	if err != nil {
+		level.Error(logger).Err("could not check guild membership", err, "user_id", uid.ToString())
+		s.writeError(ctx, w, http.StatusServiceUnavailable, "could not check guild membership")
+		return
+	}
+	if !member {
+		s.writeError(ctx, w, http.StatusUnprocessableEntity, "user is not a member of the server")
+		return
+	}
+
+	p := storage.GetPrinter(ctx, s.deps.GuildAPI(), rt.gid)
+
+	t, err := s.deps.TrialAPI().NewTransaction(ctx, rt.gid.ToString(), true)
+	if err != nil {
+		level.Error(logger).Err("could not start trials transaction", err)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, ok := s.getTrial(ctx, w, t, rt)
+	if !ok {
+		return
+	}
+
+	// report the role by its name even when the request used an alias
+	if name, rerr := commands.ResolveRole(ctx, trial, role); rerr == nil {
+		role = name
+	}
+
+	overflow, err := commands.SignupOpenTrial(ctx, trial, cmdhandler.UserMentionString(uid), role, s.rosterRules(ctx, rt.gid, att, trial))
+	switch {
+	case err == nil:
+	case commands.IsUnknownRole(err):
+		s.writeError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	case err == commands.ErrSignupClosed:
+		s.writeError(ctx, w, http.StatusConflict, err.Error())
+		return
+	default:
+		level.Error(logger).Err("could not sign up", err, "trial_name", rt.trialName)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	if !s.saveTrial(ctx, w, t, trial) {
+		return
+	}
+
+	level.Info(logger).Message("signed up", "overflow", overflow, "role", role, "trial_name", trial.GetName(ctx), "user_id", uid.ToString())
+
+	ev := webhook.Event{
+		Type:    webhook.EventSignup,
+		GuildID: rt.gid.ToString(),
+		Trial:   trial.GetName(ctx),
+		Role:    role,
+		Users:   []string{cmdhandler.UserMentionString(uid)},
+		Actor:   webhook.ActorAPI,
+	}
+	if overflow {
+		ev.Overflow = ev.Users
+	}
+	s.deps.Webhooks().Notify(ctx, ev)
+
+	msg := commands.SignupConfirmation(p, trial.GetName(ctx), role, overflow)
+	s.postConfirmation(ctx, rt.gid, trial, uid, msg)
+	s.deps.Notifier().Notify(ctx, commands.TrialNotices(ctx, s.deps.BotSession(), rt.gid, trial, notify.KindSignup, msg, ev.Users)...)
+
+	s.writeJSON(ctx, w, http.StatusOK, SignupResponse{
+		Message:  msg,
+		Overflow: overflow,
+		Trial:    trialResponse(ctx, trial, s.rosterRules(ctx, rt.gid, att, trial)),
+	})
+}
+
+func (s *server) withdraw(ctx context.Context, w http.ResponseWriter, r *http.Request, rt route) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "httpapi.withdraw", "guild_id", rt.gid.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	uid, ok := parseUserID(r.URL.Query().Get("user_id"))
+	if !ok {
+		s.writeError(ctx, w, http.StatusBadRequest, "invalid or missing user_id")
+		return
+	}
+
+	level.Info(logger).Message("handling api withdraw", "trial_name", rt.trialName, "user_id", uid.ToString())
+
+	p := storage.GetPrinter(ctx, s.deps.GuildAPI(), rt.gid)
+
+	t, err := s.deps.TrialAPI().NewTransaction(ctx, rt.gid.ToString(), true)
+	if err != nil {
+		level.Error(logger).Err("could not start trials transaction", err)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, ok := s.getTrial(ctx, w, t, rt)
+	if !ok {
+		return
+	}
+
+	overflowBefore := commands.OverflowSignups(ctx, trial, s.rosterRules(ctx, rt.gid, att, trial))
+
+	err = commands.WithdrawOpenTrial(ctx, trial, cmdhandler.UserMentionString(uid))
+	switch err {
+	case nil:
+	case commands.ErrWithdrawClosed:
+		s.writeError(ctx, w, http.StatusConflict, err.Error())
+		return
+	default:
+		level.Error(logger).Err("could not withdraw", err, "trial_name", rt.trialName)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	notices := commands.PromotionNotices(ctx, s.deps.BotSession(), p, rt.gid, trial, s.rosterRules(ctx, rt.gid, att, trial), overflowBefore)
+
+	if !s.saveTrial(ctx, w, t, trial) {
+		return
+	}
+
+	level.Info(logger).Message("withdrew", "trial_name", trial.GetName(ctx), "user_id", uid.ToString())
+
+	s.deps.Webhooks().Notify(ctx, webhook.Event{
+		Type:    webhook.EventWithdraw,
+		GuildID: rt.gid.ToString(),
+		Trial:   trial.GetName(ctx),
+		Users:   []string{cmdhandler.UserMentionString(uid)},
+		Actor:   webhook.ActorAPI,
+	})
+	s.deps.Notifier().Notify(ctx, notices...)
+
+	msg := commands.WithdrawConfirmation(p, trial.GetName(ctx))
+	s.postConfirmation(ctx, rt.gid, trial, uid, msg)
+
+	s.writeJSON(ctx, w, http.StatusOK, SignupResponse{
+		Message: msg,
+		Trial:   trialResponse(ctx, trial, s.rosterRules(ctx, rt.gid, att, trial)),
+	})
+}
+
+// getTrial writes the error response itself when the trial cannot be loaded
+func (s *server) getTrial(ctx context.Context, w http.ResponseWriter, t storage.TrialAPITx, rt route) (storage.Trial, bool) {
+	trial, err := t.GetTrial(ctx, rt.trialName)
+	if err == storage.ErrTrialNotExist {
+		s.writeError(ctx, w, http.StatusNotFound, "event not found")
+		return nil, false
+	}
+	if err != nil {
+		level.Error(logging.WithContext(ctx, s.deps.Logger())).Err("could not get trial", err, "trial_name", rt.trialName)
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+
+	return trial, true
+}
+
+// saveTrial writes the error response itself when the trial cannot be saved
+func (s *server) saveTrial(ctx context.Context, w http.ResponseWriter, t storage.TrialAPITx, trial storage.Trial) bool {
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	if err := t.SaveTrial(ctx, trial); err != nil {
+		level.Error(logger).Err("could not save trial", err, "trial_name", trial.GetName(ctx))
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return false
+	}
+
+	if err := t.Commit(ctx); err != nil {
+		level.Error(logger).Err("could not commit trial", err, "trial_name", trial.GetName(ctx))
+		s.writeError(ctx, w, http.StatusInternalServerError, "internal error")
+		return false
+	}
+
+	return true
+}
+
+// postConfirmation announces an api change in the trial's signup channel, the same way the
+// commands would reply there. The change is already saved, so failures are only logged.
+func (s *server) postConfirmation(ctx context.Context, gid snowflake.Snowflake, trial storage.Trial, uid snowflake.Snowflake, msg string) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "httpapi.postConfirmation", "guild_id", gid.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, s.deps.Logger())
+
+	if s.bot == nil {
+		level.Error(logger).Err("could not post confirmation", ErrNotConnected)
+		return
+	}
+
+	g, ok := s.deps.BotSession().Guild(gid)
+	if !ok {
+		level.Info(logger).Message("guild not in session; not posting confirmation", "guild_id", gid.ToString())
+		return
+	}
+
+	cid, ok := msghandler.ChannelID(&g, trial.GetSignupChannelID(ctx), trial.GetSignupChannel(ctx))
+	if !ok {
+		level.Info(logger).Message("signup channel not found; not posting confirmation", "signup_channel", trial.GetSignupChannel(ctx))
+		return
+	}
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To:          cmdhandler.UserMentionString(uid),
+		Description: msg,
+	}
+	r.SetColor(s.successColor)
+
+	// confirmations share the bot's rate limit with command responses
+	if err := s.deps.MessageRateLimiter().Wait(ctx); err != nil {
+		level.Error(logger).Err("error waiting for ratelimiting", err)
+		return
+	}
+
+	resp, body, err := s.bot.SendMessage(ctx, cid, r.ToMessage())
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		level.Error(logger).Err("could not post confirmation", err, "channel_id", cid.ToString(), "resp_body", string(body), "status_code", status)
+	}
+}
@@ -0,0 +1,71 @@
+package httpapi
+
+//go:generate easyjson types.go
+
+// TrialsResponse is the body of GET /guilds/{id}/trials
+//
+//easyjson:json
+type TrialsResponse struct {
+	GuildID string          `json:"guild_id"`
+	Trials  []TrialResponse `json:"trials"`
+}
+
+// TrialResponse is the body of GET /guilds/{id}/trials/{name}
+//
+//easyjson:json
+type TrialResponse struct {
+	Name            string       `json:"name"`
+	State           string       `json:"state"`
+	Description     string       `json:"description"`
+	AnnounceChannel string       `json:"announce_channel"`
+	SignupChannel   string       `json:"signup_channel"`
+	StartTime       string       `json:"start_time,omitempty"`
+	DurationSeconds int64        `json:"duration_seconds,omitempty"`
+	Roles           []RoleRoster `json:"roles"`
+}
+
+// RoleRoster is the main group and overflow for a single role
+//
+//easyjson:json
+type RoleRoster struct {
+	Name     string   `json:"name"`
+	Emoji    string   `json:"emoji"`
+	Count    uint64   `json:"count"`
+	Main     []Member `json:"main"`
+	Overflow []Member `json:"overflow"`
+}
+
+// Member is a user signed up for a role
+//
+//easyjson:json
+type Member struct {
+	Mention   string `json:"mention"`
+	UserID    string `json:"user_id,omitempty"`
+	Character string `json:"character,omitempty"`
+	Class     string `json:"class,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// ErrorResponse is the body of any non-2xx response
+//
+//easyjson:json
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SignupRequest is the body of POST /guilds/{id}/trials/{name}
+//
+//easyjson:json
+type SignupRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// SignupResponse is the body of a successful POST or DELETE on /guilds/{id}/trials/{name}
+//
+//easyjson:json
+type SignupResponse struct {
+	Message  string        `json:"message"`
+	Overflow bool          `json:"overflow"`
+	Trial    TrialResponse `json:"trial"`
+}
@@ -0,0 +1,403 @@
+package i18n
+
+var de = catalog{
+	"admin.canceled":        "%s wurde abgesagt.",
+	"admin.canceled_reason": "%s wurde abgesagt: %s",
+	"admin.cleared":         "Event %q erfolgreich geleert",
+	"admin.closed":          "Event %q geschlossen",
+	"admin.created":         "Event %q erfolgreich erstellt",
+	"admin.deleted":         "Event %q gelöscht",
+	"admin.edited":          "Event %q erfolgreich bearbeitet",
+	"admin.grouping":        "Jetzt Gruppenbildung für %s!",
+	"admin.locked":          "Aufstellung von %q gesperrt. Die Hauptgruppe muss bis %s mit `confirm %s` oder dem Bestätigen-Knopf unter der Aufstellung bestätigen, sonst rückt die Warteliste nach.",
+	"admin.main_group":      "**Hauptgruppe:** %s",
+	"admin.opened":          "Event %q geöffnet",
+	"admin.overflow":        "**Warteliste:** %s",
+	"admin.rescheduled":     "%s wurde auf %s verschoben. Wenn du dann nicht kannst, melde dich mit `withdraw %s` ab.",
+	"admin.signed_up":       "Für %s in %s angemeldet von %s",
+	"admin.signups":         "Anmeldungen:",
+	"admin.withdrawn":       "Von %s abgemeldet von %s",
+
+	"announce.roles":          "Gesuchte Rollen",
+	"announce.signup_channel": "Anmeldekanal",
+	"announce.title":          "Anmeldungen für %s sind offen",
+
+	"apitoken.created": "Ein neuer API-Token ersetzt jeden bisherigen; er wurde dir per Direktnachricht geschickt.",
+	"apitoken.revoked": "Der API-Token wurde widerrufen.",
+
+	"button.confirm":  "Bestätigen",
+	"button.withdraw": "Abmelden",
+	"menu.remove":     "Anmeldungen entfernen (nur Admins)",
+
+	"calendar.file":       "Kalender mit %d geplanten Event(s); öffne den Anhang, um ihn zu importieren.",
+	"calendar.guild_feed": "Abonniere die geplanten Events dieses Servers in deiner Kalender-App:\n%s",
+	"calendar.guild_name": "Discord-Events",
+	"calendar.user_feed":  "Abonniere die Events, für die du angemeldet bist, in deiner Kalender-App:\n%s",
+	"calendar.user_name":  "Meine Discord-Anmeldungen",
+
+	"doctor.admin_role":              "adminrole: <@&%s>",
+	"doctor.channel_missing":         "%s: es gibt keinen Kanal #%s",
+	"doctor.ok":                      "Keine Probleme gefunden.",
+	"doctor.problems":                "**Probleme:**",
+	"doctor.role_missing":            "adminrole: keine Rolle heißt mehr '%s' (wenn sie umbenannt oder gelöscht wurde, setze adminrole erneut)",
+	"doctor.roles":                   "**Rollen nach ID** (jede als @deleted-role angezeigte existiert nicht mehr):",
+	"doctor.trial_channel_missing":   "Event %q, %s: es gibt keinen Kanal #%s",
+	"doctor.trial_no_signup_channel": "Event %q hat keinen signupchannel, daher kann sich niemand dafür anmelden",
+	"doctor.trial_reserve_role":      "Event %q, %s: Plätze sind für <@&%s> reserviert",
+	"doctor.trial_tier_role":         "Event %q, tiers: <@&%s>",
+
+	"dm.apitoken":       "Neuer Token für die Event-API von Server %s (er ersetzt jeden bisherigen Token und wird nicht noch einmal angezeigt):\n```\n%s\n```\nSende ihn als `Authorization: Bearer <token>`.",
+	"dm.canceled":       "%s wurde abgesagt.",
+	"dm.promoted":       "Du bist aus der Warteliste in die Hauptgruppe von %s als %s aufgerückt.",
+	"dm.reminder":       "Erinnerung: %s beginnt am %s; du bist in der Hauptgruppe als %s.",
+	"dm.webhook_secret": "Payloads an den Webhook %s werden mit HMAC-SHA256 im Header `%s` mit diesem Geheimnis signiert (es wird nicht noch einmal angezeigt):\n```\n%s\n```",
+
+	"export.done": "Einstellungen und %d Event(s) exportiert (Formatversion %d).",
+
+	"help.user":          "Befehle",
+	"help.admin":         "Admin-Befehle",
+	"help.config":        "Einstellungsbefehle",
+	"help.more":          "Mit %s <Befehl> gibt es Details und Beispiele zu einem Befehl.",
+	"help.arguments":     "Argumente",
+	"help.examples":      "Beispiele",
+	"help.aliases":       "Auch:",
+	"help.permission":    "Wer ihn ausführen darf:",
+	"help.perm.everyone": "Alle; Event-Befehle antworten im Anmeldekanal des Events",
+	"help.perm.admin":    "Bot-Admins (Server-Admins und die adminrole), im Admin-Kanal",
+	"help.perm.config":   "Bot-Admins (Server-Admins und die adminrole), in jedem Kanal",
+
+	"help.user.list.summary":  "Die offenen und geschlossenen Events dieses Servers auflisten",
+	"help.user.list.examples": "list",
+
+	"help.user.show.summary":   "Die Aufstellung eines Events anzeigen",
+	"help.user.show.arg.event": "Der Name des Events oder sein Anfang (Namen mit Leerzeichen in Anführungszeichen)",
+	"help.user.show.examples":  "show vAA\nshow \"Vet AA\"",
+
+	"help.user.signup.summary":     "Sich für ein Event in einer Rolle anmelden",
+	"help.user.signup.arg.event":   "Der Name des Events",
+	"help.user.signup.arg.role":    "Eine der Rollen des Events oder einer ihrer Aliase; ohne Angabe die Rolle aus deinem Profil",
+	"help.user.signup.arg.details": "character=, class= und note=, um zu sagen, was du mitbringst (ein leerer Wert löscht einen); ein abschließendes note: nimmt den Rest der Nachricht",
+	"help.user.signup.examples":    "signup vAA tank\nsignup vAA\nsu \"Vet AA\" dps\nsignup vAA dps character=Zyra class=nb note=\"10 Min. später\"\nsu vAA healer note: komme vielleicht später",
+
+	"help.user.withdraw.summary":   "Sich von einem Event abmelden",
+	"help.user.withdraw.arg.event": "Der Name des Events",
+	"help.user.withdraw.examples":  "withdraw vAA\nwd vAA",
+
+	"help.user.confirm.summary":   "Deinen Platz in der Hauptgruppe eines gesperrten Events vor der Frist bestätigen; wer das nicht tut, wird aus der Warteliste ersetzt",
+	"help.user.confirm.arg.event": "Der Name des Events",
+	"help.user.confirm.examples":  "confirm vAA",
+
+	"help.user.profile.summary":      "Die Rolle, den Charakter und die Klasse anzeigen oder ändern, mit denen du dich meist anmeldest; eine Anmeldung ohne Rolle nutzt die Profilrolle, und Charakter und Klasse werden in deine Anmeldungen eingetragen",
+	"help.user.profile.arg.action":   "show (Standard), set oder clear",
+	"help.user.profile.arg.settings": "Für set key=value-Einstellungen: role, character (oder char) und class; ein leerer Wert löscht eine",
+	"help.user.profile.examples":     "profile\nprofile set role=healer char=\"Lady Heals\" class=templar\nprofile set class=\nprofile clear",
+
+	"help.user.notify.summary":    "Die Direktnachrichten zu den Events dieses Servers anzeigen oder wählen: Anmeldebestätigungen, Aufrücken aus der Warteliste, Absagen und neue Startzeiten sowie Erinnerungen vor dem Start",
+	"help.user.notify.arg.choice": "on, off (Standard) oder promotions-only, um nur vom Aufrücken aus der Warteliste zu hören; wenn du keine Direktnachrichten annimmst, werden sie stattdessen im Anmeldekanal gepostet",
+	"help.user.notify.examples":   "notify\nnotify on\nnotify promotions-only",
+
+	"help.user.presets.summary":    "Die Rollenaufteilungen auflisten, aus denen Events erstellt werden können, eingebaut für jede ESO-Prüfung und Arena oder für diesen Server hinzugefügt",
+	"help.user.presets.arg.preset": "Nur diese Vorlage anzeigen",
+	"help.user.presets.examples":   "presets\npresets vss-hm",
+
+	"help.user.calendar.summary":  "Den Kalender-Feed der geplanten Events dieses Servers verlinken",
+	"help.user.calendar.arg.me":   "Nur die Events, für die du angemeldet bist",
+	"help.user.calendar.arg.file": "Eine .ics-Datei anhängen, statt den Feed zu verlinken",
+	"help.user.calendar.examples": "calendar\ncalendar me file",
+
+	"help.user.help.summary":     "Die Befehle anzeigen, die du ausführen kannst, oder die Details eines davon",
+	"help.user.help.arg.command": "Ein Befehl, etwa signup oder admin create",
+	"help.user.help.examples":    "help\nhelp signup\nhelp admin create",
+
+	"help.admin.list.summary":  "Die offenen und geschlossenen Events mit ihren Anmeldekanälen auflisten",
+	"help.admin.list.examples": "list",
+
+	"help.admin.show.summary":   "Ein Event mit seinen Einstellungen anzeigen",
+	"help.admin.show.arg.event": "Der Name des Events",
+	"help.admin.show.examples":  "show vAA",
+
+	"help.admin.create.summary":      "Ein Event erstellen, offen für Anmeldungen",
+	"help.admin.create.arg.event":    "Der Name des Events",
+	"help.admin.create.arg.settings": "key=value-Einstellungen: preset (eine Aufteilung aus presets, deren Rollen und Beschreibung die anderen Einstellungen ändern), description, roles (rolle:anzahl oder rolle:anzahl:emoji, durch Kommas getrennt), aliases (andere Namen zum Anmelden, etwa dps:dd:damage,healer:heal), reserve (Plätze, die zuerst an die genannten Mitglieder oder eine Discord-Rolle gehen, etwa dps:2:@Core), release (wie lange vor dem Start nicht beanspruchte reservierte Plätze für alle frei werden, etwa 24h), policy (wer die Plätze bekommt, wenn eine Rolle voll ist: first-come, tiers, attendance bei vergangenen Events oder lottery, ausgelost beim Schließen der Anmeldungen), tiers (für die Policy tiers Discord-Rollen von der ersten bis zur letzten, etwa @Core,@Raider), announcechannel, signupchannel, announceto, start (etwa 2006-01-02T15:04, in UTC, außer mit Offset) und duration (etwa 2h); ein abschließendes description: nimmt den Rest der Nachricht",
+	"help.admin.create.examples":     "create vAA roles=tank:2,healer:2,dps:8 aliases=dps:dd:damage signupchannel=#signups description: Vet AA Hardmode, Essen mitbringen\ncreate \"Vet AA\" start=2026-11-01T20:00+01:00 duration=2h roles=tank:2,healer:2,dps:8\ncreate sunspire preset=vss-hm roles=dps:9,healer:1\ncreate prog preset=vrg-hm reserve=dps:2:@Core,tank:1:@Zyra release=24h start=2026-11-01T20:00\ncreate vDSR preset=vdsr policy=tiers tiers=@Core,@Raider",
+
+	"help.admin.edit.summary":      "Die Einstellungen eines Events ändern",
+	"help.admin.edit.arg.event":    "Der Name des Events",
+	"help.admin.edit.arg.settings": "key=value-Einstellungen wie bei create; eine Rolle mit Anzahl 0 wird entfernt, eine Rolle, die in aliases ohne Aliase steht, verliert ihre Aliase, und eine Rolle mit 0 reservierten Plätzen verliert ihre Reservierung; eine neue Policy ordnet die Aufstellung eines geschlossenen Events neu",
+	"help.admin.edit.examples":     "edit vAA roles=dps:10,healer:0 aliases=tank:\nedit vAA description=\"Gleicher Ort, neue Zeit\" start=2026-11-02T20:00",
+
+	"help.admin.open.summary":   "Ein Event für Anmeldungen öffnen",
+	"help.admin.open.arg.event": "Der Name des Events",
+	"help.admin.open.examples":  "open vAA",
+
+	"help.admin.close.summary":   "Ein Event für Anmeldungen schließen",
+	"help.admin.close.arg.event": "Der Name des Events",
+	"help.admin.close.examples":  "close vAA",
+
+	"help.admin.lock.summary":      "Ein Event schließen und seine Aufstellung als endgültig nehmen: die Hauptgruppe wird im Ankündigungskanal gepingt, um bis zur Frist zu bestätigen, und die Warteliste rückt für alle nach, die das nicht tun; öffne das Event erneut, um es zu entsperren",
+	"help.admin.lock.arg.event":    "Der Name des Events",
+	"help.admin.lock.arg.deadline": "Wie lange Mitglieder zum Bestätigen haben (etwa 12h) oder bis wann (etwa 2006-01-02T15:04); standardmäßig ein Tag oder bis zum Start, wenn das früher ist",
+	"help.admin.lock.examples":     "lock vAA\nlock vAA 6h\nlock vAA 2026-11-01T18:00",
+
+	"help.admin.cancel.summary":    "Ein Event absagen, ohne es zu löschen: die angemeldeten Mitglieder werden im Ankündigungskanal gepingt und die Anmeldungen bleiben erhalten; öffne das Event erneut, um das rückgängig zu machen",
+	"help.admin.cancel.arg.event":  "Der Name des Events",
+	"help.admin.cancel.arg.reason": "Warum das Event abgesagt wird, angezeigt in der Aufstellung und im Ping; ein abschließendes message: nimmt den Rest der Nachricht unverändert",
+	"help.admin.cancel.examples":   "cancel vAA\ncancel vAA Diese Woche nicht genug Heiler",
+
+	"help.admin.reschedule.summary":   "Den Start eines Events verschieben und die Anmeldungen behalten: die angemeldeten Mitglieder werden im Ankündigungskanal gepingt und können sich auch bei geschlossenen Anmeldungen abmelden, bis das Event erneut geschlossen wird",
+	"help.admin.reschedule.arg.event": "Der Name des Events",
+	"help.admin.reschedule.arg.start": "Der neue Start, etwa 2006-01-02T15:04 oder 2006-01-02 15:04 (UTC), optional mit Offset",
+	"help.admin.reschedule.examples":  "reschedule vAA 2026-11-02T19:00\nreschedule vAA 2026-11-02 20:00 +01:00",
+
+	"help.admin.delete.summary":   "Ein Event löschen",
+	"help.admin.delete.arg.event": "Der vollständige Name des Events",
+	"help.admin.delete.examples":  "delete vAA",
+
+	"help.admin.clear.summary":   "Alle Anmeldungen aus einem Event entfernen",
+	"help.admin.clear.arg.event": "Der vollständige Name des Events",
+	"help.admin.clear.examples":  "clear vAA",
+
+	"help.admin.announce.summary":     "Ein Event in seinem Ankündigungskanal ankündigen",
+	"help.admin.announce.arg.event":   "Der Name des Events",
+	"help.admin.announce.arg.message": "Text, der der Ankündigung hinzugefügt wird; ein abschließendes message: nimmt den Rest der Nachricht unverändert",
+	"help.admin.announce.examples":    "announce vAA\nannounce vAA message: Essen mitbringen, wir starten pünktlich",
+
+	"help.admin.grouping.summary":     "Die angemeldeten Mitglieder eines Events zur Gruppenbildung rufen",
+	"help.admin.grouping.arg.event":   "Der Name des Events",
+	"help.admin.grouping.arg.message": "Text, der statt des Standardtexts gepostet wird; ein abschließendes message: nimmt den Rest der Nachricht unverändert",
+	"help.admin.grouping.examples":    "grouping vAA\ngrouping vAA Einladungen gehen jetzt raus",
+
+	"help.admin.signup.summary":     "Andere Mitglieder für ein Event anmelden",
+	"help.admin.signup.arg.event":   "Der Name des Events",
+	"help.admin.signup.arg.role":    "Eine der Rollen des Events",
+	"help.admin.signup.arg.members": "Erwähnungen der anzumeldenden Mitglieder",
+	"help.admin.signup.examples":    "signup vAA tank @Someone @Someone-else",
+
+	"help.admin.withdraw.summary":     "Andere Mitglieder von einem Event abmelden",
+	"help.admin.withdraw.arg.event":   "Der Name des Events",
+	"help.admin.withdraw.arg.members": "Erwähnungen der abzumeldenden Mitglieder",
+	"help.admin.withdraw.examples":    "withdraw vAA @Someone",
+
+	"help.config.list.summary":  "Die Einstellungen dieses Servers anzeigen, und Kanäle oder Rollen darin, die nicht mehr existieren",
+	"help.config.list.examples": "list",
+
+	"help.config.get.summary":     "Eine Einstellung anzeigen",
+	"help.config.get.arg.setting": "Der Name der Einstellung",
+	"help.config.get.examples":    "get signupchannel",
+
+	"help.config.set.summary":      "Einstellungen ändern",
+	"help.config.set.arg.settings": "key=value-Einstellungen: controlsequence, announcechannel, signupchannel, adminchannel (#Kanal-Erwähnungen oder Namen), announceto, showaftersignup, showafterwithdraw, adminrole (eine @Rollen-Erwähnung, ID oder ein Name; leer zum Löschen) und locale",
+	"help.config.set.examples":     "set signupchannel=#signups adminchannel=#officers\nset adminrole=\"Raid Lead\" locale=de",
+
+	"help.config.reset.summary":  "Alle Einstellungen auf ihren Standard zurücksetzen",
+	"help.config.reset.examples": "reset",
+
+	"help.config.doctor.summary":  "Die Einstellungen und alle Events auf Kanäle und Rollen prüfen, die nicht mehr existieren",
+	"help.config.doctor.examples": "doctor",
+
+	"help.config.export.summary":  "Die Einstellungen und Events dieses Servers als JSON-Datei exportieren",
+	"help.config.export.examples": "export",
+
+	"help.config.apitoken.summary":    "Einen Token für die Event-API erstellen, der jeden bisherigen ersetzt",
+	"help.config.apitoken.arg.revoke": "Stattdessen den Token widerrufen",
+	"help.config.apitoken.examples":   "apitoken\napitoken revoke",
+
+	"help.config.webhook.summary":    "Die Webhooks verwalten, an die Änderungen an Events gesendet werden",
+	"help.config.webhook.arg.action": "add, list oder remove",
+	"help.config.webhook.arg.args":   "Für add die öffentliche https-URL und optional events=signup,withdraw,open,close,create,delete,cancel,reschedule; für remove die URL oder ihre Nummer in der Liste",
+	"help.config.webhook.examples":   "webhook add https://example.com/hook events=signup,withdraw\nwebhook list\nwebhook remove 1",
+
+	"help.config.preset.summary":    "Die Vorlagen dieses Servers zum Erstellen von Events verwalten; eine Vorlage mit dem Namen einer eingebauten ersetzt diese hier",
+	"help.config.preset.arg.action": "set, list oder remove",
+	"help.config.preset.arg.args":   "Für set der Vorlagenname, roles= und optional description=; für remove der Vorlagenname",
+	"help.config.preset.examples":   "preset set prog roles=tank:2:🛡️,healer:3:💚,dps:7:⚔️ description=\"Progressionsabend\"\npreset list\npreset remove prog",
+
+	"help.config.stats.summary":  "Die Anzahl der Events über alle Server des Bots anzeigen",
+	"help.config.stats.examples": "stats",
+
+	"help.config.version.summary":  "Die Version des Bots anzeigen",
+	"help.config.version.examples": "version",
+
+	"help.config.website.summary":  "Die Website des Bots anzeigen",
+	"help.config.website.examples": "website",
+
+	"help.config.discord.summary":  "Den Support-Discord des Bots anzeigen",
+	"help.config.discord.examples": "discord",
+
+	"list.closed": "*Geschlossene Trials*",
+	"list.none":   "(noch keine)",
+	"list.open":   "*Verfügbare Trials*",
+
+	"notice.buttons_server_only": "Diese Schaltflächen funktionieren nur auf einem Server.",
+	"notice.error":               "Fehler: %v",
+	"notice.post_failed":         "Konnte in diesem Kanal nicht posten; prüfe dort die Berechtigungen des Bots.",
+	"notice.posted":              "In %s gepostet.",
+	"notice.remove_unauthorized": "Nur Admins können Anmeldungen entfernen.",
+	"notice.removed":             "%s von %s entfernt.",
+	"notice.server_only":         "Diese Befehle funktionieren nur auf einem Server.",
+	"notice.unauthorized":        "Du darfst diesen Befehl nicht verwenden.",
+	"notice.unknown_command":     "Unbekannter Befehl; der Bot wurde eventuell aktualisiert, seit die Befehlsliste geladen wurde.",
+	"notice.wrong_channel":       "Dieser Befehl kann in diesem Kanal nicht verwendet werden.",
+
+	"notify.current":         "Direktnachrichten zu Events: %s",
+	"notify.off":             "Du bekommst keine Direktnachrichten.",
+	"notify.on":              "Du bekommst eine Direktnachricht, wenn du dich anmeldest, aus der Warteliste aufrückst, ein Event, für das du angemeldet bist, abgesagt oder verschoben wird und bevor es beginnt.",
+	"notify.promotions_only": "Du bekommst nur eine Direktnachricht, wenn du aus der Warteliste aufrückst.",
+
+	"presets.builtin": "**Eingebaute Vorlagen**",
+	"presets.guild":   "**Vorlagen dieses Servers**",
+	"presets.removed": "Vorlage %s entfernt.",
+	"presets.saved":   "Vorlage gespeichert: %s",
+
+	"profile.none":  "Du hast noch kein Profil; lege eines an mit profile set role=... character=... class=...",
+	"profile.saved": "Profil gespeichert.",
+	"profile.show": `Dein Profil:
+- Rolle: %s
+- Charakter: %s
+- Klasse: %s
+- Direktnachrichten: %s`,
+
+	"roster.canceled":        "❌ Dieses Event ist abgesagt",
+	"roster.canceled_reason": "❌ Dieses Event ist abgesagt: %s",
+	"roster.confirm_by":      "🔒 Die Aufstellung ist gesperrt: die Hauptgruppe bestätigt mit `confirm %s` bis %s",
+	"roster.confirm_closed":  "🔒 Die Aufstellung ist gesperrt; Bestätigungen endeten %s",
+	"roster.confirmed":       "%s ✅",
+	"roster.empty":           "(leer)",
+	"roster.overflow":        "Warteliste %s",
+	"roster.pending":         "%s ⏳",
+	"roster.promoted":        "%s ⬆️",
+	"roster.reserved":        "%s 🔒",
+	"roster.unclaimed":       "_%d reservierte(r) Platz/Plätze noch frei_",
+
+	"signup.done":      "Für %s in %s angemeldet",
+	"signup.last_only": "(nur die Details des letzten Trials werden angezeigt)",
+	"signup.overflow":  "Auf der WARTELISTE für %s in %s angemeldet",
+	"confirm.done":     "Platz in %s bestätigt",
+	"withdraw.done":    "Von %s abgemeldet",
+
+	"state.canceled": "abgesagt",
+	"state.closed":   "geschlossen",
+	"state.open":     "offen",
+
+	"stats.summary": "Server insgesamt: %d\nEvents insgesamt: %d\nDerzeit offen: %d\nDerzeit geschlossen: %d\n",
+
+	"webhook.added":   "Webhook %s für %s hinzugefügt.\nPayloads werden mit HMAC-SHA256 im Header `%s` signiert; das Geheimnis wurde dir per Direktnachricht geschickt.",
+	"webhook.none":    "Es sind keine Webhooks registriert.",
+	"webhook.removed": "Webhook %s entfernt",
+
+	"settings.guild": `
+Servereinstellungen:
+
+	- ControlSequence: '%[2]s',
+	- AnnounceChannel: '#%[3]s',
+	- SignupChannel: '#%[4]s',
+	- AdminChannel: '#%[5]s',
+	- AnnounceTo: '%[6]s',
+	- ShowAfterSignup: '%[7]s',
+	- ShowAfterWithdraw: '%[8]s',
+	- AdminRole: '<@&%[9]s>',
+	- Locale: '%[10]s',
+
+	`,
+
+	"trial.lottery_drawn": "%s (gezogen mit Startwert %d)",
+	"trial.settings": `
+Event-Einstellungen:
+
+	- Status: '%[4]s',
+	- Ankündigungskanal: '#%[1]s',
+	- Anmeldekanal: '#%[2]s',
+	- Ankündigen an: '%[3]s',
+	- Beginn: '%[7]s',
+	- Dauer: '%[8]s',
+	- Reservierungen frei: '%[9]s',
+	- Reihenfolge: '%[10]s',
+	- Rollen:
+		%[5]s
+
+Beschreibung:
+%[6]s
+
+	`,
+	"trial.unscheduled": "nicht geplant",
+
+	"err.admin_signup_mentions":      "du musst einen oder mehrere Benutzer erwähnen, die du anmelden möchtest (@...)",
+	"err.admin_signup_usage":         "nicht genügend Argumente (benötigt `event-name rolle benutzer-erwähnung(en)`)",
+	"err.admin_withdraw_mentions":    "du musst einen oder mehrere Benutzer erwähnen, die du abmelden möchtest (@...)",
+	"err.admin_withdraw_usage":       "nicht genügend Argumente (benötigt `event-name benutzer-erwähnung(en)`)",
+	"err.alias_conflict":             "'%s' ist schon ein Name oder Alias der Rolle %s",
+	"err.alias_unknown_role":         "'%s' ist keine Rolle des Events und kann keine Aliase bekommen",
+	"err.already_locked":             "die Aufstellung von %s ist schon gesperrt (öffne das Event erneut, um sie zu entsperren)",
+	"err.ambiguous_trial":            "'%s' passt auf mehrere Events: %s; gib mehr vom Namen ein",
+	"err.apitoken_argument":          "unbekanntes Argument '%s' (meintest du 'revoke'?)",
+	"err.apitoken_undelivered":       "der neue API-Token konnte dir nicht per Direktnachricht geschickt werden, daher bleibt der bisherige Token gültig; erlaube Direktnachrichten von diesem Server und versuche es erneut",
+	"err.bad_aliases":                "Aliase '%s' nicht verstanden; benutze rolle:alias:alias",
+	"err.bad_argument":               "'%s' konnte nicht gelesen werden (verwende schlüssel=wert und setze Werte mit Leerzeichen in Anführungszeichen)",
+	"err.bad_deadline":               "Frist '%s' konnte nicht gelesen werden (verwende eine Dauer wie 12h oder eine künftige Zeit wie 2006-01-02T15:04)",
+	"err.bad_duration":               "Dauer '%s' konnte nicht gelesen werden (verwende etwa 90m oder 2h)",
+	"err.bad_locale":                 "nicht unterstützte Sprache '%s' (verwende eine von %s)",
+	"err.bad_option_value":           "Optionswert nicht verstanden",
+	"err.bad_reserve":                "Reservierung '%s' konnte nicht gelesen werden (benötigt rolle:plätze:@nutzer oder @rolle)",
+	"err.bad_role_count":             "Rollenanzahl '%s' konnte nicht gelesen werden",
+	"err.bad_roles":                  "Rollen konnten nicht gelesen werden",
+	"err.bad_setting":                "ungültige Einstellung",
+	"err.bad_start":                  "Startzeit '%s' konnte nicht gelesen werden (verwende 2006-01-02T15:04 oder 2006-01-02 15:04 in UTC oder füge einen Versatz wie +01:00 hinzu)",
+	"err.calendar_argument":          "unbekanntes Argument '%s' (verwende me und/oder file)",
+	"err.canceled":                   "%s ist abgesagt (öffne es erneut, um das rückgängig zu machen)",
+	"err.confirm_closed":             "die Frist zum Bestätigen für %s ist abgelaufen",
+	"err.guild_not_found":            "Server nicht gefunden",
+	"err.missing_role":               "Rolle fehlt",
+	"err.missing_setting":            "Name der Einstellung fehlt",
+	"err.need_event_name":            "Eventname benötigt",
+	"err.need_start":                 "neuer Start des Events benötigt, wie 2006-01-02T15:04",
+	"err.no_settings":                "keine Einstellungen zum Speichern",
+	"err.no_signups_chosen":          "es wurden keine Anmeldungen ausgewählt",
+	"err.not_in_main_group":          "du bist nicht in der Hauptgruppe von %s",
+	"err.not_locked":                 "die Aufstellung von %s ist nicht gesperrt, es gibt nichts zu bestätigen",
+	"err.notify_choice":              "unbekannte Auswahl '%s' (erlaubt sind on, off oder promotions-only)",
+	"err.policy_needs_tiers":         "die Richtlinie tiers benötigt tiers=... mit den Discord-Rollen, die zuerst kommen",
+	"err.preset_action":              "Aktion benötigt (set, list oder remove)",
+	"err.preset_name_too_long":       "Vorlagennamen dürfen höchstens %d Zeichen haben",
+	"err.preset_need_name":           "Vorlagenname benötigt",
+	"err.preset_need_roles":          "roles=... mit mindestens einer Rolle benötigt",
+	"err.preset_not_found":           "dieser Server hat keine Vorlage '%s'",
+	"err.profile_action":             "unbekannte Profilaktion '%s'; benutze show, set oder clear",
+	"err.profile_role_not_in_trial":  "deine Profilrolle %s ist keine Rolle von %s; gib die Rolle an",
+	"err.show_usage":                 "du musst genau 1 Argument angeben -- den Eventnamen; fehlen Anführungszeichen?",
+	"err.signup_arguments":           "falsche Anzahl an Argumenten",
+	"err.reserve_one_role":           "die Reservierung von %s kann nur eine Discord-Rolle nennen",
+	"err.reserve_too_many":           "von %s können nicht mehr Plätze reserviert werden als ihre Anzahl %d",
+	"err.reserve_unknown_role":       "für '%s' können keine Plätze reserviert werden, es ist keine Rolle des Events",
+	"err.signup_closed":              "Anmeldung für ein geschlossenes Trial nicht möglich",
+	"err.signup_detail_too_long":     "%s darf höchstens %d Zeichen lang sein",
+	"err.too_many_arguments":         "zu viele Argumente",
+	"err.too_many_presets":           "ein Server kann höchstens %d Vorlagen haben",
+	"err.too_many_webhooks":          "ein Server kann höchstens %d Webhooks haben",
+	"err.trial_not_exist":            "Trial existiert nicht",
+	"err.trial_not_exist_suggest":    "es gibt kein Event '%s'; meintest du %s?",
+	"err.unknown_guild_role":         "Rolle mit dem Namen '%s' nicht gefunden",
+	"err.unknown_help_topic":         "es gibt keinen Befehl '%s', den du ausführen darfst",
+	"err.unknown_channel":            "es gibt keinen Kanal namens '#%s'",
+	"err.unknown_channel_mention":    "%s ist kein Kanal dieses Servers",
+	"err.unknown_preset":             "es gibt keine Vorlage '%s'; siehe presets",
+	"err.unknown_preset_suggest":     "es gibt keine Vorlage '%s'; meintest du %s?",
+	"err.unknown_policy":             "unbekannte Richtlinie '%s' (benötigt first-come, tiers, attendance oder lottery)",
+	"err.unknown_profile_field":      "unbekannte Profileinstellung '%s'; benutze role, character oder class",
+	"err.unknown_role":               "unbekannte Rolle",
+	"err.unknown_role_mention":       "%s ist keine Rolle dieses Servers",
+	"err.unknown_role_suggest":       "unbekannte Rolle '%s'; meintest du %s?",
+	"err.unknown_setting":            "'%s' ist nicht der Name einer Einstellung",
+	"err.unmatched_quote":            "das Anführungszeichen an Zeichen %d wird nie geschlossen: %s (schließe es oder schreibe \\\" für ein wörtliches Anführungszeichen)",
+	"err.webhook_action":             "Aktion benötigt (add, list oder remove)",
+	"err.webhook_address_host":       "'%s' ist eine IP-Adresse; Webhooks brauchen einen Hostnamen",
+	"err.webhook_exists":             "diese Webhook-URL ist bereits registriert",
+	"err.webhook_need_remove":        "Webhook-URL oder -Nummer zum Entfernen benötigt",
+	"err.webhook_need_url":           "Webhook-URL benötigt",
+	"err.webhook_not_found":          "Webhook nicht gefunden",
+	"err.webhook_private_host":       "'%s' ist eine private oder lokale Adresse; Webhooks können nur an öffentliche Hosts gesendet werden",
+	"err.webhook_secret_undelivered": "das Signaturgeheimnis für %s konnte dir nicht per Direktnachricht geschickt werden, daher wurde der Webhook nicht hinzugefügt; erlaube Direktnachrichten von diesem Server und füge ihn erneut hinzu",
+	"err.webhook_unknown_action":     "unbekannte Aktion '%s' (benötigt add, list oder remove)",
+	"err.webhook_unresolved_host":    "der Webhook-Host '%s' konnte nicht aufgelöst werden",
+	"err.webhook_url":                "die Webhook-URL muss eine absolute https-URL mit einem Hostnamen sein",
+	"err.withdraw_closed":            "Abmeldung von einem geschlossenen Trial nicht möglich",
+}
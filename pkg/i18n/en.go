@@ -0,0 +1,403 @@
+package i18n
+
+var en = catalog{
+	"admin.canceled":        "%s has been canceled.",
+	"admin.canceled_reason": "%s has been canceled: %s",
+	"admin.cleared":         "Event %q cleared successfully",
+	"admin.closed":          "Closed event %q",
+	"admin.created":         "Event %q created successfully",
+	"admin.deleted":         "Deleted event %q",
+	"admin.edited":          "Event %q edited successfully",
+	"admin.grouping":        "Grouping now for %s!",
+	"admin.locked":          "Locked the roster of %q. The main group must confirm by %s with `confirm %s` or the Confirm button under the roster, or the overflow takes their place.",
+	"admin.main_group":      "**Main Group:** %s",
+	"admin.opened":          "Opened event %q",
+	"admin.overflow":        "**Overflow:** %s",
+	"admin.rescheduled":     "%s has moved to %s. If you can no longer make it, withdraw with `withdraw %s`.",
+	"admin.signed_up":       "Signed up for %s in %s by %s",
+	"admin.signups":         "Signups:",
+	"admin.withdrawn":       "Withdrawn from %s by %s",
+
+	"announce.roles":          "Roles Requested",
+	"announce.signup_channel": "Signup Channel",
+	"announce.title":          "Signups are open for %s",
+
+	"apitoken.created": "A new API token replaces any previous one; it was sent to you by direct message.",
+	"apitoken.revoked": "The API token has been revoked.",
+
+	"button.confirm":  "Confirm",
+	"button.withdraw": "Withdraw",
+	"menu.remove":     "Remove signups (admins only)",
+
+	"calendar.file":       "Calendar with %d scheduled event(s); open the attachment to import it.",
+	"calendar.guild_feed": "Subscribe to this server's scheduled events in your calendar app:\n%s",
+	"calendar.guild_name": "Discord events",
+	"calendar.user_feed":  "Subscribe to the events you signed up for in your calendar app:\n%s",
+	"calendar.user_name":  "My Discord signups",
+
+	"doctor.admin_role":              "adminrole: <@&%s>",
+	"doctor.channel_missing":         "%s: there is no channel #%s",
+	"doctor.ok":                      "No problems found.",
+	"doctor.problems":                "**Problems:**",
+	"doctor.role_missing":            "adminrole: no role is named '%s' any more (if it was renamed or deleted, set adminrole again)",
+	"doctor.roles":                   "**Roles kept by id** (any shown as @deleted-role no longer exists):",
+	"doctor.trial_channel_missing":   "event %q, %s: there is no channel #%s",
+	"doctor.trial_no_signup_channel": "event %q has no signupchannel, so members cannot sign up for it",
+	"doctor.trial_reserve_role":      "event %q, %s: slots are reserved for <@&%s>",
+	"doctor.trial_tier_role":         "event %q, tiers: <@&%s>",
+
+	"dm.apitoken":       "New events API token for server %s (it replaces any previous token and will not be shown again):\n```\n%s\n```\nSend it as `Authorization: Bearer <token>`.",
+	"dm.canceled":       "%s has been canceled.",
+	"dm.promoted":       "You moved up from the overflow into the main group of %s as %s.",
+	"dm.reminder":       "Reminder: %s starts at %s; you are in the main group as %s.",
+	"dm.webhook_secret": "Payloads sent to the webhook %s are signed with HMAC-SHA256 in the `%s` header using this secret (it will not be shown again):\n```\n%s\n```",
+
+	"export.done": "Exported settings and %d event(s) (format version %d).",
+
+	"help.user":          "Commands",
+	"help.admin":         "Admin commands",
+	"help.config":        "Settings commands",
+	"help.more":          "Use %s <command> for the details and examples of a command.",
+	"help.arguments":     "Arguments",
+	"help.examples":      "Examples",
+	"help.aliases":       "Also:",
+	"help.permission":    "Who can run it:",
+	"help.perm.everyone": "Anyone; event commands answer in the event signup channel",
+	"help.perm.admin":    "Bot admins (server admins and the adminrole), in the admin channel",
+	"help.perm.config":   "Bot admins (server admins and the adminrole), in any channel",
+
+	"help.user.list.summary":  "List the open and closed events of this server",
+	"help.user.list.examples": "list",
+
+	"help.user.show.summary":   "Show the roster of an event",
+	"help.user.show.arg.event": "The name of the event, or the start of it (quote names with spaces)",
+	"help.user.show.examples":  "show vAA\nshow \"Vet AA\"",
+
+	"help.user.signup.summary":     "Sign up for an event in a role",
+	"help.user.signup.arg.event":   "The name of the event",
+	"help.user.signup.arg.role":    "One of the roles of the event, or one of its aliases; the role in your profile if left out",
+	"help.user.signup.arg.details": "character=, class=, and note= to tell what you bring (an empty value clears one); a final note: takes the rest of the message",
+	"help.user.signup.examples":    "signup vAA tank\nsignup vAA\nsu \"Vet AA\" dps\nsignup vAA dps character=Zyra class=nb note=\"late 10min\"\nsu vAA healer note: might be late",
+
+	"help.user.withdraw.summary":   "Withdraw from an event",
+	"help.user.withdraw.arg.event": "The name of the event",
+	"help.user.withdraw.examples":  "withdraw vAA\nwd vAA",
+
+	"help.user.confirm.summary":   "Confirm your place in the main group of a locked event before the deadline; those who do not are replaced from the overflow",
+	"help.user.confirm.arg.event": "The name of the event",
+	"help.user.confirm.examples":  "confirm vAA",
+
+	"help.user.profile.summary":      "Show or change the role, character, and class you usually sign up with; signing up without a role uses the profile role, and the character and class are filled in on your signups",
+	"help.user.profile.arg.action":   "show (the default), set, or clear",
+	"help.user.profile.arg.settings": "For set, key=value settings: role, character (or char), and class; an empty value clears one",
+	"help.user.profile.examples":     "profile\nprofile set role=healer char=\"Lady Heals\" class=templar\nprofile set class=\nprofile clear",
+
+	"help.user.notify.summary":    "Show or choose the direct messages you get about this server's events: signup confirmations, moving up from the overflow, cancellations and new start times, and reminders before the start",
+	"help.user.notify.arg.choice": "on, off (the default), or promotions-only to hear only about moving up from the overflow; if you do not accept direct messages, they are posted in the signup channel instead",
+	"help.user.notify.examples":   "notify\nnotify on\nnotify promotions-only",
+
+	"help.user.presets.summary":    "List the role layouts events can be created from, built in for every ESO trial and arena or added for this server",
+	"help.user.presets.arg.preset": "Show only this preset",
+	"help.user.presets.examples":   "presets\npresets vss-hm",
+
+	"help.user.calendar.summary":  "Link the calendar feed of this server's scheduled events",
+	"help.user.calendar.arg.me":   "Only the events you signed up for",
+	"help.user.calendar.arg.file": "Attach an .ics file instead of linking the feed",
+	"help.user.calendar.examples": "calendar\ncalendar me file",
+
+	"help.user.help.summary":     "Show the commands you can run, or the details of one of them",
+	"help.user.help.arg.command": "A command, like signup or admin create",
+	"help.user.help.examples":    "help\nhelp signup\nhelp admin create",
+
+	"help.admin.list.summary":  "List the open and closed events with their signup channels",
+	"help.admin.list.examples": "list",
+
+	"help.admin.show.summary":   "Show an event with its settings",
+	"help.admin.show.arg.event": "The name of the event",
+	"help.admin.show.examples":  "show vAA",
+
+	"help.admin.create.summary":      "Create an event, open for signups",
+	"help.admin.create.arg.event":    "The name of the event",
+	"help.admin.create.arg.settings": "key=value settings: preset (a layout from presets, whose roles and description the other settings change), description, roles (role:count or role:count:emoji, separated by commas), aliases (other names to sign up with, like dps:dd:damage,healer:heal), reserve (slots filled first by the listed members or a discord role, like dps:2:@Core), release (how long before the start unclaimed reserved slots open to everyone, like 24h), policy (who gets the slots when a role is full: first-come, tiers, attendance in past events, or a lottery drawn when signups close), tiers (for the tiers policy, discord roles from first to last, like @Core,@Raider), announcechannel, signupchannel, announceto, start (like 2006-01-02T15:04, in UTC unless an offset is given), and duration (like 2h); a final description: takes the rest of the message",
+	"help.admin.create.examples":     "create vAA roles=tank:2,healer:2,dps:8 aliases=dps:dd:damage signupchannel=#signups description: Vet AA hardmode, bring food\ncreate \"Vet AA\" start=2026-11-01T20:00+01:00 duration=2h roles=tank:2,healer:2,dps:8\ncreate sunspire preset=vss-hm roles=dps:9,healer:1\ncreate prog preset=vrg-hm reserve=dps:2:@Core,tank:1:@Zyra release=24h start=2026-11-01T20:00\ncreate vDSR preset=vdsr policy=tiers tiers=@Core,@Raider",
+
+	"help.admin.edit.summary":      "Change the settings of an event",
+	"help.admin.edit.arg.event":    "The name of the event",
+	"help.admin.edit.arg.settings": "key=value settings, as for create; a role with count 0 is removed, and a role listed in aliases without any loses its aliases, and a role with 0 reserved slots loses its reservation; changing the policy of a closed event orders its roster again",
+	"help.admin.edit.examples":     "edit vAA roles=dps:10,healer:0 aliases=tank:\nedit vAA description=\"Same place, new time\" start=2026-11-02T20:00",
+
+	"help.admin.open.summary":   "Open an event for signups",
+	"help.admin.open.arg.event": "The name of the event",
+	"help.admin.open.examples":  "open vAA",
+
+	"help.admin.close.summary":   "Close an event for signups",
+	"help.admin.close.arg.event": "The name of the event",
+	"help.admin.close.examples":  "close vAA",
+
+	"help.admin.lock.summary":      "Close an event and take its roster as final: the main group is pinged in the announce channel to confirm by the deadline, and the overflow moves up in place of those who do not; open the event again to unlock it",
+	"help.admin.lock.arg.event":    "The name of the event",
+	"help.admin.lock.arg.deadline": "How long members have to confirm (like 12h) or when they must by (like 2006-01-02T15:04); a day, or until the start if sooner, by default",
+	"help.admin.lock.examples":     "lock vAA\nlock vAA 6h\nlock vAA 2026-11-01T18:00",
+
+	"help.admin.cancel.summary":    "Cancel an event without deleting it: the signed up members are pinged in the announce channel and the signups are kept; open the event again to undo it",
+	"help.admin.cancel.arg.event":  "The name of the event",
+	"help.admin.cancel.arg.reason": "Why the event is canceled, shown on the roster and in the ping; a final message: takes the rest of the message as is",
+	"help.admin.cancel.examples":   "cancel vAA\ncancel vAA Not enough healers this week",
+
+	"help.admin.reschedule.summary":   "Move the start of an event, keeping its signups: the signed up members are pinged in the announce channel and can withdraw even if signups are closed, until the event is closed again",
+	"help.admin.reschedule.arg.event": "The name of the event",
+	"help.admin.reschedule.arg.start": "The new start, like 2006-01-02T15:04 or 2006-01-02 15:04 (UTC), optionally with an offset",
+	"help.admin.reschedule.examples":  "reschedule vAA 2026-11-02T19:00\nreschedule vAA 2026-11-02 20:00 +01:00",
+
+	"help.admin.delete.summary":   "Delete an event",
+	"help.admin.delete.arg.event": "The full name of the event",
+	"help.admin.delete.examples":  "delete vAA",
+
+	"help.admin.clear.summary":   "Remove every signup from an event",
+	"help.admin.clear.arg.event": "The full name of the event",
+	"help.admin.clear.examples":  "clear vAA",
+
+	"help.admin.announce.summary":     "Announce an event in its announce channel",
+	"help.admin.announce.arg.event":   "The name of the event",
+	"help.admin.announce.arg.message": "Text to add to the announcement; a final message: takes the rest of the message as is",
+	"help.admin.announce.examples":    "announce vAA\nannounce vAA message: Bring food, we start on time",
+
+	"help.admin.grouping.summary":     "Call the signed up members of an event to group up",
+	"help.admin.grouping.arg.event":   "The name of the event",
+	"help.admin.grouping.arg.message": "Text to post instead of the default; a final message: takes the rest of the message as is",
+	"help.admin.grouping.examples":    "grouping vAA\ngrouping vAA Invites going out now",
+
+	"help.admin.signup.summary":     "Sign other members up for an event",
+	"help.admin.signup.arg.event":   "The name of the event",
+	"help.admin.signup.arg.role":    "One of the roles of the event",
+	"help.admin.signup.arg.members": "Mentions of the members to sign up",
+	"help.admin.signup.examples":    "signup vAA tank @Someone @Someone-else",
+
+	"help.admin.withdraw.summary":     "Withdraw other members from an event",
+	"help.admin.withdraw.arg.event":   "The name of the event",
+	"help.admin.withdraw.arg.members": "Mentions of the members to withdraw",
+	"help.admin.withdraw.examples":    "withdraw vAA @Someone",
+
+	"help.config.list.summary":  "Show the settings of this server, and channels or roles in them that no longer exist",
+	"help.config.list.examples": "list",
+
+	"help.config.get.summary":     "Show one setting",
+	"help.config.get.arg.setting": "The name of the setting",
+	"help.config.get.examples":    "get signupchannel",
+
+	"help.config.set.summary":      "Change settings",
+	"help.config.set.arg.settings": "key=value settings: controlsequence, announcechannel, signupchannel, adminchannel (#channel mentions or names), announceto, showaftersignup, showafterwithdraw, adminrole (a @role mention, id, or name; empty to clear), and locale",
+	"help.config.set.examples":     "set signupchannel=#signups adminchannel=#officers\nset adminrole=\"Raid Lead\" locale=de",
+
+	"help.config.reset.summary":  "Reset every setting to its default",
+	"help.config.reset.examples": "reset",
+
+	"help.config.doctor.summary":  "Check the settings and every event for channels and roles that no longer exist",
+	"help.config.doctor.examples": "doctor",
+
+	"help.config.export.summary":  "Export the settings and events of this server as a JSON file",
+	"help.config.export.examples": "export",
+
+	"help.config.apitoken.summary":    "Create a token for the events API, replacing any previous one",
+	"help.config.apitoken.arg.revoke": "Revoke the token instead",
+	"help.config.apitoken.examples":   "apitoken\napitoken revoke",
+
+	"help.config.webhook.summary":    "Manage the webhooks that are sent changes to events",
+	"help.config.webhook.arg.action": "add, list, or remove",
+	"help.config.webhook.arg.args":   "For add, the public https url and optionally events=signup,withdraw,open,close,create,delete,cancel,reschedule; for remove, the url or its number in the list",
+	"help.config.webhook.examples":   "webhook add https://example.com/hook events=signup,withdraw\nwebhook list\nwebhook remove 1",
+
+	"help.config.preset.summary":    "Manage this server's presets for creating events; a preset named like a built-in one replaces it here",
+	"help.config.preset.arg.action": "set, list, or remove",
+	"help.config.preset.arg.args":   "For set, the preset name, roles=, and optionally description=; for remove, the preset name",
+	"help.config.preset.examples":   "preset set prog roles=tank:2:🛡️,healer:3:💚,dps:7:⚔️ description=\"Progression night\"\npreset list\npreset remove prog",
+
+	"help.config.stats.summary":  "Show event counts across every server the bot is in",
+	"help.config.stats.examples": "stats",
+
+	"help.config.version.summary":  "Show the bot version",
+	"help.config.version.examples": "version",
+
+	"help.config.website.summary":  "Show the bot website",
+	"help.config.website.examples": "website",
+
+	"help.config.discord.summary":  "Show the bot support discord",
+	"help.config.discord.examples": "discord",
+
+	"list.closed": "*Closed Trials*",
+	"list.none":   "(none yet)",
+	"list.open":   "*Available Trials*",
+
+	"notice.buttons_server_only": "These buttons only work in a server.",
+	"notice.error":               "Error: %v",
+	"notice.post_failed":         "Could not post in that channel; check the bot's permissions there.",
+	"notice.posted":              "Posted in %s.",
+	"notice.remove_unauthorized": "Only admins can remove signups.",
+	"notice.removed":             "Removed %s from %s.",
+	"notice.server_only":         "These commands only work in a server.",
+	"notice.unauthorized":        "You are not allowed to use that command.",
+	"notice.unknown_command":     "Unknown command; the bot may have been updated since the command list was loaded.",
+	"notice.wrong_channel":       "That command cannot be used in this channel.",
+
+	"notify.current":         "Direct messages about events: %s",
+	"notify.off":             "You get no direct messages.",
+	"notify.on":              "You get a direct message when you sign up, move up from the overflow, an event you signed up for is canceled or moved, and before it starts.",
+	"notify.promotions_only": "You get a direct message only when you move up from the overflow.",
+
+	"presets.builtin": "**Built-in presets**",
+	"presets.guild":   "**This server's presets**",
+	"presets.removed": "Removed preset %s.",
+	"presets.saved":   "Saved preset %s",
+
+	"profile.none":  "You have no profile yet; set one with profile set role=... character=... class=...",
+	"profile.saved": "Profile saved.",
+	"profile.show": `Your profile:
+- Role: %s
+- Character: %s
+- Class: %s
+- Direct messages: %s`,
+
+	"roster.canceled":        "❌ This event is canceled",
+	"roster.canceled_reason": "❌ This event is canceled: %s",
+	"roster.confirm_by":      "🔒 The roster is locked: the main group confirms with `confirm %s` by %s",
+	"roster.confirm_closed":  "🔒 The roster is locked; confirmations closed %s",
+	"roster.confirmed":       "%s ✅",
+	"roster.empty":           "(empty)",
+	"roster.overflow":        "Overflow %s",
+	"roster.pending":         "%s ⏳",
+	"roster.promoted":        "%s ⬆️",
+	"roster.reserved":        "%s 🔒",
+	"roster.unclaimed":       "_%d reserved slot(s) not yet claimed_",
+
+	"signup.done":      "Signed up for %s in %s",
+	"signup.last_only": "(only showing last trial details)",
+	"signup.overflow":  "Signed up as OVERFLOW for %s in %s",
+	"confirm.done":     "Confirmed your place in %s",
+	"withdraw.done":    "Withdrew from %s",
+
+	"state.canceled": "canceled",
+	"state.closed":   "closed",
+	"state.open":     "open",
+
+	"stats.summary": "Total guilds: %d\nTotal events: %d\nCurrently open: %d\nCurrently closed: %d\n",
+
+	"webhook.added":   "Added webhook %s for %s.\nPayloads are signed with HMAC-SHA256 in the `%s` header; the secret was sent to you by direct message.",
+	"webhook.none":    "No webhooks are registered.",
+	"webhook.removed": "Removed webhook %s",
+
+	"settings.guild": `
+GuildSettings:
+
+	- ControlSequence: '%[2]s',
+	- AnnounceChannel: '#%[3]s',
+	- SignupChannel: '#%[4]s',
+	- AdminChannel: '#%[5]s',
+	- AnnounceTo: '%[6]s',
+	- ShowAfterSignup: '%[7]s',
+	- ShowAfterWithdraw: '%[8]s',
+	- AdminRole: '<@&%[9]s>',
+	- Locale: '%[10]s',
+
+	`,
+
+	"trial.lottery_drawn": "%s (drawn with seed %d)",
+	"trial.settings": `
+Event settings:
+
+	- State: '%[4]s',
+	- AnnounceChannel: '#%[1]s',
+	- SignupChannel: '#%[2]s',
+	- AnnounceTo: '%[3]s',
+	- Start: '%[7]s',
+	- Duration: '%[8]s',
+	- ReleaseReserved: '%[9]s',
+	- RosterPolicy: '%[10]s',
+	- Roles:
+		%[5]s
+
+Description:
+%[6]s
+
+	`,
+	"trial.unscheduled": "unscheduled",
+
+	"err.admin_signup_mentions":      "you must mention one or more users that you are trying to sign up (@...)",
+	"err.admin_signup_usage":         "not enough arguments (need `event-name role user-mention(s)`)",
+	"err.admin_withdraw_mentions":    "you must mention one or more users that you are trying to withdraw (@...)",
+	"err.admin_withdraw_usage":       "not enough arguments (need `event-name user-mention(s)`)",
+	"err.alias_conflict":             "'%s' is already a name or alias of the role %s",
+	"err.alias_unknown_role":         "cannot give aliases to '%s', which is not a role of the event",
+	"err.already_locked":             "the roster of %s is already locked (open the event again to unlock it)",
+	"err.ambiguous_trial":            "'%s' could be any of %s; type more of the name",
+	"err.apitoken_argument":          "unknown argument '%s' (did you mean 'revoke'?)",
+	"err.apitoken_undelivered":       "could not send you the new API token by direct message, so the previous token is kept; allow direct messages from this server and try again",
+	"err.bad_aliases":                "could not understand aliases '%s'; use role:alias:alias",
+	"err.bad_argument":               "could not parse '%s' (use key=value, and put quotes around values with spaces)",
+	"err.bad_deadline":               "could not parse deadline '%s' (use a duration like 12h or a future time like 2006-01-02T15:04)",
+	"err.bad_duration":               "could not parse duration '%s' (use something like 90m or 2h)",
+	"err.bad_locale":                 "unsupported locale '%s' (use one of %s)",
+	"err.bad_option_value":           "could not understand option value",
+	"err.bad_reserve":                "could not parse reservation '%s' (need role:slots:@user or @role)",
+	"err.bad_role_count":             "could not parse role count '%s'",
+	"err.bad_roles":                  "could not parse roles",
+	"err.bad_setting":                "bad setting",
+	"err.bad_start":                  "could not parse start time '%s' (use 2006-01-02T15:04 or 2006-01-02 15:04 in UTC, or add an offset like -05:00)",
+	"err.calendar_argument":          "unknown argument '%s' (use me and/or file)",
+	"err.canceled":                   "%s is canceled (open it again to undo that)",
+	"err.confirm_closed":             "the deadline to confirm for %s has passed",
+	"err.guild_not_found":            "guild not found",
+	"err.missing_role":               "missing role",
+	"err.missing_setting":            "missing setting name",
+	"err.need_event_name":            "need event name",
+	"err.need_start":                 "need the new start of the event, like 2006-01-02T15:04",
+	"err.no_settings":                "no settings to save",
+	"err.no_signups_chosen":          "no signups were chosen",
+	"err.not_in_main_group":          "you are not in the main group of %s",
+	"err.not_locked":                 "the roster of %s is not locked, so there is nothing to confirm",
+	"err.notify_choice":              "unknown choice '%s' (need on, off, or promotions-only)",
+	"err.policy_needs_tiers":         "the tiers policy needs tiers=... with the discord roles to put first",
+	"err.preset_action":              "need an action (set, list, or remove)",
+	"err.preset_name_too_long":       "preset names can be at most %d characters",
+	"err.preset_need_name":           "need a preset name",
+	"err.preset_need_roles":          "need roles=... with at least one role",
+	"err.preset_not_found":           "this server has no preset '%s'",
+	"err.profile_action":             "unknown profile action '%s'; use show, set, or clear",
+	"err.profile_role_not_in_trial":  "your profile role %s is not a role of %s; give the role",
+	"err.show_usage":                 "you must supply exactly 1 argument -- event name; are you missing quotes?",
+	"err.signup_arguments":           "incorrect number of arguments",
+	"err.reserve_one_role":           "the reservation of %s can name only one discord role",
+	"err.reserve_too_many":           "cannot reserve more slots of %s than its count of %d",
+	"err.reserve_unknown_role":       "cannot reserve slots of '%s', which is not a role of the event",
+	"err.signup_closed":              "cannot sign up for a closed trial",
+	"err.signup_detail_too_long":     "%s can be at most %d characters long",
+	"err.too_many_arguments":         "too many arguments",
+	"err.too_many_presets":           "a guild can have at most %d presets",
+	"err.too_many_webhooks":          "a guild can have at most %d webhooks",
+	"err.trial_not_exist":            "trial does not exist",
+	"err.trial_not_exist_suggest":    "there is no event '%s'; did you mean %s?",
+	"err.unknown_guild_role":         "could not find role with name '%s'",
+	"err.unknown_help_topic":         "there is no command '%s' that you can run",
+	"err.unknown_channel":            "there is no channel named '#%s'",
+	"err.unknown_channel_mention":    "%s is not a channel of this server",
+	"err.unknown_preset":             "there is no preset '%s'; see presets",
+	"err.unknown_preset_suggest":     "there is no preset '%s'; did you mean %s?",
+	"err.unknown_policy":             "unknown roster policy '%s' (need first-come, tiers, attendance, or lottery)",
+	"err.unknown_profile_field":      "unknown profile setting '%s'; use role, character, or class",
+	"err.unknown_role":               "unknown role",
+	"err.unknown_role_mention":       "%s is not a role of this server",
+	"err.unknown_role_suggest":       "unknown role '%s'; did you mean %s?",
+	"err.unknown_setting":            "'%s' is not the name of a setting",
+	"err.unmatched_quote":            "the quote at character %d is never closed: %s (close it, or write \\\" for a literal quote)",
+	"err.webhook_action":             "need an action (add, list, or remove)",
+	"err.webhook_address_host":       "'%s' is an ip address; webhooks need a host name",
+	"err.webhook_exists":             "that webhook url is already registered",
+	"err.webhook_need_remove":        "need the webhook url or number to remove",
+	"err.webhook_need_url":           "need a webhook url",
+	"err.webhook_not_found":          "webhook not found",
+	"err.webhook_private_host":       "'%s' is a private or local address; webhooks can only be sent to public hosts",
+	"err.webhook_secret_undelivered": "could not send you the signing secret of %s by direct message, so the webhook was not added; allow direct messages from this server and add it again",
+	"err.webhook_unknown_action":     "unknown action '%s' (need add, list, or remove)",
+	"err.webhook_unresolved_host":    "could not resolve the webhook host '%s'",
+	"err.webhook_url":                "webhook url must be an absolute https url with a host name",
+	"err.withdraw_closed":            "cannot withdraw from a closed trial",
+}
@@ -0,0 +1,403 @@
+package i18n
+
+var fr = catalog{
+	"admin.canceled":        "%s a été annulé.",
+	"admin.canceled_reason": "%s a été annulé : %s",
+	"admin.cleared":         "Événement %q vidé avec succès",
+	"admin.closed":          "Événement %q fermé",
+	"admin.created":         "Événement %q créé avec succès",
+	"admin.deleted":         "Événement %q supprimé",
+	"admin.edited":          "Événement %q modifié avec succès",
+	"admin.grouping":        "Regroupement maintenant pour %s !",
+	"admin.locked":          "Liste de %q verrouillée. Le groupe principal doit confirmer avant %s avec `confirm %s` ou le bouton Confirmer sous la liste, sinon la liste d'attente prend leur place.",
+	"admin.main_group":      "**Groupe principal :** %s",
+	"admin.opened":          "Événement %q ouvert",
+	"admin.overflow":        "**Liste d'attente :** %s",
+	"admin.rescheduled":     "%s a été déplacé au %s. Si vous ne pouvez plus venir, désinscrivez-vous avec `withdraw %s`.",
+	"admin.signed_up":       "Inscrit pour %s dans %s par %s",
+	"admin.signups":         "Inscriptions :",
+	"admin.withdrawn":       "Désinscrit de %s par %s",
+
+	"announce.roles":          "Rôles recherchés",
+	"announce.signup_channel": "Salon d'inscription",
+	"announce.title":          "Les inscriptions sont ouvertes pour %s",
+
+	"apitoken.created": "Un nouveau jeton d'API remplace tout jeton précédent ; il vous a été envoyé en message privé.",
+	"apitoken.revoked": "Le jeton d'API a été révoqué.",
+
+	"button.confirm":  "Confirmer",
+	"button.withdraw": "Se désinscrire",
+	"menu.remove":     "Retirer des inscriptions (admins uniquement)",
+
+	"calendar.file":       "Calendrier avec %d événement(s) planifié(s) ; ouvrez la pièce jointe pour l'importer.",
+	"calendar.guild_feed": "Abonnez-vous aux événements planifiés de ce serveur dans votre application de calendrier :\n%s",
+	"calendar.guild_name": "Événements Discord",
+	"calendar.user_feed":  "Abonnez-vous aux événements auxquels vous êtes inscrit dans votre application de calendrier :\n%s",
+	"calendar.user_name":  "Mes inscriptions Discord",
+
+	"doctor.admin_role":              "adminrole : <@&%s>",
+	"doctor.channel_missing":         "%s : il n'y a pas de salon #%s",
+	"doctor.ok":                      "Aucun problème trouvé.",
+	"doctor.problems":                "**Problèmes :**",
+	"doctor.role_missing":            "adminrole : aucun rôle ne s'appelle plus '%s' (s'il a été renommé ou supprimé, définissez adminrole à nouveau)",
+	"doctor.roles":                   "**Rôles gardés par id** (ceux affichés comme @deleted-role n'existent plus) :",
+	"doctor.trial_channel_missing":   "événement %q, %s : il n'y a pas de salon #%s",
+	"doctor.trial_no_signup_channel": "l'événement %q n'a pas de signupchannel, donc personne ne peut s'y inscrire",
+	"doctor.trial_reserve_role":      "événement %q, %s : des places sont réservées à <@&%s>",
+	"doctor.trial_tier_role":         "événement %q, tiers : <@&%s>",
+
+	"dm.apitoken":       "Nouveau jeton pour l'API des événements du serveur %s (il remplace tout jeton précédent et ne sera plus affiché) :\n```\n%s\n```\nEnvoyez-le sous la forme `Authorization: Bearer <token>`.",
+	"dm.canceled":       "%s a été annulé.",
+	"dm.promoted":       "Vous êtes passé de la liste d'attente au groupe principal de %s en tant que %s.",
+	"dm.reminder":       "Rappel : %s commence le %s ; vous êtes dans le groupe principal en tant que %s.",
+	"dm.webhook_secret": "Les payloads envoyés au webhook %s sont signés avec HMAC-SHA256 dans l'en-tête `%s` à l'aide de ce secret (il ne sera plus affiché) :\n```\n%s\n```",
+
+	"export.done": "Paramètres et %d événement(s) exportés (version de format %d).",
+
+	"help.user":          "Commandes",
+	"help.admin":         "Commandes d'administration",
+	"help.config":        "Commandes de paramétrage",
+	"help.more":          "Utilisez %s <commande> pour les détails et exemples d'une commande.",
+	"help.arguments":     "Arguments",
+	"help.examples":      "Exemples",
+	"help.aliases":       "Aussi :",
+	"help.permission":    "Qui peut l'utiliser :",
+	"help.perm.everyone": "Tout le monde ; les commandes d'événement répondent dans le salon d'inscription de l'événement",
+	"help.perm.admin":    "Les admins du bot (admins du serveur et adminrole), dans le salon d'administration",
+	"help.perm.config":   "Les admins du bot (admins du serveur et adminrole), dans n'importe quel salon",
+
+	"help.user.list.summary":  "Lister les événements ouverts et fermés de ce serveur",
+	"help.user.list.examples": "list",
+
+	"help.user.show.summary":   "Afficher la liste d'un événement",
+	"help.user.show.arg.event": "Le nom de l'événement, ou son début (noms avec espaces entre guillemets)",
+	"help.user.show.examples":  "show vAA\nshow \"Vet AA\"",
+
+	"help.user.signup.summary":     "S'inscrire à un événement dans un rôle",
+	"help.user.signup.arg.event":   "Le nom de l'événement",
+	"help.user.signup.arg.role":    "Un des rôles de l'événement, ou un de ses alias ; le rôle de votre profil s'il est omis",
+	"help.user.signup.arg.details": "character=, class= et note= pour dire ce que vous apportez (une valeur vide en efface une) ; un note: final prend le reste du message",
+	"help.user.signup.examples":    "signup vAA tank\nsignup vAA\nsu \"Vet AA\" dps\nsignup vAA dps character=Zyra class=nb note=\"10 min de retard\"\nsu vAA healer note: peut-être en retard",
+
+	"help.user.withdraw.summary":   "Se désinscrire d'un événement",
+	"help.user.withdraw.arg.event": "Le nom de l'événement",
+	"help.user.withdraw.examples":  "withdraw vAA\nwd vAA",
+
+	"help.user.confirm.summary":   "Confirmer votre place dans le groupe principal d'un événement verrouillé avant l'échéance ; ceux qui ne le font pas sont remplacés depuis la liste d'attente",
+	"help.user.confirm.arg.event": "Le nom de l'événement",
+	"help.user.confirm.examples":  "confirm vAA",
+
+	"help.user.profile.summary":      "Afficher ou changer le rôle, le personnage et la classe avec lesquels vous vous inscrivez d'habitude ; une inscription sans rôle utilise le rôle du profil, et le personnage et la classe sont ajoutés à vos inscriptions",
+	"help.user.profile.arg.action":   "show (par défaut), set ou clear",
+	"help.user.profile.arg.settings": "Pour set, des réglages key=value : role, character (ou char) et class ; une valeur vide en efface un",
+	"help.user.profile.examples":     "profile\nprofile set role=healer char=\"Lady Heals\" class=templar\nprofile set class=\nprofile clear",
+
+	"help.user.notify.summary":    "Afficher ou choisir les messages privés que vous recevez sur les événements de ce serveur : confirmations d'inscription, passage depuis la liste d'attente, annulations et nouvelles heures de début, et rappels avant le début",
+	"help.user.notify.arg.choice": "on, off (par défaut) ou promotions-only pour n'être prévenu que du passage depuis la liste d'attente ; si vous n'acceptez pas les messages privés, ils sont publiés dans le salon d'inscription à la place",
+	"help.user.notify.examples":   "notify\nnotify on\nnotify promotions-only",
+
+	"help.user.presets.summary":    "Lister les répartitions de rôles à partir desquelles créer des événements, intégrées pour chaque épreuve et arène d'ESO ou ajoutées pour ce serveur",
+	"help.user.presets.arg.preset": "Afficher seulement ce modèle",
+	"help.user.presets.examples":   "presets\npresets vss-hm",
+
+	"help.user.calendar.summary":  "Donner le lien du flux de calendrier des événements planifiés de ce serveur",
+	"help.user.calendar.arg.me":   "Seulement les événements auxquels vous êtes inscrit",
+	"help.user.calendar.arg.file": "Joindre un fichier .ics au lieu du lien vers le flux",
+	"help.user.calendar.examples": "calendar\ncalendar me file",
+
+	"help.user.help.summary":     "Afficher les commandes que vous pouvez lancer, ou le détail de l'une d'elles",
+	"help.user.help.arg.command": "Une commande, comme signup ou admin create",
+	"help.user.help.examples":    "help\nhelp signup\nhelp admin create",
+
+	"help.admin.list.summary":  "Lister les événements ouverts et fermés avec leurs salons d'inscription",
+	"help.admin.list.examples": "list",
+
+	"help.admin.show.summary":   "Afficher un événement avec ses réglages",
+	"help.admin.show.arg.event": "Le nom de l'événement",
+	"help.admin.show.examples":  "show vAA",
+
+	"help.admin.create.summary":      "Créer un événement, ouvert aux inscriptions",
+	"help.admin.create.arg.event":    "Le nom de l'événement",
+	"help.admin.create.arg.settings": "réglages key=value : preset (une répartition de presets, dont les autres réglages modifient les rôles et la description), description, roles (rôle:nombre ou rôle:nombre:emoji, séparés par des virgules), aliases (autres noms pour s'inscrire, comme dps:dd:damage,healer:heal), reserve (places attribuées d'abord aux membres cités ou à un rôle discord, comme dps:2:@Core), release (combien de temps avant le début les places réservées non prises s'ouvrent à tous, comme 24h), policy (qui obtient les places quand un rôle est plein : first-come, tiers, attendance aux événements passés, ou lottery tirée à la fermeture des inscriptions), tiers (pour la politique tiers, les rôles discord du premier au dernier, comme @Core,@Raider), announcechannel, signupchannel, announceto, start (comme 2006-01-02T15:04, en UTC sauf si un décalage est donné) et duration (comme 2h) ; un description: final prend le reste du message",
+	"help.admin.create.examples":     "create vAA roles=tank:2,healer:2,dps:8 aliases=dps:dd:damage signupchannel=#signups description: Vet AA hardmode, apportez à manger\ncreate \"Vet AA\" start=2026-11-01T20:00+01:00 duration=2h roles=tank:2,healer:2,dps:8\ncreate sunspire preset=vss-hm roles=dps:9,healer:1\ncreate prog preset=vrg-hm reserve=dps:2:@Core,tank:1:@Zyra release=24h start=2026-11-01T20:00\ncreate vDSR preset=vdsr policy=tiers tiers=@Core,@Raider",
+
+	"help.admin.edit.summary":      "Changer les réglages d'un événement",
+	"help.admin.edit.arg.event":    "Le nom de l'événement",
+	"help.admin.edit.arg.settings": "réglages key=value, comme pour create ; un rôle avec le nombre 0 est retiré, un rôle listé dans aliases sans alias perd ses alias, et un rôle avec 0 place réservée perd sa réservation ; changer la politique d'un événement fermé réordonne sa liste",
+	"help.admin.edit.examples":     "edit vAA roles=dps:10,healer:0 aliases=tank:\nedit vAA description=\"Même endroit, nouvelle heure\" start=2026-11-02T20:00",
+
+	"help.admin.open.summary":   "Ouvrir un événement aux inscriptions",
+	"help.admin.open.arg.event": "Le nom de l'événement",
+	"help.admin.open.examples":  "open vAA",
+
+	"help.admin.close.summary":   "Fermer un événement aux inscriptions",
+	"help.admin.close.arg.event": "Le nom de l'événement",
+	"help.admin.close.examples":  "close vAA",
+
+	"help.admin.lock.summary":      "Fermer un événement et figer sa liste : le groupe principal est mentionné dans le salon d'annonce pour confirmer avant l'échéance, et la liste d'attente remplace ceux qui ne le font pas ; rouvrez l'événement pour le déverrouiller",
+	"help.admin.lock.arg.event":    "Le nom de l'événement",
+	"help.admin.lock.arg.deadline": "Combien de temps les membres ont pour confirmer (comme 12h) ou jusqu'à quand (comme 2006-01-02T15:04) ; par défaut un jour, ou jusqu'au début si c'est plus tôt",
+	"help.admin.lock.examples":     "lock vAA\nlock vAA 6h\nlock vAA 2026-11-01T18:00",
+
+	"help.admin.cancel.summary":    "Annuler un événement sans le supprimer : les membres inscrits sont mentionnés dans le salon d'annonce et les inscriptions sont conservées ; rouvrez l'événement pour revenir en arrière",
+	"help.admin.cancel.arg.event":  "Le nom de l'événement",
+	"help.admin.cancel.arg.reason": "Pourquoi l'événement est annulé, affiché sur la liste et dans la mention ; un message: final prend le reste du message tel quel",
+	"help.admin.cancel.examples":   "cancel vAA\ncancel vAA Pas assez de soigneurs cette semaine",
+
+	"help.admin.reschedule.summary":   "Déplacer le début d'un événement en gardant ses inscriptions : les membres inscrits sont mentionnés dans le salon d'annonce et peuvent se désinscrire même si les inscriptions sont fermées, jusqu'à ce que l'événement soit de nouveau fermé",
+	"help.admin.reschedule.arg.event": "Le nom de l'événement",
+	"help.admin.reschedule.arg.start": "Le nouveau début, comme 2006-01-02T15:04 ou 2006-01-02 15:04 (UTC), éventuellement avec un décalage",
+	"help.admin.reschedule.examples":  "reschedule vAA 2026-11-02T19:00\nreschedule vAA 2026-11-02 20:00 +01:00",
+
+	"help.admin.delete.summary":   "Supprimer un événement",
+	"help.admin.delete.arg.event": "Le nom complet de l'événement",
+	"help.admin.delete.examples":  "delete vAA",
+
+	"help.admin.clear.summary":   "Retirer toutes les inscriptions d'un événement",
+	"help.admin.clear.arg.event": "Le nom complet de l'événement",
+	"help.admin.clear.examples":  "clear vAA",
+
+	"help.admin.announce.summary":     "Annoncer un événement dans son salon d'annonce",
+	"help.admin.announce.arg.event":   "Le nom de l'événement",
+	"help.admin.announce.arg.message": "Texte à ajouter à l'annonce ; un message: final prend le reste du message tel quel",
+	"help.admin.announce.examples":    "announce vAA\nannounce vAA message: Apportez à manger, on commence à l'heure",
+
+	"help.admin.grouping.summary":     "Appeler les membres inscrits à un événement à former le groupe",
+	"help.admin.grouping.arg.event":   "Le nom de l'événement",
+	"help.admin.grouping.arg.message": "Texte à publier à la place du texte par défaut ; un message: final prend le reste du message tel quel",
+	"help.admin.grouping.examples":    "grouping vAA\ngrouping vAA Les invitations partent maintenant",
+
+	"help.admin.signup.summary":     "Inscrire d'autres membres à un événement",
+	"help.admin.signup.arg.event":   "Le nom de l'événement",
+	"help.admin.signup.arg.role":    "Un des rôles de l'événement",
+	"help.admin.signup.arg.members": "Mentions des membres à inscrire",
+	"help.admin.signup.examples":    "signup vAA tank @Someone @Someone-else",
+
+	"help.admin.withdraw.summary":     "Désinscrire d'autres membres d'un événement",
+	"help.admin.withdraw.arg.event":   "Le nom de l'événement",
+	"help.admin.withdraw.arg.members": "Mentions des membres à désinscrire",
+	"help.admin.withdraw.examples":    "withdraw vAA @Someone",
+
+	"help.config.list.summary":  "Afficher les réglages de ce serveur, et les salons ou rôles qui n'existent plus",
+	"help.config.list.examples": "list",
+
+	"help.config.get.summary":     "Afficher un réglage",
+	"help.config.get.arg.setting": "Le nom du réglage",
+	"help.config.get.examples":    "get signupchannel",
+
+	"help.config.set.summary":      "Changer des réglages",
+	"help.config.set.arg.settings": "réglages key=value : controlsequence, announcechannel, signupchannel, adminchannel (mentions #salon ou noms), announceto, showaftersignup, showafterwithdraw, adminrole (une mention @rôle, un id ou un nom ; vide pour l'effacer) et locale",
+	"help.config.set.examples":     "set signupchannel=#signups adminchannel=#officers\nset adminrole=\"Raid Lead\" locale=fr",
+
+	"help.config.reset.summary":  "Remettre tous les réglages à leur valeur par défaut",
+	"help.config.reset.examples": "reset",
+
+	"help.config.doctor.summary":  "Vérifier les réglages et chaque événement à la recherche de salons et rôles qui n'existent plus",
+	"help.config.doctor.examples": "doctor",
+
+	"help.config.export.summary":  "Exporter les réglages et événements de ce serveur dans un fichier JSON",
+	"help.config.export.examples": "export",
+
+	"help.config.apitoken.summary":    "Créer un jeton pour l'API des événements, qui remplace le précédent",
+	"help.config.apitoken.arg.revoke": "Révoquer le jeton à la place",
+	"help.config.apitoken.examples":   "apitoken\napitoken revoke",
+
+	"help.config.webhook.summary":    "Gérer les webhooks auxquels sont envoyés les changements des événements",
+	"help.config.webhook.arg.action": "add, list ou remove",
+	"help.config.webhook.arg.args":   "Pour add, l'url https publique et éventuellement events=signup,withdraw,open,close,create,delete,cancel,reschedule ; pour remove, l'url ou son numéro dans la liste",
+	"help.config.webhook.examples":   "webhook add https://example.com/hook events=signup,withdraw\nwebhook list\nwebhook remove 1",
+
+	"help.config.preset.summary":    "Gérer les modèles de ce serveur pour créer des événements ; un modèle nommé comme un modèle intégré le remplace ici",
+	"help.config.preset.arg.action": "set, list ou remove",
+	"help.config.preset.arg.args":   "Pour set, le nom du modèle, roles= et éventuellement description= ; pour remove, le nom du modèle",
+	"help.config.preset.examples":   "preset set prog roles=tank:2:🛡️,healer:3:💚,dps:7:⚔️ description=\"Soirée progression\"\npreset list\npreset remove prog",
+
+	"help.config.stats.summary":  "Afficher le nombre d'événements sur tous les serveurs du bot",
+	"help.config.stats.examples": "stats",
+
+	"help.config.version.summary":  "Afficher la version du bot",
+	"help.config.version.examples": "version",
+
+	"help.config.website.summary":  "Afficher le site du bot",
+	"help.config.website.examples": "website",
+
+	"help.config.discord.summary":  "Afficher le discord d'assistance du bot",
+	"help.config.discord.examples": "discord",
+
+	"list.closed": "*Trials fermés*",
+	"list.none":   "(aucun pour l'instant)",
+	"list.open":   "*Trials disponibles*",
+
+	"notice.buttons_server_only": "Ces boutons ne fonctionnent que sur un serveur.",
+	"notice.error":               "Erreur : %v",
+	"notice.post_failed":         "Impossible de publier dans ce salon ; vérifiez les permissions du bot.",
+	"notice.posted":              "Publié dans %s.",
+	"notice.remove_unauthorized": "Seuls les admins peuvent retirer des inscriptions.",
+	"notice.removed":             "%s retiré(s) de %s.",
+	"notice.server_only":         "Ces commandes ne fonctionnent que sur un serveur.",
+	"notice.unauthorized":        "Vous n'êtes pas autorisé à utiliser cette commande.",
+	"notice.unknown_command":     "Commande inconnue ; le bot a peut-être été mis à jour depuis le chargement de la liste des commandes.",
+	"notice.wrong_channel":       "Cette commande ne peut pas être utilisée dans ce salon.",
+
+	"notify.current":         "Messages privés sur les événements : %s",
+	"notify.off":             "Vous ne recevez aucun message privé.",
+	"notify.on":              "Vous recevez un message privé quand vous vous inscrivez, passez de la liste d'attente au groupe principal, quand un événement auquel vous êtes inscrit est annulé ou déplacé, et avant qu'il commence.",
+	"notify.promotions_only": "Vous recevez un message privé seulement quand vous passez de la liste d'attente au groupe principal.",
+
+	"presets.builtin": "**Modèles intégrés**",
+	"presets.guild":   "**Modèles de ce serveur**",
+	"presets.removed": "Modèle %s supprimé.",
+	"presets.saved":   "Modèle enregistré : %s",
+
+	"profile.none":  "Vous n'avez pas encore de profil ; créez-le avec profile set role=... character=... class=...",
+	"profile.saved": "Profil enregistré.",
+	"profile.show": `Votre profil :
+- Rôle : %s
+- Personnage : %s
+- Classe : %s
+- Messages privés : %s`,
+
+	"roster.canceled":        "❌ Cet événement est annulé",
+	"roster.canceled_reason": "❌ Cet événement est annulé : %s",
+	"roster.confirm_by":      "🔒 La liste est verrouillée : le groupe principal confirme avec `confirm %s` avant %s",
+	"roster.confirm_closed":  "🔒 La liste est verrouillée ; les confirmations ont fermé %s",
+	"roster.confirmed":       "%s ✅",
+	"roster.empty":           "(vide)",
+	"roster.overflow":        "Liste d'attente %s",
+	"roster.pending":         "%s ⏳",
+	"roster.promoted":        "%s ⬆️",
+	"roster.reserved":        "%s 🔒",
+	"roster.unclaimed":       "_%d place(s) réservée(s) pas encore prise(s)_",
+
+	"signup.done":      "Inscrit pour %s dans %s",
+	"signup.last_only": "(seuls les détails du dernier trial sont affichés)",
+	"signup.overflow":  "Inscrit en LISTE D'ATTENTE pour %s dans %s",
+	"confirm.done":     "Place confirmée pour %s",
+	"withdraw.done":    "Désinscrit de %s",
+
+	"state.canceled": "annulé",
+	"state.closed":   "fermé",
+	"state.open":     "ouvert",
+
+	"stats.summary": "Serveurs au total : %d\nÉvénements au total : %d\nActuellement ouverts : %d\nActuellement fermés : %d\n",
+
+	"webhook.added":   "Webhook %s ajouté pour %s.\nLes payloads sont signés avec HMAC-SHA256 dans l'en-tête `%s` ; le secret vous a été envoyé en message privé.",
+	"webhook.none":    "Aucun webhook n'est enregistré.",
+	"webhook.removed": "Webhook %s supprimé",
+
+	"settings.guild": `
+Paramètres du serveur :
+
+	- ControlSequence: '%[2]s',
+	- AnnounceChannel: '#%[3]s',
+	- SignupChannel: '#%[4]s',
+	- AdminChannel: '#%[5]s',
+	- AnnounceTo: '%[6]s',
+	- ShowAfterSignup: '%[7]s',
+	- ShowAfterWithdraw: '%[8]s',
+	- AdminRole: '<@&%[9]s>',
+	- Locale: '%[10]s',
+
+	`,
+
+	"trial.lottery_drawn": "%s (tiré avec la graine %d)",
+	"trial.settings": `
+Paramètres de l'événement :
+
+	- État : '%[4]s',
+	- Salon d'annonce : '#%[1]s',
+	- Salon d'inscription : '#%[2]s',
+	- Annoncer à : '%[3]s',
+	- Début : '%[7]s',
+	- Durée : '%[8]s',
+	- Libération des réservations : '%[9]s',
+	- Ordre de la liste : '%[10]s',
+	- Rôles :
+		%[5]s
+
+Description :
+%[6]s
+
+	`,
+	"trial.unscheduled": "non planifié",
+
+	"err.admin_signup_mentions":      "vous devez mentionner un ou plusieurs utilisateurs à inscrire (@...)",
+	"err.admin_signup_usage":         "arguments insuffisants (il faut `nom-événement rôle mention(s)-utilisateur`)",
+	"err.admin_withdraw_mentions":    "vous devez mentionner un ou plusieurs utilisateurs à désinscrire (@...)",
+	"err.admin_withdraw_usage":       "arguments insuffisants (il faut `nom-événement mention(s)-utilisateur`)",
+	"err.alias_conflict":             "'%s' est déjà un nom ou un alias du rôle %s",
+	"err.alias_unknown_role":         "impossible de donner des alias à '%s', qui n'est pas un rôle de l'événement",
+	"err.already_locked":             "la liste de %s est déjà verrouillée (rouvrez l'événement pour la déverrouiller)",
+	"err.ambiguous_trial":            "'%s' peut désigner %s ; tapez davantage du nom",
+	"err.apitoken_argument":          "argument inconnu '%s' (vouliez-vous dire 'revoke' ?)",
+	"err.apitoken_undelivered":       "impossible de vous envoyer le nouveau jeton d'API en message privé, le jeton précédent est donc conservé ; autorisez les messages privés de ce serveur et réessayez",
+	"err.bad_aliases":                "alias '%s' incompréhensibles ; utilisez rôle:alias:alias",
+	"err.bad_argument":               "impossible de lire '%s' (utilisez clé=valeur, et mettez entre guillemets les valeurs avec des espaces)",
+	"err.bad_deadline":               "impossible de lire l'échéance '%s' (utilisez une durée comme 12h ou une date future comme 2006-01-02T15:04)",
+	"err.bad_duration":               "impossible de lire la durée '%s' (utilisez par exemple 90m ou 2h)",
+	"err.bad_locale":                 "langue non prise en charge '%s' (utilisez l'une de %s)",
+	"err.bad_option_value":           "valeur d'option non comprise",
+	"err.bad_reserve":                "impossible de lire la réservation '%s' (il faut rôle:places:@membre ou @rôle)",
+	"err.bad_role_count":             "impossible de lire le nombre '%s' pour le rôle",
+	"err.bad_roles":                  "impossible de lire les rôles",
+	"err.bad_setting":                "paramètre invalide",
+	"err.bad_start":                  "impossible de lire l'heure de début '%s' (utilisez 2006-01-02T15:04 ou 2006-01-02 15:04 en UTC, ou ajoutez un décalage comme +01:00)",
+	"err.calendar_argument":          "argument inconnu '%s' (utilisez me et/ou file)",
+	"err.canceled":                   "%s est annulé (ouvrez-le à nouveau pour annuler cela)",
+	"err.confirm_closed":             "l'échéance pour confirmer %s est passée",
+	"err.guild_not_found":            "serveur introuvable",
+	"err.missing_role":               "rôle manquant",
+	"err.missing_setting":            "nom du paramètre manquant",
+	"err.need_event_name":            "nom de l'événement requis",
+	"err.need_start":                 "nouveau début de l'événement requis, comme 2006-01-02T15:04",
+	"err.no_settings":                "aucun paramètre à enregistrer",
+	"err.no_signups_chosen":          "aucune inscription n'a été choisie",
+	"err.not_in_main_group":          "vous n'êtes pas dans le groupe principal de %s",
+	"err.not_locked":                 "la liste de %s n'est pas verrouillée, il n'y a rien à confirmer",
+	"err.notify_choice":              "choix inconnu '%s' (choisissez on, off ou promotions-only)",
+	"err.policy_needs_tiers":         "la règle tiers a besoin de tiers=... avec les rôles Discord à placer en premier",
+	"err.preset_action":              "action requise (set, list ou remove)",
+	"err.preset_name_too_long":       "les noms de modèle ont au plus %d caractères",
+	"err.preset_need_name":           "nom de modèle requis",
+	"err.preset_need_roles":          "roles=... avec au moins un rôle requis",
+	"err.preset_not_found":           "ce serveur n'a pas de modèle '%s'",
+	"err.profile_action":             "action de profil inconnue '%s' ; utilisez show, set ou clear",
+	"err.profile_role_not_in_trial":  "le rôle de votre profil %s n'est pas un rôle de %s ; indiquez le rôle",
+	"err.show_usage":                 "vous devez fournir exactement 1 argument -- le nom de l'événement ; manque-t-il des guillemets ?",
+	"err.signup_arguments":           "nombre d'arguments incorrect",
+	"err.reserve_one_role":           "la réservation de %s ne peut nommer qu'un seul rôle Discord",
+	"err.reserve_too_many":           "impossible de réserver plus de places de %s que son nombre de %d",
+	"err.reserve_unknown_role":       "impossible de réserver des places de '%s', qui n'est pas un rôle de l'événement",
+	"err.signup_closed":              "impossible de s'inscrire à un trial fermé",
+	"err.signup_detail_too_long":     "%s ne peut dépasser %d caractères",
+	"err.too_many_arguments":         "trop d'arguments",
+	"err.too_many_presets":           "un serveur peut avoir au plus %d modèles",
+	"err.too_many_webhooks":          "un serveur peut avoir au plus %d webhooks",
+	"err.trial_not_exist":            "le trial n'existe pas",
+	"err.trial_not_exist_suggest":    "aucun événement '%s' ; vouliez-vous dire %s ?",
+	"err.unknown_guild_role":         "aucun rôle nommé '%s'",
+	"err.unknown_help_topic":         "aucune commande '%s' que vous pouvez utiliser",
+	"err.unknown_channel":            "il n'y a pas de salon nommé '#%s'",
+	"err.unknown_channel_mention":    "%s n'est pas un salon de ce serveur",
+	"err.unknown_preset":             "aucun modèle '%s' ; voir presets",
+	"err.unknown_preset_suggest":     "aucun modèle '%s' ; vouliez-vous dire %s ?",
+	"err.unknown_policy":             "règle de liste inconnue '%s' (il faut first-come, tiers, attendance ou lottery)",
+	"err.unknown_profile_field":      "paramètre de profil inconnu '%s' ; utilisez role, character ou class",
+	"err.unknown_role":               "rôle inconnu",
+	"err.unknown_role_mention":       "%s n'est pas un rôle de ce serveur",
+	"err.unknown_role_suggest":       "rôle inconnu '%s' ; vouliez-vous dire %s ?",
+	"err.unknown_setting":            "'%s' n'est pas le nom d'un paramètre",
+	"err.unmatched_quote":            "le guillemet au caractère %d n'est jamais fermé : %s (fermez-le, ou écrivez \\\" pour un guillemet littéral)",
+	"err.webhook_action":             "action requise (add, list ou remove)",
+	"err.webhook_address_host":       "'%s' est une adresse IP ; les webhooks ont besoin d'un nom d'hôte",
+	"err.webhook_exists":             "cette URL de webhook est déjà enregistrée",
+	"err.webhook_need_remove":        "l'URL ou le numéro du webhook à retirer est requis",
+	"err.webhook_need_url":           "URL de webhook requise",
+	"err.webhook_not_found":          "webhook introuvable",
+	"err.webhook_private_host":       "'%s' est une adresse privée ou locale ; les webhooks ne peuvent être envoyés qu'à des hôtes publics",
+	"err.webhook_secret_undelivered": "impossible de vous envoyer le secret de signature de %s en message privé, le webhook n'a donc pas été ajouté ; autorisez les messages privés de ce serveur et ajoutez-le à nouveau",
+	"err.webhook_unknown_action":     "action inconnue '%s' (il faut add, list ou remove)",
+	"err.webhook_unresolved_host":    "impossible de résoudre l'hôte du webhook '%s'",
+	"err.webhook_url":                "l'URL du webhook doit être une URL https absolue avec un nom d'hôte",
+	"err.withdraw_closed":            "impossible de se désinscrire d'un trial fermé",
+}
@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// DefaultLocale is the locale of guilds that have not chosen one, and the fallback
+// for messages missing from another catalog
+const DefaultLocale = "en"
+
+type catalog map[string]string
+
+var catalogs = map[string]catalog{
+	"en": en,
+	"de": de,
+	"fr": fr,
+}
+
+// Locales lists the supported locales
+func Locales() []string {
+	l := make([]string, 0, len(catalogs))
+	for k := range catalogs {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// ParseLocale normalizes a locale setting ("DE", "de-AT" and "de_AT" are all "de"), and
+// reports whether it is supported
+func ParseLocale(val string) (string, bool) {
+	val = strings.ToLower(strings.TrimSpace(val))
+	if i := strings.IndexAny(val, "-_"); i >= 0 {
+		val = val[:i]
+	}
+
+	_, ok := catalogs[val]
+	return val, ok
+}
+
+// Printer formats the messages of one locale
+type Printer struct {
+	locale string
+	c      catalog
+}
+
+// For returns the Printer for a locale, falling back to DefaultLocale if it is unset
+// or not supported
+func For(locale string) Printer {
+	locale, ok := ParseLocale(locale)
+	if !ok {
+		locale = DefaultLocale
+	}
+
+	return Printer{locale: locale, c: catalogs[locale]}
+}
+
+// Locale is the locale the Printer formats messages in
+func (p Printer) Locale() string {
+	return p.locale
+}
+
+// Sprintf formats the message for key with fmt.Sprintf
+func (p Printer) Sprintf(key string, args ...interface{}) string {
+	format, ok := p.c[key]
+	if !ok {
+		format, ok = en[key]
+	}
+
+	if !ok {
+		return key
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+// LocalizeError translates err if it is (or wraps) an Error; other errors are returned
+// unchanged
+func (p Printer) LocalizeError(err error) error {
+	for e := err; e != nil; {
+		if le, ok := e.(*Error); ok {
+			return errors.New(p.Sprintf(le.key, le.args...))
+		}
+
+		e2, ok := e.(errors.Error)
+		if !ok {
+			break
+		}
+		e = e2.Cause()
+	}
+
+	return err
+}
+
+// Error is an error whose message comes from the catalog, so that it can be shown to
+// users in their guild's locale
+//
+// Error() gives the English message, for logs and for comparisons against sentinels
+type Error struct {
+	key  string
+	args []interface{}
+}
+
+// NewError creates an Error for the message key, formatted with args
+func NewError(key string, args ...interface{}) error {
+	return &Error{key: key, args: args}
+}
+
+// Key is the catalog key of the error message
+func (e *Error) Key() string {
+	return e.key
+}
+
+func (e *Error) Error() string {
+	return For(DefaultLocale).Sprintf(e.key, e.args...)
+}
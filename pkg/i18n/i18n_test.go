@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+var verbRE = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// verbs lists the formatting directives of a message, so that a translation cannot
+// drop an argument or change its type
+func verbs(format string) []string {
+	v := verbRE.FindAllString(format, -1)
+	sort.Strings(v)
+	return v
+}
+
+func TestCatalogsHaveEveryKey(t *testing.T) {
+	for name, c := range catalogs {
+		for key := range en {
+			if _, ok := c[key]; !ok {
+				t.Errorf("catalog %q is missing key %q", name, key)
+			}
+		}
+
+		for key := range c {
+			if _, ok := en[key]; !ok {
+				t.Errorf("catalog %q has key %q that is not in the default catalog", name, key)
+			}
+		}
+	}
+}
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	for name, c := range catalogs {
+		for key, format := range c {
+			if want, got := verbs(en[key]), verbs(format); !reflect.DeepEqual(want, got) {
+				t.Errorf("catalog %q key %q has verbs %v, want %v", name, key, got, want)
+			}
+		}
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"de", "de", true},
+		{"FR", "fr", true},
+		{"de-AT", "de", true},
+		{"en_GB", "en", true},
+		{"es", "es", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseLocale(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLocale(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	err := NewError("err.bad_duration", "soon")
+
+	if got, want := err.Error(), "could not parse duration 'soon' (use something like 90m or 2h)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got, want := For("de").LocalizeError(err).Error(), "Dauer 'soon' konnte nicht gelesen werden (verwende etwa 90m oder 2h)"; got != want {
+		t.Errorf("LocalizeError() = %q, want %q", got, want)
+	}
+
+	if got := For("xx").Locale(); got != DefaultLocale {
+		t.Errorf("For(\"xx\").Locale() = %q, want %q", got, DefaultLocale)
+	}
+}
@@ -0,0 +1,122 @@
+package interactions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// maxChoices is the most suggestions discord accepts in an autocomplete response
+const maxChoices = 25
+
+func (h *handler) handleAutocomplete(ctx context.Context, ia interaction) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "interactions.handleAutocomplete", "guild_id", ia.guildID.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, h.deps.Logger())
+
+	choices := make([]OptionChoice, 0, maxChoices)
+
+	if ia.guildID != 0 {
+		var err error
+		if choices, err = h.suggest(ctx, ia, choices); err != nil {
+			level.Error(logger).Err("could not build autocomplete suggestions", err, "command", ia.data.name)
+		}
+	}
+
+	err := h.postJSON(ctx, h.callbackURL(ia), AutocompleteResponse{
+		Type: callbackAutocomplete,
+		Data: AutocompleteData{Choices: choices},
+	})
+	if err != nil {
+		level.Error(logger).Err("could not respond to autocomplete", err)
+	}
+}
+
+func (h *handler) suggest(ctx context.Context, ia interaction, choices []OptionChoice) ([]OptionChoice, error) {
+	root, sub, opts, err := resolve(ia.data)
+	if err != nil {
+		return choices, err
+	}
+
+	focused, ok := focusedOption(opts)
+	if !ok {
+		return choices, nil
+	}
+
+	spec, ok := sub.option(focused.name)
+	if !ok || spec.complete == completeNone {
+		return choices, nil
+	}
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, ia.guildID.ToString(), false)
+	if err != nil {
+		return choices, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	var names []string
+
+	switch spec.complete {
+	case completeTrial:
+		for _, trial := range t.GetTrials(ctx) {
+			// members can only act on open events, admins on any of them
+			if root.tree == userTree && trial.GetState(ctx) != storage.TrialStateOpen {
+				continue
+			}
+			names = append(names, trial.GetName(ctx))
+		}
+	case completeRole:
+		event, ok := findOption(opts, eventOption.name)
+		if !ok || event.value == "" {
+			return choices, nil
+		}
+
+		trial, err := t.GetTrial(ctx, event.value)
+		if err == storage.ErrTrialNotExist {
+			return choices, nil
+		}
+		if err != nil {
+			return choices, err
+		}
+
+		for _, rc := range trial.GetRoleCounts(ctx) {
+			names = append(names, rc.GetRole(ctx))
+		}
+	}
+
+	return appendMatches(choices, names, focused.value), nil
+}
+
+// appendMatches adds the names that contain the typed text, those starting with it first
+func appendMatches(choices []OptionChoice, names []string, typed string) []OptionChoice {
+	typed = strings.ToLower(strings.TrimSpace(typed))
+
+	var contains []string
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(lower, typed):
+			if len(choices) < maxChoices {
+				choices = append(choices, OptionChoice{Name: name, Value: name})
+			}
+		case strings.Contains(lower, typed):
+			contains = append(contains, name)
+		}
+	}
+
+	for _, name := range contains {
+		if len(choices) >= maxChoices {
+			break
+		}
+		choices = append(choices, OptionChoice{Name: name, Value: name})
+	}
+
+	return choices
+}
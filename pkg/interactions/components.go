@@ -0,0 +1,254 @@
+package interactions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+)
+
+// handleComponent answers a click on a roster button or a choice in the admin menu,
+// privately, so that busy signup channels do not fill up with confirmations
+func (h *handler) handleComponent(ctx context.Context, ia interaction) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "interactions.handleComponent", "guild_id", ia.guildID.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, h.deps.Logger())
+	level.Info(logger).Message("handling component", "custom_id", ia.data.customID, "user_id", ia.userID.ToString())
+
+	if ia.guildID == 0 {
+		h.respondNotice(ctx, ia, i18n.For(i18n.DefaultLocale).Sprintf("notice.buttons_server_only"))
+		return
+	}
+
+	p := storage.GetPrinter(ctx, h.deps.GuildAPI(), ia.guildID)
+
+	action, args, err := msghandler.ParseComponentID(ia.data.customID)
+
+	var text string
+	switch {
+	case err != nil:
+	case action == msghandler.ActionSignup && len(args) == 2:
+		text, err = h.componentSignup(ctx, ia, p, args[0], args[1])
+	case action == msghandler.ActionWithdraw && len(args) == 1:
+		text, err = h.componentWithdraw(ctx, ia, p, args[0])
+	case action == msghandler.ActionRemove && len(args) == 1:
+		text, err = h.componentRemove(ctx, ia, p, args[0])
+	case action == msghandler.ActionConfirm && len(args) == 1:
+		text, err = h.componentConfirm(ctx, ia, p, args[0])
+	default:
+		err = msghandler.ErrBadComponentID
+	}
+
+	switch cause(err) {
+	case nil:
+		h.respondNotice(ctx, ia, text)
+	case msghandler.ErrUnauthorized:
+		h.respondNotice(ctx, ia, p.Sprintf("notice.remove_unauthorized"))
+	default:
+		level.Error(logger).Err("error handling component", err, "custom_id", ia.data.customID)
+		h.respondNotice(ctx, ia, p.Sprintf("notice.error", p.LocalizeError(err)))
+	}
+}
+
+func (h *handler) componentSignup(ctx context.Context, ia interaction, p i18n.Printer, trialName, role string) (string, error) {
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, ia.guildID.ToString(), true)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return "", err
+	}
+
+	userMention := cmdhandler.UserMentionString(ia.userID)
+
+	rules := commands.NewRosterRules(ctx, h.deps.BotSession(), ia.guildID, att, trial, time.Now())
+	overflow, err := commands.SignupOpenTrial(ctx, trial, userMention, role, rules)
+	if err != nil {
+		return "", err
+	}
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return "", errors.Wrap(err, "could not save trial signup")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "could not save trial signup")
+	}
+
+	level.Info(logging.WithContext(ctx, h.deps.Logger())).Message("signed up", "overflow", overflow, "role", role, "trial_name", trialName)
+
+	ev := webhook.Event{
+		Type:    webhook.EventSignup,
+		GuildID: ia.guildID.ToString(),
+		Trial:   trial.GetName(ctx),
+		Role:    role,
+		Users:   []string{userMention},
+		Actor:   userMention,
+	}
+	if overflow {
+		ev.Overflow = ev.Users
+	}
+	h.deps.Webhooks().Notify(ctx, ev)
+	h.deps.Notifier().Notify(ctx, commands.TrialNotices(ctx, h.deps.BotSession(), ia.guildID, trial, notify.KindSignup, commands.SignupConfirmation(p, trial.GetName(ctx), role, overflow), ev.Users)...)
+
+	return commands.SignupConfirmation(p, trial.GetName(ctx), role, overflow), nil
+}
+
+func (h *handler) componentWithdraw(ctx context.Context, ia interaction, p i18n.Printer, trialName string) (string, error) {
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, ia.guildID.ToString(), true)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return "", err
+	}
+
+	userMention := cmdhandler.UserMentionString(ia.userID)
+
+	overflowBefore := commands.OverflowSignups(ctx, trial, commands.NewRosterRules(ctx, h.deps.BotSession(), ia.guildID, att, trial, time.Now()))
+
+	if err = commands.WithdrawOpenTrial(ctx, trial, userMention); err != nil {
+		return "", err
+	}
+
+	notices := commands.PromotionNotices(ctx, h.deps.BotSession(), p, ia.guildID, trial, commands.NewRosterRules(ctx, h.deps.BotSession(), ia.guildID, att, trial, time.Now()), overflowBefore)
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return "", errors.Wrap(err, "could not save trial withdraw")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "could not save trial withdraw")
+	}
+
+	level.Info(logging.WithContext(ctx, h.deps.Logger())).Message("withdrew", "trial_name", trialName)
+
+	h.deps.Webhooks().Notify(ctx, webhook.Event{
+		Type:    webhook.EventWithdraw,
+		GuildID: ia.guildID.ToString(),
+		Trial:   trial.GetName(ctx),
+		Users:   []string{userMention},
+		Actor:   userMention,
+	})
+	h.deps.Notifier().Notify(ctx, notices...)
+
+	return commands.WithdrawConfirmation(p, trial.GetName(ctx)), nil
+}
+
+func (h *handler) componentConfirm(ctx context.Context, ia interaction, p i18n.Printer, trialName string) (string, error) {
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, ia.guildID.ToString(), true)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return "", err
+	}
+
+	if err = commands.ConfirmLockedTrial(ctx, trial, cmdhandler.UserMentionString(ia.userID), time.Now()); err != nil {
+		return "", err
+	}
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return "", errors.Wrap(err, "could not save confirmation")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "could not save confirmation")
+	}
+
+	level.Info(logging.WithContext(ctx, h.deps.Logger())).Message("confirmed", "trial_name", trialName)
+
+	return commands.RosterConfirmation(p, trial.GetName(ctx)), nil
+}
+
+// componentRemove withdraws the signups chosen in the admin menu, like !admin withdraw
+func (h *handler) componentRemove(ctx context.Context, ia interaction, p i18n.Printer, trialName string) (string, error) {
+	logger := logging.WithContext(ctx, h.deps.Logger())
+
+	s, err := storage.GetSettings(ctx, h.deps.GuildAPI(), ia.guildID)
+	if err != nil {
+		level.Error(logger).Err("could not retrieve guild settings", err)
+	}
+
+	msg := cmdhandler.NewSimpleMessage(ctx, ia.userID, ia.guildID, ia.channelID, ia.id, "")
+	if !msghandler.IsAdminAuthorized(logger, msg, s.AdminRole, h.deps.BotSession()) {
+		return "", msghandler.ErrUnauthorized
+	}
+
+	if len(ia.data.values) == 0 {
+		return "", i18n.NewError("err.no_signups_chosen")
+	}
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, ia.guildID.ToString(), true)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return "", err
+	}
+
+	if !commands.WithdrawAllowed(ctx, trial) {
+		return "", commands.ErrWithdrawClosed
+	}
+
+	overflowBefore := commands.OverflowSignups(ctx, trial, commands.NewRosterRules(ctx, h.deps.BotSession(), ia.guildID, att, trial, time.Now()))
+
+	for _, name := range ia.data.values {
+		trial.RemoveSignup(ctx, name)
+	}
+
+	notices := commands.PromotionNotices(ctx, h.deps.BotSession(), p, ia.guildID, trial, commands.NewRosterRules(ctx, h.deps.BotSession(), ia.guildID, att, trial, time.Now()), overflowBefore)
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return "", errors.Wrap(err, "could not save event withdraw")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "could not save event withdraw")
+	}
+
+	level.Info(logger).Message("admin removed signups", "trial_name", trialName, "signups", ia.data.values)
+
+	h.deps.Webhooks().Notify(ctx, webhook.Event{
+		Type:    webhook.EventWithdraw,
+		GuildID: ia.guildID.ToString(),
+		Trial:   trial.GetName(ctx),
+		Users:   ia.data.values,
+		Actor:   cmdhandler.UserMentionString(ia.userID),
+	})
+	h.deps.Notifier().Notify(ctx, notices...)
+
+	return p.Sprintf("notice.removed", strings.Join(ia.data.values, ", "), trial.GetName(ctx)), nil
+}
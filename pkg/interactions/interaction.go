@@ -0,0 +1,246 @@
+package interactions
+
+import (
+	"strconv"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+)
+
+// See https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-interaction-type
+const (
+	interactionPing         = 1
+	interactionCommand      = 2
+	interactionComponent    = 3
+	interactionAutocomplete = 4
+)
+
+// interaction is the data about an INTERACTION_CREATE event that the handlers need
+type interaction struct {
+	id            snowflake.Snowflake
+	applicationID snowflake.Snowflake
+	kind          int
+	token         string
+	guildID       snowflake.Snowflake
+	channelID     snowflake.Snowflake
+	userID        snowflake.Snowflake
+	data          commandData
+}
+
+// commandData is the data of a command or autocomplete interaction (name and options),
+// or of a component interaction (customID and, for select menus, values)
+type commandData struct {
+	name     string
+	options  []interactionOption
+	customID string
+	values   []string
+}
+
+type interactionOption struct {
+	name    string
+	kind    int
+	value   string
+	focused bool
+	options []interactionOption
+}
+
+func findOption(opts []interactionOption, name string) (interactionOption, bool) {
+	for _, o := range opts {
+		if o.name == name {
+			return o, true
+		}
+	}
+	return interactionOption{}, false
+}
+
+// focusedOption finds the option being typed in an autocomplete interaction
+func focusedOption(opts []interactionOption) (interactionOption, bool) {
+	for _, o := range opts {
+		if o.focused {
+			return o, true
+		}
+		if f, ok := focusedOption(o.options); ok {
+			return f, true
+		}
+	}
+	return interactionOption{}, false
+}
+
+// optionalSnowflake reads a snowflake field that discord leaves out in some contexts (e.g. guild_id in DMs)
+func optionalSnowflake(eMap map[string]etfapi.Element, key string) (snowflake.Snowflake, error) {
+	e, ok := eMap[key]
+	if !ok || e.IsNil() {
+		return 0, nil
+	}
+	return etfapi.SnowflakeFromElement(e)
+}
+
+// elementValue converts an option value to the string it would have been in a message
+func elementValue(e etfapi.Element) (string, error) {
+	switch {
+	case e.IsTrue():
+		return "true", nil
+	case e.IsFalse():
+		return "false", nil
+	case e.IsNumeric():
+		v, err := e.ToInt64()
+		return strconv.FormatInt(v, 10), err
+	default:
+		return e.ToString()
+	}
+}
+
+func optionsFromElement(e etfapi.Element) ([]interactionOption, error) {
+	if e.IsNil() {
+		return nil, nil
+	}
+
+	eList, err := e.ToList()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not inflate options to list")
+	}
+
+	opts := make([]interactionOption, 0, len(eList))
+	for _, oe := range eList {
+		oMap, err := oe.ToMap()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not inflate option to map")
+		}
+
+		var o interactionOption
+
+		ne, te := oMap["name"], oMap["type"]
+		if o.name, err = ne.ToString(); err != nil {
+			return nil, errors.Wrap(err, "could not get option name")
+		}
+
+		if o.kind, err = te.ToInt(); err != nil {
+			return nil, errors.Wrap(err, "could not get option type", "name", o.name)
+		}
+
+		if ve, ok := oMap["value"]; ok && !ve.IsNil() {
+			if o.value, err = elementValue(ve); err != nil {
+				return nil, errors.Wrap(err, "could not get option value", "name", o.name)
+			}
+		}
+
+		if fe, ok := oMap["focused"]; ok {
+			o.focused = fe.IsTrue()
+		}
+
+		if se, ok := oMap["options"]; ok {
+			if o.options, err = optionsFromElement(se); err != nil {
+				return nil, err
+			}
+		}
+
+		opts = append(opts, o)
+	}
+
+	return opts, nil
+}
+
+func userIDFromElementMap(eMap map[string]etfapi.Element) (snowflake.Snowflake, error) {
+	// guild interactions carry the user inside the member object, DM ones at the top level
+	if me, ok := eMap["member"]; ok && !me.IsNil() {
+		mMap, err := me.ToMap()
+		if err != nil {
+			return 0, errors.Wrap(err, "could not inflate member to map")
+		}
+		eMap = mMap
+	}
+
+	ue, ok := eMap["user"]
+	if !ok {
+		return 0, errors.New("interaction has no user")
+	}
+
+	_, uid, err := etfapi.MapAndIDFromElement(ue)
+	return uid, err
+}
+
+// interactionFromElementMap generates an interaction from the data of an INTERACTION_CREATE payload
+func interactionFromElementMap(eMap map[string]etfapi.Element) (interaction, error) {
+	var ia interaction
+	var err error
+
+	if ia.id, err = etfapi.SnowflakeFromElement(eMap["id"]); err != nil {
+		return ia, errors.Wrap(err, "could not get interaction id")
+	}
+
+	if ia.applicationID, err = etfapi.SnowflakeFromElement(eMap["application_id"]); err != nil {
+		return ia, errors.Wrap(err, "could not get application id")
+	}
+
+	te, ke := eMap["type"], eMap["token"]
+	if ia.kind, err = te.ToInt(); err != nil {
+		return ia, errors.Wrap(err, "could not get interaction type")
+	}
+
+	if ia.token, err = ke.ToString(); err != nil {
+		return ia, errors.Wrap(err, "could not get interaction token")
+	}
+
+	if ia.kind == interactionPing {
+		return ia, nil
+	}
+
+	if ia.guildID, err = optionalSnowflake(eMap, "guild_id"); err != nil {
+		return ia, errors.Wrap(err, "could not get guild id")
+	}
+
+	if ia.channelID, err = optionalSnowflake(eMap, "channel_id"); err != nil {
+		return ia, errors.Wrap(err, "could not get channel id")
+	}
+
+	if ia.userID, err = userIDFromElementMap(eMap); err != nil {
+		return ia, errors.Wrap(err, "could not get user id")
+	}
+
+	de, ok := eMap["data"]
+	if !ok {
+		return ia, nil
+	}
+
+	dMap, err := de.ToMap()
+	if err != nil {
+		return ia, errors.Wrap(err, "could not inflate interaction data to map")
+	}
+
+	if ne, ok := dMap["name"]; ok {
+		if ia.data.name, err = ne.ToString(); err != nil {
+			return ia, errors.Wrap(err, "could not get command name")
+		}
+	}
+
+	if oe, ok := dMap["options"]; ok {
+		if ia.data.options, err = optionsFromElement(oe); err != nil {
+			return ia, err
+		}
+	}
+
+	if ce, ok := dMap["custom_id"]; ok {
+		if ia.data.customID, err = ce.ToString(); err != nil {
+			return ia, errors.Wrap(err, "could not get component custom id")
+		}
+	}
+
+	if ve, ok := dMap["values"]; ok && !ve.IsNil() {
+		vList, err := ve.ToList()
+		if err != nil {
+			return ia, errors.Wrap(err, "could not inflate component values to list")
+		}
+
+		for _, v := range vList {
+			val, err := v.ToString()
+			if err != nil {
+				return ia, errors.Wrap(err, "could not get component value")
+			}
+			ia.data.values = append(ia.data.values, val)
+		}
+	}
+
+	return ia, nil
+}
@@ -0,0 +1,254 @@
+package interactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	"github.com/gsmcwhirter/go-util/v5/parser"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/bot"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/httpclient"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/request"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/wsclient"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhook"
+)
+
+// ErrRegistration is the error returned when discord does not accept a slash command
+var ErrRegistration = errors.New("command registration failed")
+
+type dependencies interface {
+	Logger() logging.Logger
+	GuildAPI() storage.GuildAPI
+	TrialAPI() storage.TrialAPI
+	CommandHandler() *cmdhandler.CommandHandler
+	ConfigHandler() *cmdhandler.CommandHandler
+	AdminHandler() *cmdhandler.CommandHandler
+	HTTPClient() httpclient.HTTPClient
+	BotSession() *etfapi.Session
+	Webhooks() webhook.Dispatcher
+	Notifier() notify.Notifier
+	Census() *census.Census
+}
+
+// Handler is the interface for a Handler dependency that answers slash commands
+// and the buttons and menus on roster messages
+//
+// The slash commands mirror the !-commands (see ApplicationCommands) and run the same
+// handlers, so they behave identically apart from how the reply is delivered
+type Handler interface {
+	ConnectToBot(bot.DiscordBot)
+	RegisterCommands(ctx context.Context) error
+}
+
+type handler struct {
+	bot          bot.DiscordBot
+	deps         dependencies
+	successColor int
+	errorColor   int
+}
+
+// Options provides a way to pass configuration to NewHandler
+type Options struct {
+	SuccessColor int
+	ErrorColor   int
+}
+
+// NewHandler creates a new Handler object
+func NewHandler(deps dependencies, opts Options) Handler {
+	return &handler{
+		deps:         deps,
+		successColor: opts.SuccessColor,
+		errorColor:   opts.ErrorColor,
+	}
+}
+
+func (h *handler) ConnectToBot(b bot.DiscordBot) {
+	h.bot = b
+
+	b.AddMessageHandler("INTERACTION_CREATE", h.handleInteraction)
+}
+
+// RegisterCommands creates or updates the global slash commands of the application
+func (h *handler) RegisterCommands(ctx context.Context) error {
+	ctx, span := h.deps.Census().StartSpan(ctx, "interactions.RegisterCommands")
+	defer span.End()
+
+	if h.bot == nil {
+		return errors.New("not connected to a bot")
+	}
+
+	logger := logging.WithContext(ctx, h.deps.Logger())
+	url := fmt.Sprintf("%s/applications/%s/commands", h.bot.Config().APIURL, h.bot.Config().ClientID)
+
+	for _, cmd := range ApplicationCommands() {
+		b, err := cmd.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal command", "command", cmd.Name)
+		}
+
+		header := http.Header{}
+		header.Add("Content-Type", "application/json")
+		resp, body, err := h.deps.HTTPClient().PostBody(ctx, url, &header, bytes.NewReader(b))
+		if err != nil {
+			return errors.Wrap(err, "could not register command", "command", cmd.Name)
+		}
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+			return errors.Wrap(ErrRegistration, "non-2xx response", "command", cmd.Name, "status_code", resp.StatusCode, "resp_body", string(body))
+		}
+
+		level.Info(logger).Message("registered command", "command", cmd.Name)
+	}
+
+	return nil
+}
+
+func (h *handler) handleInteraction(p *etfapi.Payload, req wsclient.WSMessage, respChan chan<- wsclient.WSMessage) snowflake.Snowflake {
+	ctx, span := h.deps.Census().StartSpan(req.Ctx, "interactions.handleInteraction")
+	defer span.End()
+	req.Ctx = ctx
+
+	if h.bot == nil {
+		return 0
+	}
+
+	select {
+	case <-req.Ctx.Done():
+		return 0
+	default:
+	}
+
+	logger := logging.WithContext(req.Ctx, h.deps.Logger())
+
+	ia, err := interactionFromElementMap(p.Data)
+	if err != nil {
+		level.Error(logger).Err("error inflating interaction", err)
+		return 0
+	}
+
+	req.Ctx = request.WithGuildID(req.Ctx, ia.guildID)
+	logger = logging.WithContext(req.Ctx, h.deps.Logger())
+
+	switch ia.kind {
+	case interactionCommand:
+		h.handleCommand(req.Ctx, ia)
+	case interactionComponent:
+		h.handleComponent(req.Ctx, ia)
+	case interactionAutocomplete:
+		h.handleAutocomplete(req.Ctx, ia)
+	default:
+		level.Info(logger).Message("ignoring interaction", "interaction_type", ia.kind)
+	}
+
+	return ia.guildID
+}
+
+// cause unwraps the errors that the command handlers wrap
+func cause(err error) error {
+	if e2, ok := err.(errors.Error); ok && e2.Cause() != nil {
+		return e2.Cause()
+	}
+	return err
+}
+
+func (h *handler) handleCommand(ctx context.Context, ia interaction) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "interactions.handleCommand", "guild_id", ia.guildID.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, h.deps.Logger())
+	level.Info(logger).Message("handling interaction", "command", ia.data.name, "user_id", ia.userID.ToString())
+
+	if ia.guildID == 0 {
+		h.respondNotice(ctx, ia, i18n.For(i18n.DefaultLocale).Sprintf("notice.server_only"))
+		return
+	}
+
+	p := storage.GetPrinter(ctx, h.deps.GuildAPI(), ia.guildID)
+
+	resp, err := h.runCommand(ctx, ia)
+
+	switch cause(err) {
+	case nil:
+	case msghandler.ErrUnauthorized:
+		h.respondNotice(ctx, ia, p.Sprintf("notice.unauthorized"))
+		return
+	case msghandler.ErrNoResponse:
+		h.respondNotice(ctx, ia, p.Sprintf("notice.wrong_channel"))
+		return
+	case parser.ErrUnknownCommand, ErrUnknownInteraction:
+		h.respondNotice(ctx, ia, p.Sprintf("notice.unknown_command"))
+		return
+	default:
+		level.Error(logger).Err("error handling interaction", err, "command", ia.data.name)
+		if resp == nil {
+			resp = &cmdhandler.SimpleEmbedResponse{To: cmdhandler.UserMentionString(ia.userID)}
+		}
+		resp.IncludeError(p.LocalizeError(err))
+	}
+
+	if resp.HasErrors() {
+		resp.SetColor(h.errorColor)
+	} else {
+		resp.SetColor(h.successColor)
+	}
+
+	h.respond(ctx, ia, p, resp)
+}
+
+// runCommand dispatches an interaction into the message handler tree of its command
+func (h *handler) runCommand(ctx context.Context, ia interaction) (cmdhandler.Response, error) {
+	root, sub, opts, err := resolve(ia.data)
+	if err != nil {
+		return nil, err
+	}
+
+	args, argErr := sub.arguments(opts)
+
+	msg := cmdhandler.NewSimpleMessage(ctx, ia.userID, ia.guildID, ia.channelID, ia.id, "")
+	logger := logging.WithMessage(msg, h.deps.Logger())
+
+	var ch *cmdhandler.CommandHandler
+	var tokens []string
+
+	switch root.tree {
+	case adminTree:
+		ch = h.deps.AdminHandler()
+	case configTree:
+		ch = h.deps.ConfigHandler()
+	default:
+		ch = h.deps.CommandHandler()
+	}
+
+	tokens = append(tokens, ch.CommandIndicator()+root.name)
+	if root.tree != userTree {
+		tokens = append(tokens, sub.name)
+
+		s, err := storage.GetSettings(ctx, h.deps.GuildAPI(), ia.guildID)
+		if err != nil {
+			level.Error(logger).Err("could not retrieve guild settings", err)
+		}
+
+		if !msghandler.IsAdminAuthorized(logger, msg, s.AdminRole, h.deps.BotSession()) {
+			level.Info(logger).Message("non-admin trying to use admin interaction", "command", root.name)
+			return nil, msghandler.ErrUnauthorized
+		}
+	}
+	tokens = append(tokens, args...)
+
+	level.Info(logger).Message("processing interaction", "tokens", fmt.Sprintf("%q", tokens))
+	return ch.HandleMessage(cmdhandler.NewWithTokens(msg, tokens, argErr))
+}
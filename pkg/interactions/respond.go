@@ -0,0 +1,164 @@
+package interactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/jsonapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+)
+
+// See https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-interaction-callback-type
+const (
+	callbackMessage      = 4
+	callbackAutocomplete = 8
+)
+
+// flagEphemeral makes a reply visible only to the user who ran the command
+const flagEphemeral = 64
+
+// ErrCallback is the error returned when discord does not accept an interaction response
+var ErrCallback = errors.New("interaction response failed")
+
+// messageData converts a command response to the message format that interaction callbacks use
+func messageData(r cmdhandler.Response) MessageData {
+	switch m := r.ToMessage().(type) {
+	case msghandler.MessageWithComponents:
+		return MessageData{Content: m.Content, Embeds: []jsonapi.Embed{m.Embed}, Components: m.Components}
+	case jsonapi.MessageWithEmbed:
+		return MessageData{Content: m.Content, Embeds: []jsonapi.Embed{m.Embed}}
+	case jsonapi.Message:
+		return MessageData{Content: m.Content}
+	default:
+		return MessageData{Content: r.ToString()}
+	}
+}
+
+func (h *handler) callbackURL(ia interaction) string {
+	return fmt.Sprintf("%s/interactions/%d/%s/callback", h.bot.Config().APIURL, ia.id, ia.token)
+}
+
+func (h *handler) followupURL(ia interaction) string {
+	return fmt.Sprintf("%s/webhooks/%d/%s", h.bot.Config().APIURL, ia.applicationID, ia.token)
+}
+
+func (h *handler) post(ctx context.Context, url, contentType string, body io.Reader) error {
+	header := http.Header{}
+	header.Add("Content-Type", contentType)
+	resp, respBody, err := h.deps.HTTPClient().PostBody(ctx, url, &header, body)
+	if err != nil {
+		return errors.Wrap(err, "could not complete the interaction response")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Wrap(ErrCallback, "non-2xx response", "status_code", resp.StatusCode, "resp_body", string(respBody))
+	}
+
+	return nil
+}
+
+func (h *handler) postJSON(ctx context.Context, url string, v cmdhandler.JSONMarshaler) error {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal interaction response")
+	}
+
+	return h.post(ctx, url, "application/json", bytes.NewReader(b))
+}
+
+// postFile sends an interaction response with an attachment, the same way msghandler uploads files
+func (h *handler) postFile(ctx context.Context, url string, v cmdhandler.JSONMarshaler, r *msghandler.FileResponse) error {
+	payload, err := v.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal interaction response")
+	}
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+
+	if err = w.WriteField("payload_json", string(payload)); err != nil {
+		return errors.Wrap(err, "could not write message payload")
+	}
+
+	fw, err := w.CreateFormFile("files[0]", r.FileName)
+	if err != nil {
+		return errors.Wrap(err, "could not create file part")
+	}
+
+	if _, err = fw.Write(r.FileData); err != nil {
+		return errors.Wrap(err, "could not write file part")
+	}
+
+	if err = w.Close(); err != nil {
+		return errors.Wrap(err, "could not finish multipart body")
+	}
+
+	return h.post(ctx, url, w.FormDataContentType(), buf)
+}
+
+// respondNotice answers with a short message that only the user can see
+func (h *handler) respondNotice(ctx context.Context, ia interaction, text string) {
+	err := h.postJSON(ctx, h.callbackURL(ia), MessageResponse{
+		Type: callbackMessage,
+		Data: MessageData{Content: text, Flags: flagEphemeral},
+	})
+	if err != nil {
+		level.Error(logging.WithContext(ctx, h.deps.Logger())).Err("could not respond to interaction", err)
+	}
+}
+
+// respond answers with a command response; the first part of a long response is the
+// interaction reply and the rest are followup messages
+func (h *handler) respond(ctx context.Context, ia interaction, p i18n.Printer, resp cmdhandler.Response) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "interactions.respond", "guild_id", ia.guildID.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, h.deps.Logger())
+
+	// responses meant for another channel (e.g. announcements) still go there
+	if cid := resp.Channel(); cid != 0 && cid != ia.channelID {
+		for _, res := range resp.Split() {
+			if _, body, err := h.bot.SendMessage(ctx, cid, res.ToMessage()); err != nil {
+				level.Error(logger).Err("could not send message", err, "channel_id", cid.ToString(), "resp_body", string(body))
+				h.respondNotice(ctx, ia, p.Sprintf("notice.post_failed"))
+				return
+			}
+		}
+
+		h.respondNotice(ctx, ia, p.Sprintf("notice.posted", cmdhandler.ChannelMentionString(cid)))
+		return
+	}
+
+	for i, res := range resp.Split() {
+		data := messageData(res)
+
+		var err error
+		switch {
+		case i > 0:
+			err = h.postJSON(ctx, h.followupURL(ia), data)
+		default:
+			cb := MessageResponse{Type: callbackMessage, Data: data}
+			if fr, ok := res.(*msghandler.FileResponse); ok {
+				err = h.postFile(ctx, h.callbackURL(ia), cb, fr)
+			} else {
+				err = h.postJSON(ctx, h.callbackURL(ia), cb)
+			}
+		}
+
+		if err != nil {
+			level.Error(logger).Err("could not respond to interaction", err, "part", i)
+			return
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package interactions
+
+import (
+	"fmt"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+)
+
+// See https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-type
+const (
+	optionSubCommand = 1
+	optionString     = 3
+	optionBoolean    = 5
+)
+
+// ErrUnknownInteraction is the error returned when an interaction names a command
+// that was never registered
+var ErrUnknownInteraction = errors.New("unknown interaction command")
+
+// tree is the command handler tree that a slash command is dispatched into
+type tree int
+
+const (
+	userTree tree = iota
+	adminTree
+	configTree
+)
+
+// completion is the kind of autocomplete suggestions an option gets
+type completion int
+
+const (
+	completeNone completion = iota
+	completeTrial
+	completeRole
+)
+
+type commandSpec struct {
+	name        string
+	description string
+	tree        tree
+	options     []optionSpec
+	subcommands []commandSpec
+}
+
+type optionSpec struct {
+	name        string
+	description string
+	kind        int
+	required    bool
+	choices     []string
+	complete    completion
+
+	// flag is the argument a true boolean option turns into
+	flag string
+
+	// rest options are free text, split into arguments the way a message would be
+	rest bool
+}
+
+var (
+	eventOption = optionSpec{name: "event", description: "The event name", kind: optionString, required: true, complete: completeTrial}
+	roleOption  = optionSpec{name: "role", description: "The role to sign up as", kind: optionString, required: true, complete: completeRole}
+	usersOption = optionSpec{name: "users", description: "The users, as mentions separated by spaces", kind: optionString, required: true, rest: true}
+)
+
+// commandSpecs mirror the !-command trees, so each slash command runs the handler
+// of the message command with the same name
+var commandSpecs = []commandSpec{
+	{name: "list", description: "List the events open for signups", tree: userTree},
+	{name: "show", description: "Show the roster of an event", tree: userTree, options: []optionSpec{eventOption}},
+	{name: "signup", description: "Sign up for an event", tree: userTree, options: []optionSpec{
+		eventOption,
+		{name: "role", description: "The role to sign up as (default: the role in your profile)", kind: optionString, complete: completeRole},
+		{name: "details", description: "Optional details, e.g. character=Zyra class=nb note=\"late 10min\"", kind: optionString, rest: true},
+	}},
+	{name: "withdraw", description: "Withdraw from an event", tree: userTree, options: []optionSpec{eventOption}},
+	{name: "confirm", description: "Confirm your place in a locked event", tree: userTree, options: []optionSpec{eventOption}},
+	{name: "profile", description: "Show or change the role and character you usually sign up with", tree: userTree, options: []optionSpec{
+		{name: "action", description: "What to do", kind: optionString, required: true, choices: []string{"show", "set", "clear"}},
+		{name: "settings", description: "For set, e.g. role=healer character=\"Lady Heals\" class=templar", kind: optionString, rest: true},
+	}},
+	{name: "notify", description: "Show or choose the direct messages you get about events", tree: userTree, options: []optionSpec{
+		{name: "choice", description: "Which direct messages to get", kind: optionString, choices: []string{"on", "off", "promotions-only"}},
+	}},
+	{name: "presets", description: "List the role layouts events can be created from", tree: userTree, options: []optionSpec{
+		{name: "preset", description: "Show only this preset", kind: optionString},
+	}},
+	{name: "calendar", description: "Get a calendar of the scheduled events", tree: userTree, options: []optionSpec{
+		{name: "mine", description: "Only the events you signed up for", kind: optionBoolean, flag: "me"},
+		{name: "file", description: "Attach a file instead of linking a feed", kind: optionBoolean, flag: "file"},
+	}},
+	{name: "admin", description: "Manage events", tree: adminTree, subcommands: []commandSpec{
+		{name: "list", description: "List all events"},
+		{name: "create", description: "Create an event", options: []optionSpec{
+			eventOption,
+			{name: "settings", description: "Settings as key=value pairs, e.g. preset=vss-hm or roles=tank:2,healer:2,dps:8", kind: optionString, rest: true},
+		}},
+		{name: "edit", description: "Change the settings of an event", options: []optionSpec{
+			eventOption,
+			{name: "settings", description: "Settings as key=value pairs", kind: optionString, required: true, rest: true},
+		}},
+		{name: "open", description: "Open an event for signups", options: []optionSpec{eventOption}},
+		{name: "close", description: "Close an event for signups", options: []optionSpec{eventOption}},
+		{name: "lock", description: "Lock the roster of an event for its main group to confirm", options: []optionSpec{
+			eventOption,
+			{name: "deadline", description: "How long to confirm (e.g. 12h) or when by (e.g. 2026-11-01T18:00)", kind: optionString},
+		}},
+		{name: "cancel", description: "Cancel an event and ping its signups", options: []optionSpec{
+			eventOption,
+			{name: "reason", description: "Why the event is canceled", kind: optionString, rest: true},
+		}},
+		{name: "reschedule", description: "Move the start of an event and ping its signups", options: []optionSpec{
+			eventOption,
+			{name: "start", description: "The new start, e.g. 2026-11-01 18:00 (UTC) or 2026-11-01 19:00 +01:00", kind: optionString, required: true},
+		}},
+		{name: "delete", description: "Delete an event", options: []optionSpec{eventOption}},
+		{name: "announce", description: "Announce an event", options: []optionSpec{
+			eventOption,
+			{name: "message", description: "Text to include in the announcement", kind: optionString, rest: true},
+		}},
+		{name: "grouping", description: "Mention the signups of an event", options: []optionSpec{
+			eventOption,
+			{name: "message", description: "Text to include with the mentions", kind: optionString, rest: true},
+		}},
+		{name: "signup", description: "Sign users up for an event", options: []optionSpec{eventOption, roleOption, usersOption}},
+		{name: "withdraw", description: "Withdraw users from an event", options: []optionSpec{eventOption, usersOption}},
+		{name: "clear", description: "Remove every signup from an event", options: []optionSpec{eventOption}},
+		{name: "show", description: "Show an event with its settings", options: []optionSpec{eventOption}},
+	}},
+	{name: "config-su", description: "Configure the signup bot", tree: configTree, subcommands: []commandSpec{
+		{name: "list", description: "List the available settings"},
+		{name: "get", description: "Show the value of a setting", options: []optionSpec{
+			{name: "setting", description: "The setting name", kind: optionString, required: true},
+		}},
+		{name: "set", description: "Change settings", options: []optionSpec{
+			{name: "settings", description: "Settings as key=value pairs", kind: optionString, required: true, rest: true},
+		}},
+		{name: "reset", description: "Reset every setting to its default"},
+		{name: "version", description: "Show the bot version"},
+		{name: "website", description: "Show the bot website"},
+		{name: "discord", description: "Show the bot support discord"},
+		{name: "stats", description: "Show statistics about this server's events"},
+		{name: "doctor", description: "Check settings and events for channels and roles that no longer exist"},
+		{name: "export", description: "Export this server's settings and events"},
+		{name: "apitoken", description: "Generate a token for the events api", options: []optionSpec{
+			{name: "revoke", description: "Revoke the token instead", kind: optionBoolean, flag: "revoke"},
+		}},
+		{name: "webhook", description: "Manage outbound webhooks", options: []optionSpec{
+			{name: "action", description: "What to do", kind: optionString, required: true, choices: []string{"add", "list", "remove"}},
+			{name: "args", description: "The url (and events=...) to add, or the url or number to remove", kind: optionString, rest: true},
+		}},
+		{name: "preset", description: "Manage this server's presets for creating events", options: []optionSpec{
+			{name: "action", description: "What to do", kind: optionString, required: true, choices: []string{"set", "list", "remove"}},
+			{name: "args", description: "The name (and roles=... description=...) to set, or the name to remove", kind: optionString, rest: true},
+		}},
+	}},
+}
+
+func findSpec(specs []commandSpec, name string) (commandSpec, bool) {
+	for _, s := range specs {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return commandSpec{}, false
+}
+
+func (o optionSpec) toCommandOption() CommandOption {
+	opt := CommandOption{
+		Type:         o.kind,
+		Name:         o.name,
+		Description:  o.description,
+		Required:     o.required,
+		Autocomplete: o.complete != completeNone,
+	}
+
+	for _, c := range o.choices {
+		opt.Choices = append(opt.Choices, OptionChoice{Name: c, Value: c})
+	}
+
+	return opt
+}
+
+func (s commandSpec) toCommandOptions() []CommandOption {
+	opts := make([]CommandOption, 0, len(s.options)+len(s.subcommands))
+	for _, o := range s.options {
+		opts = append(opts, o.toCommandOption())
+	}
+
+	for _, sub := range s.subcommands {
+		opts = append(opts, CommandOption{
+			Type:        optionSubCommand,
+			Name:        sub.name,
+			Description: sub.description,
+			Options:     sub.toCommandOptions(),
+		})
+	}
+
+	return opts
+}
+
+// ApplicationCommands is the definition of every slash command, for registering with discord
+func ApplicationCommands() []ApplicationCommand {
+	cmds := make([]ApplicationCommand, 0, len(commandSpecs))
+	for _, s := range commandSpecs {
+		cmds = append(cmds, ApplicationCommand{
+			Name:        s.name,
+			Description: s.description,
+			Options:     s.toCommandOptions(),
+		})
+	}
+	return cmds
+}
+
+// resolve finds the spec of the (sub)command an interaction invoked, and the options given to it
+func resolve(data commandData) (commandSpec, commandSpec, []interactionOption, error) {
+	root, ok := findSpec(commandSpecs, data.name)
+	if !ok {
+		return root, root, nil, ErrUnknownInteraction
+	}
+
+	if len(root.subcommands) == 0 {
+		return root, root, data.options, nil
+	}
+
+	if len(data.options) != 1 || data.options[0].kind != optionSubCommand {
+		return root, root, nil, ErrUnknownInteraction
+	}
+
+	sub, ok := findSpec(root.subcommands, data.options[0].name)
+	if !ok {
+		return root, root, nil, ErrUnknownInteraction
+	}
+
+	return root, sub, data.options[0].options, nil
+}
+
+// arguments turns the options of an interaction into the arguments the message
+// handler would have parsed from the equivalent command
+func (s commandSpec) arguments(opts []interactionOption) ([]string, error) {
+	args := make([]string, 0, len(s.options))
+
+	for _, o := range s.options {
+		val, ok := findOption(opts, o.name)
+		if !ok {
+			if o.required {
+				return args, fmt.Errorf("missing required option '%s'", o.name)
+			}
+			continue
+		}
+
+		switch {
+		case o.kind == optionBoolean:
+			if val.value == "true" && o.flag != "" {
+				args = append(args, o.flag)
+			}
+		case o.rest:
+			toks, err := msghandler.Tokenize(val.value)
+			if err != nil {
+				return args, err
+			}
+			args = append(args, toks...)
+		default:
+			args = append(args, val.value)
+		}
+	}
+
+	return args, nil
+}
+
+func (s commandSpec) option(name string) (optionSpec, bool) {
+	for _, o := range s.options {
+		if o.name == name {
+			return o, true
+		}
+	}
+	return optionSpec{}, false
+}
@@ -0,0 +1,72 @@
+package interactions
+
+//go:generate easyjson types.go
+
+import (
+	"github.com/gsmcwhirter/discord-bot-lib/v12/jsonapi"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+)
+
+// ApplicationCommand is the json object that registers a slash command with discord
+//
+//easyjson:json
+type ApplicationCommand struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []CommandOption `json:"options,omitempty"`
+}
+
+// CommandOption is an argument or subcommand of an ApplicationCommand
+//
+//easyjson:json
+type CommandOption struct {
+	Type         int             `json:"type"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Required     bool            `json:"required,omitempty"`
+	Autocomplete bool            `json:"autocomplete,omitempty"`
+	Choices      []OptionChoice  `json:"choices,omitempty"`
+	Options      []CommandOption `json:"options,omitempty"`
+}
+
+// OptionChoice is a fixed or suggested value for a CommandOption
+//
+//easyjson:json
+type OptionChoice struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MessageResponse is the json object that answers an interaction with a message
+//
+//easyjson:json
+type MessageResponse struct {
+	Type int         `json:"type"`
+	Data MessageData `json:"data"`
+}
+
+// MessageData is the message in a MessageResponse, and the body of a followup message
+//
+//easyjson:json
+type MessageData struct {
+	Content    string                 `json:"content,omitempty"`
+	Embeds     []jsonapi.Embed        `json:"embeds,omitempty"`
+	Components []msghandler.Component `json:"components,omitempty"`
+	Flags      int                    `json:"flags,omitempty"`
+}
+
+// AutocompleteResponse is the json object that answers an autocomplete interaction
+//
+//easyjson:json
+type AutocompleteResponse struct {
+	Type int              `json:"type"`
+	Data AutocompleteData `json:"data"`
+}
+
+// AutocompleteData holds the suggestions in an AutocompleteResponse
+//
+//easyjson:json
+type AutocompleteData struct {
+	Choices []OptionChoice `json:"choices"`
+}
@@ -0,0 +1,147 @@
+package msghandler
+
+//go:generate easyjson components.go
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/jsonapi"
+)
+
+// See https://discord.com/developers/docs/interactions/message-components#component-object-component-types
+const (
+	ComponentActionRow  = 1
+	ComponentButton     = 2
+	ComponentSelectMenu = 3
+)
+
+// See https://discord.com/developers/docs/interactions/message-components#button-object-button-styles
+const (
+	ButtonPrimary   = 1
+	ButtonSecondary = 2
+	ButtonSuccess   = 3
+	ButtonDanger    = 4
+)
+
+// Actions encoded in the custom ids of components; the interactions package handles them
+const (
+	ActionSignup   = "signup"
+	ActionWithdraw = "withdraw"
+	ActionRemove   = "remove"
+	ActionConfirm  = "confirm"
+)
+
+// maxCustomID is the longest custom id discord accepts
+const maxCustomID = 100
+
+// ErrBadComponentID is the error returned when a custom id was not made by ComponentID
+var ErrBadComponentID = errors.New("malformed component id")
+
+// Component is a button, select menu, or the action row that holds them
+//
+//easyjson:json
+type Component struct {
+	Type        int            `json:"type"`
+	Style       int            `json:"style,omitempty"`
+	Label       string         `json:"label,omitempty"`
+	CustomID    string         `json:"custom_id,omitempty"`
+	Placeholder string         `json:"placeholder,omitempty"`
+	MinValues   int            `json:"min_values,omitempty"`
+	MaxValues   int            `json:"max_values,omitempty"`
+	Options     []SelectOption `json:"options,omitempty"`
+	Components  []Component    `json:"components,omitempty"`
+}
+
+// SelectOption is one of the choices in a select menu
+//
+//easyjson:json
+type SelectOption struct {
+	Label       string `json:"label"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// MessageWithComponents is a jsonapi.MessageWithEmbed with components attached
+//
+//easyjson:json
+type MessageWithComponents struct {
+	Content    string        `json:"content"`
+	Tts        bool          `json:"tts"`
+	Embed      jsonapi.Embed `json:"embed"`
+	Components []Component   `json:"components,omitempty"`
+}
+
+// ComponentID encodes an action and its arguments as a custom id; it returns false
+// when the result would be too long for discord
+func ComponentID(action string, args ...string) (string, bool) {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, action)
+	for _, a := range args {
+		parts = append(parts, url.QueryEscape(a))
+	}
+
+	id := strings.Join(parts, ":")
+	return id, len(id) <= maxCustomID
+}
+
+// ParseComponentID decodes a custom id made by ComponentID
+func ParseComponentID(id string) (string, []string, error) {
+	parts := strings.Split(id, ":")
+	if parts[0] == "" {
+		return "", nil, ErrBadComponentID
+	}
+
+	args := make([]string, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		a, err := url.QueryUnescape(p)
+		if err != nil {
+			return "", nil, errors.Wrap(ErrBadComponentID, "bad argument encoding", "custom_id", id)
+		}
+		args = append(args, a)
+	}
+
+	return parts[0], args, nil
+}
+
+// ComponentResponse is an embed response with buttons or select menus attached
+type ComponentResponse struct {
+	cmdhandler.EmbedResponse
+
+	Components []Component
+}
+
+// ToMessage generates the message with its components
+func (r *ComponentResponse) ToMessage() cmdhandler.JSONMarshaler {
+	m, ok := r.EmbedResponse.ToMessage().(jsonapi.MessageWithEmbed)
+	if !ok {
+		return r.EmbedResponse.ToMessage()
+	}
+
+	return MessageWithComponents{
+		Content:    m.Content,
+		Tts:        m.Tts,
+		Embed:      m.Embed,
+		Components: r.Components,
+	}
+}
+
+// Split separates the response like an EmbedResponse, with the components on the last part
+func (r *ComponentResponse) Split() []cmdhandler.Response {
+	parts := r.EmbedResponse.Split()
+	if len(parts) <= 1 {
+		return []cmdhandler.Response{r}
+	}
+
+	if last, ok := parts[len(parts)-1].(*cmdhandler.EmbedResponse); ok {
+		parts[len(parts)-1] = &ComponentResponse{
+			EmbedResponse: *last,
+			Components:    r.Components,
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,73 @@
+package msghandler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+)
+
+// ErrFileUpload is the error returned when discord does not accept a file upload
+var ErrFileUpload = errors.New("file upload failed")
+
+// FileResponse is an embed response that also uploads a file as an attachment
+type FileResponse struct {
+	cmdhandler.SimpleEmbedResponse
+
+	FileName string
+	FileData []byte
+}
+
+// Split returns the response as-is, so that the file is only uploaded once
+func (r *FileResponse) Split() []cmdhandler.Response {
+	return []cmdhandler.Response{r}
+}
+
+func (h *handlers) sendFile(ctx context.Context, cid snowflake.Snowflake, r *FileResponse) (*http.Response, []byte, error) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "handlers.sendFile")
+	defer span.End()
+
+	payload, err := r.ToMessage().MarshalJSON()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not marshal message as json")
+	}
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+
+	if err = w.WriteField("payload_json", string(payload)); err != nil {
+		return nil, nil, errors.Wrap(err, "could not write message payload")
+	}
+
+	fw, err := w.CreateFormFile("file", r.FileName)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create file part")
+	}
+
+	if _, err = fw.Write(r.FileData); err != nil {
+		return nil, nil, errors.Wrap(err, "could not write file part")
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, nil, errors.Wrap(err, "could not finish multipart body")
+	}
+
+	header := http.Header{}
+	header.Add("Content-Type", w.FormDataContentType())
+	resp, body, err := h.deps.HTTPClient().PostBody(ctx, fmt.Sprintf("%s/channels/%d/messages", h.bot.Config().APIURL, cid), &header, buf)
+	if err != nil {
+		return resp, body, errors.Wrap(err, "could not complete the file upload")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, body, errors.Wrap(ErrFileUpload, "non-200 response", "file_name", r.FileName)
+	}
+
+	return resp, body, nil
+}
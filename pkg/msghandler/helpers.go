@@ -6,6 +6,8 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
 	"github.com/gsmcwhirter/go-util/v5/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 )
 
 // IsAdminAuthorized determines if a user can take admin actions with the bot (ignoring channel)
@@ -18,7 +20,7 @@ func IsAdminAuthorized(logger logging.Logger, msg cmdhandler.Message, adminRole
 }
 
 // IsAdminChannel determines if a message is occurring in the admin channel for a guild
-func IsAdminChannel(logger logging.Logger, msg cmdhandler.Message, adminChannel string, session *etfapi.Session) bool {
+func IsAdminChannel(logger logging.Logger, msg cmdhandler.Message, adminChannelID, adminChannel string, session *etfapi.Session) bool {
 
 	g, ok := session.Guild(msg.GuildID())
 	if !ok {
@@ -26,11 +28,11 @@ func IsAdminChannel(logger logging.Logger, msg cmdhandler.Message, adminChannel
 		return false
 	}
 
-	if adminChannel == "" {
+	if adminChannelID == "" && adminChannel == "" {
 		return true
 	}
 
-	cid, ok := g.ChannelWithName(adminChannel)
+	cid, ok := ChannelID(&g, adminChannelID, adminChannel)
 	if !ok {
 		return false
 	}
@@ -38,14 +40,30 @@ func IsAdminChannel(logger logging.Logger, msg cmdhandler.Message, adminChannel
 	return cid == msg.ChannelID()
 }
 
+// ChannelID finds a configured channel in a guild by its id, or by name if the id is
+// not set or the channel it names is gone
+func ChannelID(g *etfapi.Guild, channelID, channelName string) (snowflake.Snowflake, bool) {
+	if channelID != "" {
+		if cid, err := snowflake.FromString(channelID); err == nil && g.OwnsChannel(cid) {
+			return cid, true
+		}
+	}
+
+	if channelName == "" {
+		return 0, false
+	}
+
+	return g.ChannelWithName(channelName)
+}
+
 // IsSignupChannel determines if a message is occurring in the designated signup channel for a guild
-func IsSignupChannel(msg cmdhandler.Message, signupChannel string, session *etfapi.Session) bool {
+func IsSignupChannel(msg cmdhandler.Message, signupChannelID, signupChannel string, session *etfapi.Session) bool {
 	g, ok := session.Guild(msg.GuildID())
 	if !ok {
 		return false
 	}
 
-	cid, ok := g.ChannelWithName(signupChannel)
+	cid, ok := ChannelID(&g, signupChannelID, signupChannel)
 	if !ok {
 		return false
 	}
@@ -53,6 +71,33 @@ func IsSignupChannel(msg cmdhandler.Message, signupChannel string, session *etfa
 	return cid == msg.ChannelID()
 }
 
+// ChannelLookup finds channels by name in the guilds of a session, so that storage can record
+// the ids of channels that were saved by name
+func ChannelLookup(session *etfapi.Session) storage.ChannelLookup {
+	return func(guildID, channelName string) (string, bool) {
+		if channelName == "" {
+			return "", false
+		}
+
+		gid, err := snowflake.FromString(guildID)
+		if err != nil {
+			return "", false
+		}
+
+		g, ok := session.Guild(gid)
+		if !ok {
+			return "", false
+		}
+
+		cid, ok := g.ChannelWithName(channelName)
+		if !ok {
+			return "", false
+		}
+
+		return cid.ToString(), true
+	}
+}
+
 // HasAdminRole determines if the message author is an authorized bot admin (not super-admin)
 func HasAdminRole(logger logging.Logger, msg cmdhandler.Message, adminRole string, session *etfapi.Session) bool {
 	if adminRole == "" {
@@ -3,6 +3,7 @@ package msghandler
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v5/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v12/bot"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/httpclient"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/request"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
@@ -38,6 +40,7 @@ type dependencies interface {
 	DebugHandler() *cmdhandler.CommandHandler
 	AdminHandler() *cmdhandler.CommandHandler
 	MessageRateLimiter() *rate.Limiter
+	HTTPClient() httpclient.HTTPClient
 	BotSession() *etfapi.Session
 	Census() *census.Census
 }
@@ -125,7 +128,7 @@ func (h *handlers) attemptConfigAndAdminHandlers(msg cmdhandler.Message, cmdIndi
 	level.Debug(logger).Message("admin trying to config")
 
 	level.Info(logger).Message("processing debug command", "cmdContent", fmt.Sprintf("%q", content))
-	resp, err := h.deps.DebugHandler().HandleMessage(cmdhandler.NewWithContents(msg, content))
+	resp, err := h.deps.DebugHandler().HandleMessage(NewWithContents(msg, content))
 	if err == nil {
 		return resp, nil
 	}
@@ -140,7 +143,7 @@ func (h *handlers) attemptConfigAndAdminHandlers(msg cmdhandler.Message, cmdIndi
 
 	cmdContent := h.deps.ConfigHandler().CommandIndicator() + strings.TrimPrefix(content, cmdIndicator)
 	level.Info(logger).Message("processing command", "cmdContent", fmt.Sprintf("%q", cmdContent), "rawCmd", fmt.Sprintf("%q", content))
-	resp, err = h.deps.ConfigHandler().HandleMessage(cmdhandler.NewWithContents(msg, cmdContent))
+	resp, err = h.deps.ConfigHandler().HandleMessage(NewWithContents(msg, cmdContent))
 
 	if err == nil {
 		return resp, nil
@@ -152,7 +155,7 @@ func (h *handlers) attemptConfigAndAdminHandlers(msg cmdhandler.Message, cmdIndi
 
 	level.Debug(logger).Message("admin trying to admin")
 	cmdContent = h.deps.AdminHandler().CommandIndicator() + strings.TrimPrefix(content, cmdIndicator)
-	return h.deps.AdminHandler().HandleMessage(cmdhandler.NewWithContents(msg, cmdContent))
+	return h.deps.AdminHandler().HandleMessage(NewWithContents(msg, cmdContent))
 }
 
 func (h *handlers) handleMessage(p *etfapi.Payload, req wsclient.WSMessage, respChan chan<- wsclient.WSMessage) snowflake.Snowflake {
@@ -209,7 +212,7 @@ func (h *handlers) handleMessage(p *etfapi.Payload, req wsclient.WSMessage, resp
 	if err != nil && (err == ErrUnauthorized || err == parser.ErrUnknownCommand) {
 		level.Debug(logger).Message("admin not successful; processing as real message")
 		cmdContent := h.deps.CommandHandler().CommandIndicator() + strings.TrimPrefix(content, cmdIndicator)
-		resp, err = h.deps.CommandHandler().HandleMessage(cmdhandler.NewWithContents(msg, cmdContent))
+		resp, err = h.deps.CommandHandler().HandleMessage(NewWithContents(msg, cmdContent))
 	}
 
 	if err == ErrNoResponse || err == parser.ErrUnknownCommand {
@@ -218,7 +221,7 @@ func (h *handlers) handleMessage(p *etfapi.Payload, req wsclient.WSMessage, resp
 
 	if err != nil {
 		level.Error(logger).Err("error handling command", err, "contents", content)
-		resp.IncludeError(err)
+		resp.IncludeError(storage.GetPrinter(req.Ctx, h.deps.GuildAPI(), gid).LocalizeError(err))
 	}
 
 	if resp.HasErrors() {
@@ -245,7 +248,13 @@ func (h *handlers) handleMessage(p *etfapi.Payload, req wsclient.WSMessage, resp
 			return gid
 		}
 
-		sendResp, body, err := h.bot.SendMessage(req.Ctx, sendTo, res.ToMessage())
+		var sendResp *http.Response
+		var body []byte
+		if fr, ok := res.(*FileResponse); ok {
+			sendResp, body, err = h.sendFile(req.Ctx, sendTo, fr)
+		} else {
+			sendResp, body, err = h.bot.SendMessage(req.Ctx, sendTo, res.ToMessage())
+		}
 		if err != nil {
 			var bodyStr string
 			if body != nil {
@@ -0,0 +1,132 @@
+package msghandler
+
+import (
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+)
+
+// quotes are the characters that group words into one argument; any of them closes a quote,
+// since phones and keyboard layouts substitute typographic quotes freely
+var quotes = []rune{'"', '“', '”', '«', '»', '„'}
+
+// FreeTextKeys are the arguments that can be given as `key: text` at the end of a
+// command, taking the rest of the message verbatim as their value; message: is the
+// phrase of announce and grouping, and note: the note of a signup
+var FreeTextKeys = []string{"description", "message", "note"}
+
+const (
+	escape       = '\\'
+	maxTokenEcho = 20
+)
+
+func isQuote(r rune) bool {
+	for _, q := range quotes {
+		if r == q {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// echo is the part of a message shown in an error message, starting at a rune offset
+func echo(msg []rune, from int) string {
+	s := msg[from:]
+	if len(s) > maxTokenEcho {
+		return string(s[:maxTokenEcho]) + "…"
+	}
+	return string(s)
+}
+
+// freeText reports whether an argument starting at offset i is a free-text key, and
+// where its value starts
+func freeText(msg []rune, i int) (string, int, bool) {
+	for _, key := range FreeTextKeys {
+		n := len([]rune(key))
+		if i+n >= len(msg) || msg[i+n] != ':' {
+			continue
+		}
+
+		if strings.EqualFold(string(msg[i:i+n]), key) {
+			return key, i + n + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// Tokenize splits a command into its arguments
+//
+// Arguments are separated by spaces. Quotes group words, also in the middle of an
+// argument (`description="bring food"`), and a backslash makes the next quote, space, or
+// backslash literal. An argument `description:` (or another of FreeTextKeys) takes the
+// rest of the message as is, as `description=...`, so that it does not need quoting.
+//
+// On error, the arguments up to the problem are returned along with it.
+func Tokenize(content string) ([]string, error) {
+	msg := []rune(content)
+	tokens := make([]string, 0, strings.Count(content, " ")+1)
+	buf := make([]rune, 0, len(msg))
+
+	inToken := false
+	quoteStart := -1
+
+	for i := 0; i < len(msg); i++ {
+		r := msg[i]
+
+		switch {
+		case quoteStart < 0 && isSpace(r):
+			if inToken {
+				tokens = append(tokens, string(buf))
+				buf = buf[:0]
+				inToken = false
+			}
+			continue
+
+		case r == escape && i+1 < len(msg) && (isQuote(msg[i+1]) || msg[i+1] == escape || (quoteStart < 0 && isSpace(msg[i+1]))):
+			i++
+			buf = append(buf, msg[i])
+
+		case isQuote(r) && quoteStart >= 0:
+			quoteStart = -1
+
+		case isQuote(r):
+			quoteStart = i
+
+		case !inToken:
+			if key, start, ok := freeText(msg, i); ok {
+				tokens = append(tokens, key+"="+strings.TrimSpace(string(msg[start:])))
+				return tokens, nil
+			}
+			buf = append(buf, r)
+
+		default:
+			buf = append(buf, r)
+		}
+
+		inToken = true
+	}
+
+	if quoteStart >= 0 {
+		return tokens, i18n.NewError("err.unmatched_quote", quoteStart+1, echo(msg, quoteStart))
+	}
+
+	if inToken {
+		tokens = append(tokens, string(buf))
+	}
+
+	return tokens, nil
+}
+
+// NewWithContents clones a message with new contents, like cmdhandler.NewWithContents,
+// but split into arguments by Tokenize
+func NewWithContents(m cmdhandler.Message, contents string) cmdhandler.Message {
+	tokens, err := Tokenize(contents)
+	return cmdhandler.NewWithTokens(m, tokens, err)
+}
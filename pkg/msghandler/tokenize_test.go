@@ -0,0 +1,73 @@
+package msghandler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+		wantErr error
+	}{
+		{content: "", want: []string{}},
+		{content: "  \t ", want: []string{}},
+		{content: "vAA dps", want: []string{"vAA", "dps"}},
+		{content: "  vAA \t dps  ", want: []string{"vAA", "dps"}},
+		{content: `"Raid Lead" dps`, want: []string{"Raid Lead", "dps"}},
+		{content: `adminrole="Raid Lead"`, want: []string{"adminrole=Raid Lead"}},
+		{content: `a"b c"d e`, want: []string{"ab cd", "e"}},
+		{content: `"" dps`, want: []string{"", "dps"}},
+		{content: `“Raid Lead” «Core Team» „x y"`, want: []string{"Raid Lead", "Core Team", "x y"}},
+		{content: `note="say \"hi\""`, want: []string{`note=say "hi"`}},
+		{content: `note=\"hi\"`, want: []string{`note="hi"`}},
+		{content: `Raid\ Lead dps`, want: []string{"Raid Lead", "dps"}},
+		{content: `"Raid\ Lead"`, want: []string{`Raid\ Lead`}},
+		{content: `a\\b`, want: []string{`a\b`}},
+		{content: `a\b c\`, want: []string{`a\b`, `c\`}},
+		{content: `a\\"b c"`, want: []string{`a\b c`}},
+		{content: `create vAA description: bring "food", 10 min early `, want: []string{"create", "vAA", `description=bring "food", 10 min early`}},
+		{content: `announce vAA Message:go go`, want: []string{"announce", "vAA", "message=go go"}},
+		{content: `signup vAA dps note:`, want: []string{"signup", "vAA", "dps", "note="}},
+		{content: `xnote: a`, want: []string{"xnote:", "a"}},
+		{content: `note :a`, want: []string{"note", ":a"}},
+		{
+			content: `create "Raid Lead`,
+			want:    []string{"create"},
+			wantErr: i18n.NewError("err.unmatched_quote", 8, `"Raid Lead`),
+		},
+		{
+			content: `a "b" "c d e f g h i j k l m`,
+			want:    []string{"a", "b"},
+			wantErr: i18n.NewError("err.unmatched_quote", 7, `"c d e f g h i j k l…`),
+		},
+		{
+			content: `say \"hi`,
+			want:    []string{"say", `"hi`},
+		},
+		{
+			content: `note="say \"hi`,
+			want:    []string{},
+			wantErr: i18n.NewError("err.unmatched_quote", 6, `"say \"hi`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got, err := Tokenize(tt.content)
+
+			switch {
+			case err == nil && tt.wantErr == nil:
+			case err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error():
+				t.Errorf("Tokenize(%q) error = %v, want %v", tt.content, err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
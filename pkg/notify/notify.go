@@ -0,0 +1,387 @@
+package notify
+
+//go:generate easyjson notify.go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+	"golang.org/x/time/rate"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/bot"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/httpclient"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// Kinds of notices; which of them a member gets depends on their storage.NotifyPreference
+const (
+	KindSignup     = "signup"
+	KindPromotion  = "promotion"
+	KindCancel     = "cancel"
+	KindReminder   = "reminder"
+	KindReschedule = "reschedule"
+
+	// KindPrivate notices, such as secrets, are sent whatever the member's preference and
+	// must not have a FallbackChannel
+	KindPrivate = "private"
+)
+
+// ErrNotConnected is the error returned when sending before ConnectToBot
+var ErrNotConnected = errors.New("not connected to a bot")
+
+// ErrDMClosed is the error returned, unwrapped, when discord refuses a direct message to a
+// member, usually because they do not accept them from server members
+var ErrDMClosed = errors.New("direct messages are closed")
+
+// ErrDMFailed is the error returned when a direct message could not be sent for another reason
+var ErrDMFailed = errors.New("direct message failed")
+
+// maxAttempts is how many times a notice is sent when discord answers that it is rate limited
+const maxAttempts = 3
+
+// rateLimitedError is the error returned when discord answers 429, with how long it asked to wait
+type rateLimitedError struct {
+	retryAfter time.Duration
+}
+
+func (e rateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited by discord; retry after %s", e.retryAfter)
+}
+
+// rateLimited reads the Retry-After of a 429 response, in seconds, or a second if it is missing
+func rateLimited(resp *http.Response) rateLimitedError {
+	secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	if err != nil || secs <= 0 {
+		secs = 1
+	}
+	return rateLimitedError{retryAfter: time.Duration(secs * float64(time.Second))}
+}
+
+type dependencies interface {
+	Logger() logging.Logger
+	ProfileAPI() storage.ProfileAPI
+	HTTPClient() httpclient.HTTPClient
+	MessageRateLimiter() *rate.Limiter
+	Census() *census.Census
+}
+
+// Notice is a message for one member of a guild, by their user mention
+//
+// FallbackChannel is where the notice is posted instead, mentioning the member, when they
+// do not accept direct messages; notices without one are dropped in that case
+type Notice struct {
+	GuildID         snowflake.Snowflake
+	User            string
+	Kind            string
+	Text            string
+	FallbackChannel snowflake.Snowflake
+}
+
+// Options provides a way to pass configuration to NewNotifier
+//
+// - QueueSize is the number of notices that can wait to be sent (defaults to 100)
+// - Color is the embed color of the messages
+type Options struct {
+	QueueSize int
+	Color     int
+}
+
+// Notifier is the api for sending notices to the members who asked for them by direct message
+type Notifier interface {
+	ConnectToBot(bot.DiscordBot)
+	Notify(ctx context.Context, notices ...Notice)
+	Send(ctx context.Context, nt Notice) error
+	Run(ctx context.Context) error
+}
+
+type notifier struct {
+	bot   bot.DiscordBot
+	deps  dependencies
+	queue chan queuedNotice
+	color int
+
+	// dmChannels caches the direct message channel of each user, which discord keeps
+	dmLock     *sync.Mutex
+	dmChannels map[snowflake.Snowflake]snowflake.Snowflake
+}
+
+// NewNotifier creates a new Notifier object
+func NewNotifier(deps dependencies, opts Options) Notifier {
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+
+	return &notifier{
+		deps:       deps,
+		queue:      make(chan queuedNotice, queueSize),
+		color:      opts.Color,
+		dmLock:     &sync.Mutex{},
+		dmChannels: map[snowflake.Snowflake]snowflake.Snowflake{},
+	}
+}
+
+func (n *notifier) ConnectToBot(b bot.DiscordBot) {
+	n.bot = b
+}
+
+// Wants reports whether a member with a preference gets notices of a kind
+func Wants(pref storage.NotifyPreference, kind string) bool {
+	if kind == KindPrivate {
+		return true
+	}
+
+	switch pref {
+	case storage.NotifyOn:
+		return true
+	case storage.NotifyPromotions:
+		return kind == KindPromotion
+	default:
+		return false
+	}
+}
+
+// queuedNotice is a notice waiting to be sent, with the number of times it was tried
+type queuedNotice struct {
+	Notice
+	attempts int
+}
+
+// Notify queues notices to be sent without waiting for them
+//
+// It should be called only after the change has been committed
+func (n *notifier) Notify(ctx context.Context, notices ...Notice) {
+	for _, nt := range notices {
+		n.enqueue(ctx, queuedNotice{Notice: nt})
+	}
+}
+
+func (n *notifier) enqueue(ctx context.Context, q queuedNotice) {
+	select {
+	case n.queue <- q:
+	default:
+		level.Error(logging.WithContext(ctx, n.deps.Logger())).Message("notice queue is full; dropping notice", "guild_id", q.GuildID.ToString(), "kind", q.Kind)
+	}
+}
+
+// Send sends a notice now instead of queueing it, for callers that must know whether it
+// arrived, like those sending secrets; the error is ErrDMClosed when the member does not
+// accept direct messages and the notice has no FallbackChannel
+func (n *notifier) Send(ctx context.Context, nt Notice) error {
+	for attempt := 1; ; attempt++ {
+		err := n.send(ctx, nt)
+
+		rl, ok := err.(rateLimitedError)
+		if !ok || attempt >= maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rl.retryAfter):
+		}
+	}
+}
+
+// Run sends queued notices until the context is canceled
+func (n *notifier) Run(ctx context.Context) error {
+	level.Info(n.deps.Logger()).Message("starting notifier")
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(n.deps.Logger()).Message("stopping notifier")
+			return ctx.Err()
+		case q := <-n.queue:
+			n.deliver(ctx, q)
+		}
+	}
+}
+
+// deliver sends a queued notice, waiting and queueing it again when discord is rate limiting
+func (n *notifier) deliver(ctx context.Context, q queuedNotice) {
+	logger := logging.WithContext(ctx, n.deps.Logger())
+
+	q.attempts++
+	err := n.send(ctx, q.Notice)
+
+	rl, ok := err.(rateLimitedError)
+	switch {
+	case err == nil:
+	case ok && q.attempts < maxAttempts:
+		level.Info(logger).Message("rate limited; queueing notice again", "guild_id", q.GuildID.ToString(), "kind", q.Kind, "retry_after", rl.retryAfter.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rl.retryAfter):
+		}
+
+		n.enqueue(ctx, q)
+	default:
+		level.Error(logger).Err("could not send notice", err, "guild_id", q.GuildID.ToString(), "user", q.User, "kind", q.Kind, "attempts", q.attempts)
+	}
+}
+
+// send sends a notice to its member if they want it, or to its FallbackChannel if they do not
+// accept direct messages
+func (n *notifier) send(ctx context.Context, nt Notice) error {
+	ctx, span := n.deps.Census().StartSpan(ctx, "notifier.send", "guild_id", nt.GuildID.ToString())
+	defer span.End()
+
+	logger := logging.WithContext(ctx, n.deps.Logger())
+
+	if n.bot == nil {
+		return ErrNotConnected
+	}
+
+	uid, err := snowflake.FromString(strings.TrimLeft(strings.TrimSuffix(nt.User, ">"), "<@!"))
+	if err != nil {
+		level.Debug(logger).Message("notice is not for a discord user", "user", nt.User)
+		return nil
+	}
+
+	prof, err := storage.GetProfile(ctx, n.deps.ProfileAPI(), nt.GuildID, uid)
+	if err != nil {
+		return errors.Wrap(err, "could not load notify preference", "user_id", uid.ToString())
+	}
+
+	if !Wants(prof.Notify, nt.Kind) {
+		return nil
+	}
+
+	err = n.sendDM(ctx, uid, nt.Text)
+	if err == nil {
+		level.Debug(logger).Message("sent notice", "user_id", uid.ToString(), "kind", nt.Kind)
+		return nil
+	}
+
+	if err != ErrDMClosed || nt.FallbackChannel == 0 {
+		return err
+	}
+
+	level.Info(logger).Message("direct messages closed; posting notice in channel", "user_id", uid.ToString(), "channel_id", nt.FallbackChannel.ToString())
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To:          nt.User,
+		Description: nt.Text,
+	}
+	r.SetColor(n.color)
+
+	_, err = n.sendMessage(ctx, nt.FallbackChannel, r)
+	return err
+}
+
+func (n *notifier) sendDM(ctx context.Context, uid snowflake.Snowflake, text string) error {
+	cid, err := n.dmChannel(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		Description: text,
+	}
+	r.SetColor(n.color)
+
+	resp, err := n.sendMessage(ctx, cid, r)
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		return ErrDMClosed
+	}
+
+	return err
+}
+
+// sendMessage posts a message to a channel within the bot's rate limit, returning a
+// rateLimitedError if discord asks to try again later
+func (n *notifier) sendMessage(ctx context.Context, cid snowflake.Snowflake, r *cmdhandler.SimpleEmbedResponse) (*http.Response, error) {
+	if err := n.deps.MessageRateLimiter().Wait(ctx); err != nil {
+		return nil, errors.Wrap(err, "error waiting for ratelimiting")
+	}
+
+	resp, body, err := n.bot.SendMessage(ctx, cid, r.ToMessage())
+	if resp == nil {
+		return nil, errors.Wrap(err, "could not send message", "channel_id", cid.ToString())
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return resp, rateLimited(resp)
+	}
+
+	return resp, errors.Wrap(err, "could not send message", "channel_id", cid.ToString(), "resp_body", string(body), "status_code", resp.StatusCode)
+}
+
+// dmChannelRequest is the body that opens a direct message channel
+//
+//easyjson:json
+type dmChannelRequest struct {
+	RecipientID string `json:"recipient_id"`
+}
+
+// dmChannel is the part of discord's channel object needed to send to it
+//
+//easyjson:json
+type dmChannel struct {
+	ID string `json:"id"`
+}
+
+func (n *notifier) dmChannel(ctx context.Context, uid snowflake.Snowflake) (snowflake.Snowflake, error) {
+	n.dmLock.Lock()
+	defer n.dmLock.Unlock()
+
+	if cid, ok := n.dmChannels[uid]; ok {
+		return cid, nil
+	}
+
+	b, err := dmChannelRequest{RecipientID: uid.ToString()}.MarshalJSON()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not marshal channel request")
+	}
+
+	if err = n.deps.MessageRateLimiter().Wait(ctx); err != nil {
+		return 0, errors.Wrap(err, "error waiting for ratelimiting")
+	}
+
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+	resp, body, err := n.deps.HTTPClient().PostBody(ctx, fmt.Sprintf("%s/users/@me/channels", n.bot.Config().APIURL), &header, bytes.NewReader(b))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open direct message channel")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return 0, ErrDMClosed
+	case http.StatusTooManyRequests:
+		return 0, rateLimited(resp)
+	default:
+		return 0, errors.Wrap(ErrDMFailed, "non-200 response", "status_code", resp.StatusCode, "resp_body", string(body))
+	}
+
+	var ch dmChannel
+	if err = ch.UnmarshalJSON(body); err != nil {
+		return 0, errors.Wrap(err, "could not read direct message channel")
+	}
+
+	cid, err := snowflake.FromString(ch.ID)
+	if err != nil {
+		return 0, errors.Wrap(err, "bad direct message channel id")
+	}
+
+	n.dmChannels[uid] = cid
+	return cid, nil
+}
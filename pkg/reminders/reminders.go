@@ -0,0 +1,216 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/etfapi"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/notify"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type dependencies interface {
+	Logger() logging.Logger
+	TrialAPI() storage.TrialAPI
+	GuildAPI() storage.GuildAPI
+	BotSession() *etfapi.Session
+	Notifier() notify.Notifier
+	Census() *census.Census
+}
+
+// Options provides a way to pass configuration to NewReminder
+//
+// - Interval is the time between checks in Run (0 disables them)
+// - Lead is how long before the start of an event its main group is reminded
+type Options struct {
+	Interval time.Duration
+	Lead     time.Duration
+}
+
+// Reminder is the api for sending the notices that are due at a time rather than on a
+// command: reminders before events start, and promotions when a locked roster's
+// confirmation deadline passes
+type Reminder interface {
+	Run(ctx context.Context) error
+	RemindAll(ctx context.Context) (int, error)
+	RemindGuild(ctx context.Context, gid string) (int, error)
+}
+
+type reminder struct {
+	deps     dependencies
+	interval time.Duration
+	lead     time.Duration
+	now      func() time.Time
+}
+
+// NewReminder creates a new Reminder object
+func NewReminder(deps dependencies, opts Options) Reminder {
+	return &reminder{
+		deps:     deps,
+		interval: opts.Interval,
+		lead:     opts.Lead,
+		now:      time.Now,
+	}
+}
+
+// Run checks every guild on the configured interval until the context is canceled
+func (r *reminder) Run(ctx context.Context) error {
+	if r.interval <= 0 {
+		level.Info(r.deps.Logger()).Message("reminders disabled")
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	level.Info(r.deps.Logger()).Message("starting reminders", "interval", r.interval.String(), "lead", r.lead.String())
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(r.deps.Logger()).Message("stopping reminders")
+			return ctx.Err()
+		case <-ticker.C:
+			sent, err := r.RemindAll(ctx)
+			if err != nil {
+				level.Error(r.deps.Logger()).Err("reminders failed", err)
+				continue
+			}
+			if sent > 0 {
+				level.Info(r.deps.Logger()).Message("reminders queued", "notices", sent)
+			}
+		}
+	}
+}
+
+// RemindAll queues the notices due in every guild in the database
+func (r *reminder) RemindAll(ctx context.Context) (int, error) {
+	ctx, span := r.deps.Census().StartSpan(ctx, "reminder.RemindAll")
+	defer span.End()
+
+	guilds, err := r.deps.GuildAPI().AllGuilds(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list guilds")
+	}
+
+	total := 0
+	for _, gid := range guilds {
+		sent, err := r.RemindGuild(ctx, gid)
+		total += sent
+		if err != nil {
+			return total, errors.Wrap(err, "could not remind guild", "guild_id", gid)
+		}
+	}
+
+	return total, nil
+}
+
+// due reports whether the main group of a trial is to be reminded now, and whether the
+// confirmation deadline of its locked roster passed without being settled
+func (r *reminder) due(ctx context.Context, trial storage.Trial, now time.Time) (remind, settle bool) {
+	remind = commands.DueReminder(ctx, trial, now, r.lead)
+
+	lr := trial.GetLockedRoster(ctx)
+	settle = !lr.IsZero() && !lr.Settled && !now.Before(lr.Deadline) && trial.GetState(ctx) != storage.TrialStateCanceled
+
+	return remind, settle
+}
+
+// anyDue reports whether any trial of a guild has notices due, in a read-only transaction,
+// so that the guilds with none do not take the write lock on every check
+func (r *reminder) anyDue(ctx context.Context, gid string, now time.Time) (bool, error) {
+	t, err := r.deps.TrialAPI().NewTransaction(ctx, gid, false)
+	if err != nil {
+		return false, errors.Wrap(err, "could not start trials transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	for _, trial := range t.GetTrials(ctx) {
+		if remind, settle := r.due(ctx, trial, now); remind || settle {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RemindGuild queues the notices due in a single guild
+//
+// Each trial is marked once its notices are queued, so that they are sent only once
+// even though the check runs repeatedly
+func (r *reminder) RemindGuild(ctx context.Context, gid string) (int, error) {
+	ctx, span := r.deps.Census().StartSpan(ctx, "reminder.RemindGuild", "guild_id", gid)
+	defer span.End()
+
+	logger := logging.WithContext(ctx, r.deps.Logger())
+
+	sgid, err := snowflake.FromString(gid)
+	if err != nil {
+		return 0, errors.Wrap(err, "bad guild id")
+	}
+
+	now := r.now()
+
+	due, err := r.anyDue(ctx, gid, now)
+	if err != nil || !due {
+		return 0, err
+	}
+
+	p := storage.GetPrinter(ctx, r.deps.GuildAPI(), sgid)
+
+	t, err := r.deps.TrialAPI().NewTransaction(ctx, gid, true)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not start trials transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	att := commands.NewPastAttendance(t)
+
+	var notices []notify.Notice
+
+	// the trials are checked again, since they can change between the transactions
+	for _, trial := range t.GetTrials(ctx) {
+		remind, settle := r.due(ctx, trial, now)
+
+		if remind {
+			notices = append(notices, commands.ReminderNotices(ctx, r.deps.BotSession(), p, sgid, trial, commands.NewRosterRules(ctx, r.deps.BotSession(), sgid, att, trial, now))...)
+			trial.SetRemindedAt(ctx, now)
+		}
+
+		if settle {
+			notices = append(notices, commands.DeadlineNotices(ctx, r.deps.BotSession(), p, sgid, trial, commands.NewRosterRules(ctx, r.deps.BotSession(), sgid, att, trial, now))...)
+			lr := trial.GetLockedRoster(ctx)
+			lr.Settled = true
+			trial.SetLockedRoster(ctx, lr)
+		}
+
+		if !remind && !settle {
+			continue
+		}
+
+		if err := t.SaveTrial(ctx, trial); err != nil {
+			return 0, errors.Wrap(err, "could not save trial", "trial_name", trial.GetName(ctx))
+		}
+	}
+
+	if err := t.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "could not commit reminders")
+	}
+
+	if len(notices) > 0 {
+		level.Info(logger).Message("queued notices", "notices", len(notices))
+		r.deps.Notifier().Notify(ctx, notices...)
+	}
+
+	return len(notices), nil
+}
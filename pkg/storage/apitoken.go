@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// NewAPIToken generates a random API token and the hash that should be stored for it
+func NewAPIToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", errors.Wrap(err, "could not generate token")
+	}
+
+	token = hex.EncodeToString(b)
+	return token, HashAPIToken(token), nil
+}
+
+// HashAPIToken returns the stored form of an API token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckAPIToken reports whether the token is the current API token for the guild
+//
+// NOTE: this cannot be called after another transaction has been started
+func CheckAPIToken(ctx context.Context, gapi GuildAPI, gid, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	t, err := gapi.NewTransaction(ctx, false)
+	if err != nil {
+		return false, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	g, err := t.GetGuild(ctx, gid)
+	if err == ErrGuildNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "unable to find guild")
+	}
+
+	stored := g.GetAPITokenHash(ctx)
+	if stored == "" {
+		return false, nil
+	}
+
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(HashAPIToken(token))) == 1, nil
+}
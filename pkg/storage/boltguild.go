@@ -20,6 +20,76 @@ func (g *boltGuild) SetName(ctx context.Context, name string) {
 	g.protoGuild.Name = name
 }
 
+func (g *boltGuild) GetAPITokenHash(ctx context.Context) string {
+	return g.protoGuild.ApiTokenHash
+}
+
+func (g *boltGuild) SetAPITokenHash(ctx context.Context, hash string) {
+	g.protoGuild.ApiTokenHash = hash
+}
+
+func (g *boltGuild) GetCalendarKey(ctx context.Context) string {
+	return g.protoGuild.CalendarKey
+}
+
+func (g *boltGuild) SetCalendarKey(ctx context.Context, key string) {
+	g.protoGuild.CalendarKey = key
+}
+
+func (g *boltGuild) GetWebhooks(ctx context.Context) []Webhook {
+	hooks := make([]Webhook, 0, len(g.protoGuild.Webhooks))
+	for _, pw := range g.protoGuild.Webhooks {
+		if pw == nil {
+			continue
+		}
+
+		hooks = append(hooks, Webhook{
+			URL:    pw.Url,
+			Events: append([]string(nil), pw.Events...),
+			Secret: pw.Secret,
+		})
+	}
+	return hooks
+}
+
+func (g *boltGuild) SetWebhooks(ctx context.Context, hooks []Webhook) {
+	g.protoGuild.Webhooks = make([]*ProtoWebhook, 0, len(hooks))
+	for _, w := range hooks {
+		g.protoGuild.Webhooks = append(g.protoGuild.Webhooks, &ProtoWebhook{
+			Url:    w.URL,
+			Events: append([]string(nil), w.Events...),
+			Secret: w.Secret,
+		})
+	}
+}
+
+func (g *boltGuild) GetPresets(ctx context.Context) []Preset {
+	presets := make([]Preset, 0, len(g.protoGuild.Presets))
+	for _, pp := range g.protoGuild.Presets {
+		if pp == nil {
+			continue
+		}
+
+		presets = append(presets, Preset{
+			Name:        pp.Name,
+			Description: pp.Description,
+			Roles:       pp.Roles,
+		})
+	}
+	return presets
+}
+
+func (g *boltGuild) SetPresets(ctx context.Context, presets []Preset) {
+	g.protoGuild.Presets = make([]*ProtoPreset, 0, len(presets))
+	for _, p := range presets {
+		g.protoGuild.Presets = append(g.protoGuild.Presets, &ProtoPreset{
+			Name:        p.Name,
+			Description: p.Description,
+			Roles:       p.Roles,
+		})
+	}
+}
+
 func (g *boltGuild) Serialize(ctx context.Context) ([]byte, error) {
 	_, span := g.census.StartSpan(ctx, "boltGuild.Serialize")
 	defer span.End()
@@ -32,13 +102,18 @@ func (g *boltGuild) GetSettings(ctx context.Context) GuildSettings {
 	defer span.End()
 
 	s := GuildSettings{
-		census:          g.census,
-		ControlSequence: g.protoGuild.CommandIndicator,
-		AnnounceChannel: g.protoGuild.AnnounceChannel,
-		AdminChannel:    g.protoGuild.AdminChannel,
-		SignupChannel:   g.protoGuild.SignupChannel,
-		AnnounceTo:      g.protoGuild.AnnounceTo,
-		AdminRole:       g.protoGuild.AdminRole,
+		census:            g.census,
+		ControlSequence:   g.protoGuild.CommandIndicator,
+		AnnounceChannel:   g.protoGuild.AnnounceChannel,
+		AnnounceChannelID: g.protoGuild.AnnounceChannelId,
+		AdminChannel:      g.protoGuild.AdminChannel,
+		AdminChannelID:    g.protoGuild.AdminChannelId,
+		SignupChannel:     g.protoGuild.SignupChannel,
+		SignupChannelID:   g.protoGuild.SignupChannelId,
+		AnnounceTo:        g.protoGuild.AnnounceTo,
+		AdminRole:         g.protoGuild.AdminRole,
+		AdminRoleName:     g.protoGuild.AdminRoleName,
+		Locale:            g.protoGuild.Locale,
 	}
 
 	if g.protoGuild.ShowAfterSignup {
@@ -61,10 +136,15 @@ func (g *boltGuild) SetSettings(ctx context.Context, s GuildSettings) {
 
 	g.protoGuild.CommandIndicator = s.ControlSequence
 	g.protoGuild.AnnounceChannel = s.AnnounceChannel
+	g.protoGuild.AnnounceChannelId = s.AnnounceChannelID
 	g.protoGuild.AdminChannel = s.AdminChannel
+	g.protoGuild.AdminChannelId = s.AdminChannelID
 	g.protoGuild.SignupChannel = s.SignupChannel
+	g.protoGuild.SignupChannelId = s.SignupChannelID
 	g.protoGuild.AnnounceTo = s.AnnounceTo
 	g.protoGuild.AdminRole = s.AdminRole
+	g.protoGuild.AdminRoleName = s.AdminRoleName
+	g.protoGuild.Locale = s.Locale
 
 	g.protoGuild.ShowAfterSignup = s.ShowAfterSignup == "true"
 	g.protoGuild.ShowAfterWithdraw = s.ShowAfterWithdraw == "true"
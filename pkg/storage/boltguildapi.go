@@ -15,6 +15,11 @@ var ErrGuildNotExist = errors.New("guild does not exist")
 
 var settingsBucket = []byte("GuildRecords")
 
+// isTrialBucket reports whether a top-level bucket holds a guild's trials rather than bot-wide records
+func isTrialBucket(name []byte) bool {
+	return !bytes.Equal(name, settingsBucket) && !bytes.Equal(name, quarantineBucket) && !bytes.Equal(name, profilesBucket)
+}
+
 type boltGuildAPI struct {
 	db         *bolt.DB
 	census     *census.Census
@@ -55,7 +60,7 @@ func (b *boltGuildAPI) AllGuilds(ctx context.Context) ([]string, error) {
 
 	err := b.db.View(func(tx *bolt.Tx) error {
 		return tx.ForEach(func(bucketName []byte, b *bolt.Bucket) error {
-			if !bytes.Equal(bucketName, settingsBucket) {
+			if isTrialBucket(bucketName) {
 				guilds = append(guilds, string(bucketName))
 			}
 
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+)
+
+// profilesBucket holds a bucket of member profiles for each guild
+var profilesBucket = []byte("UserProfiles")
+
+type boltProfileAPI struct {
+	db     *bolt.DB
+	census *census.Census
+}
+
+// NewBoltProfileAPI constructs a boltDB-backed ProfileAPI
+func NewBoltProfileAPI(ctx context.Context, db *bolt.DB, c *census.Census) (ProfileAPI, error) {
+	_, span := c.StartSpan(ctx, "boltProfileAPI.NewBoltProfileAPI")
+	defer span.End()
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(profilesBucket)
+		if err != nil {
+			return errors.Wrap(err, "could not create bucket")
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltProfileAPI{
+		db:     db,
+		census: c,
+	}, nil
+}
+
+func (b *boltProfileAPI) NewTransaction(ctx context.Context, guild string, writable bool) (ProfileAPITx, error) {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPI.NewTransaction")
+	defer span.End()
+
+	tx, err := b.db.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltProfileAPITx{
+		guild:  []byte(guild),
+		tx:     tx,
+		census: b.census,
+	}, nil
+}
+
+type boltProfileAPITx struct {
+	guild  []byte
+	tx     *bolt.Tx
+	census *census.Census
+}
+
+func (b *boltProfileAPITx) Commit(ctx context.Context) error {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPITx.Commit")
+	defer span.End()
+
+	return b.tx.Commit()
+}
+
+func (b *boltProfileAPITx) Rollback(ctx context.Context) error {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPITx.Rollback")
+	defer span.End()
+
+	err := b.tx.Rollback()
+	if err != nil && err != bolt.ErrTxClosed {
+		return err
+	}
+	return nil
+}
+
+// bucket is the guild's profile bucket, or nil if no member of the guild saved a profile yet
+func (b *boltProfileAPITx) bucket() *bolt.Bucket {
+	return b.tx.Bucket(profilesBucket).Bucket(b.guild)
+}
+
+func (b *boltProfileAPITx) GetProfile(ctx context.Context, user string) (Profile, error) {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPITx.GetProfile")
+	defer span.End()
+
+	bucket := b.bucket()
+	if bucket == nil {
+		return Profile{}, nil
+	}
+
+	val := bucket.Get([]byte(user))
+	if val == nil {
+		return Profile{}, nil
+	}
+
+	protoProfile := ProtoProfile{}
+	if err := proto.Unmarshal(val, &protoProfile); err != nil {
+		return Profile{}, errors.Wrap(err, "profile record is corrupt")
+	}
+
+	return Profile{
+		Role:      protoProfile.Role,
+		Character: protoProfile.Character,
+		Class:     protoProfile.Class,
+		Notify:    NotifyPreference(protoProfile.Notify),
+	}, nil
+}
+
+func (b *boltProfileAPITx) SaveProfile(ctx context.Context, user string, p Profile) error {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPITx.SaveProfile")
+	defer span.End()
+
+	bucket, err := b.tx.Bucket(profilesBucket).CreateBucketIfNotExists(b.guild)
+	if err != nil {
+		return errors.Wrap(err, "could not create bucket")
+	}
+
+	serial, err := proto.Marshal(&ProtoProfile{
+		Role:      p.Role,
+		Character: p.Character,
+		Class:     p.Class,
+		Notify:    string(p.Notify),
+	})
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put([]byte(user), serial)
+}
+
+func (b *boltProfileAPITx) DeleteProfile(ctx context.Context, user string) error {
+	_, span := b.census.StartSpan(ctx, "boltProfileAPITx.DeleteProfile")
+	defer span.End()
+
+	bucket := b.bucket()
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.Delete([]byte(user))
+}
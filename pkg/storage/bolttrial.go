@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 const (
@@ -40,10 +43,128 @@ func (b *boltTrial) GetSignupChannel(ctx context.Context) string {
 	return b.protoTrial.SignupChannel
 }
 
+func (b *boltTrial) GetAnnounceChannelID(ctx context.Context) string {
+	return b.protoTrial.AnnounceChannelId
+}
+
+func (b *boltTrial) GetSignupChannelID(ctx context.Context) string {
+	return b.protoTrial.SignupChannelId
+}
+
 func (b *boltTrial) GetState(ctx context.Context) TrialState {
 	return TrialState(b.protoTrial.State)
 }
 
+func prettyStartTime(p i18n.Printer, t time.Time) string {
+	if t.IsZero() {
+		return p.Sprintf("trial.unscheduled")
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
+
+func unixTime(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0).UTC()
+}
+
+func unixTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (b *boltTrial) GetCreatedAt(ctx context.Context) time.Time {
+	return unixTime(b.protoTrial.CreatedAt)
+}
+
+func (b *boltTrial) GetStateChangedAt(ctx context.Context) time.Time {
+	return unixTime(b.protoTrial.StateChangedAt)
+}
+
+// GetStartTime returns the zero time for unscheduled trials
+func (b *boltTrial) GetStartTime(ctx context.Context) time.Time {
+	return unixTime(b.protoTrial.StartTime)
+}
+
+func (b *boltTrial) GetDuration(ctx context.Context) time.Duration {
+	return time.Duration(b.protoTrial.Duration) * time.Second
+}
+
+// GetReserveRelease is how long before the start unclaimed reserved slots open to everyone
+func (b *boltTrial) GetReserveRelease(ctx context.Context) time.Duration {
+	return time.Duration(b.protoTrial.ReserveRelease) * time.Second
+}
+
+// GetRosterPolicy returns first-come for trials that never had a policy set
+func (b *boltTrial) GetRosterPolicy(ctx context.Context) RosterPolicy {
+	if b.protoTrial.RosterPolicy == "" {
+		return RosterFirstCome
+	}
+	return RosterPolicy(b.protoTrial.RosterPolicy)
+}
+
+func (b *boltTrial) GetPriorityRoles(ctx context.Context) []string {
+	return b.protoTrial.PriorityRoles
+}
+
+// GetLotterySeed returns 0 until the lottery has been drawn
+func (b *boltTrial) GetLotterySeed(ctx context.Context) int64 {
+	return b.protoTrial.LotterySeed
+}
+
+// GetRosterOrder returns nil until the roster has been locked
+func (b *boltTrial) GetRosterOrder(ctx context.Context) []string {
+	return b.protoTrial.RosterOrder
+}
+
+// GetRemindedAt is the zero time until the members have been reminded of the start
+func (b *boltTrial) GetRemindedAt(ctx context.Context) time.Time {
+	return unixTime(b.protoTrial.RemindedAt)
+}
+
+func (b *boltTrial) GetCancelReason(ctx context.Context) string {
+	return b.protoTrial.CancelReason
+}
+
+// GetRescheduledAt is the zero time unless the start has been moved with !admin reschedule
+func (b *boltTrial) GetRescheduledAt(ctx context.Context) time.Time {
+	return unixTime(b.protoTrial.RescheduledAt)
+}
+
+func (b *boltTrial) GetLockedRoster(ctx context.Context) LockedRoster {
+	lr := LockedRoster{
+		LockedAt:  unixTime(b.protoTrial.LockedAt),
+		Deadline:  unixTime(b.protoTrial.ConfirmDeadline),
+		Signups:   make([]LockedSignup, 0, len(b.protoTrial.LockedSignups)),
+		Confirmed: b.protoTrial.Confirmed,
+		Settled:   b.protoTrial.LockSettled,
+	}
+
+	for _, ls := range b.protoTrial.LockedSignups {
+		lr.Signups = append(lr.Signups, LockedSignup{Name: ls.Name, Role: ls.Role, Overflow: ls.Overflow})
+	}
+
+	return lr
+}
+
+func prettyPolicy(p i18n.Printer, policy RosterPolicy, roleIDs []string, seed int64) string {
+	switch {
+	case policy == RosterTiers && len(roleIDs) > 0:
+		mentions := make([]string, 0, len(roleIDs))
+		for _, rid := range roleIDs {
+			mentions = append(mentions, fmt.Sprintf("<@&%s>", rid))
+		}
+		return fmt.Sprintf("%s (%s)", policy, strings.Join(mentions, " > "))
+	case policy == RosterLottery && seed != 0:
+		return p.Sprintf("trial.lottery_drawn", policy, seed)
+	default:
+		return string(policy)
+	}
+}
+
 func (b *boltTrial) getSignups(ctx context.Context, raw bool) []TrialSignup {
 	_, span := b.census.StartSpan(ctx, "boltTrial.getSignups")
 	defer span.End()
@@ -60,9 +181,10 @@ func (b *boltTrial) getSignups(ctx context.Context, raw bool) []TrialSignup {
 		}
 
 		s = append(s, &boltTrialSignup{
-			name:   name,
-			role:   ps.Role,
-			census: b.census,
+			name:    name,
+			role:    ps.Role,
+			details: protoSignupDetails(ps),
+			census:  b.census,
 		})
 	}
 
@@ -76,6 +198,24 @@ func (b *boltTrial) GetSignups(ctx context.Context) []TrialSignup {
 	return b.getSignups(ctx, false)
 }
 
+func (b *boltTrial) GetSignupHistory(ctx context.Context) []TrialSignup {
+	_, span := b.census.StartSpan(ctx, "boltTrial.GetSignupHistory")
+	defer span.End()
+
+	s := make([]TrialSignup, 0, len(b.protoTrial.Signups))
+	for _, ps := range b.protoTrial.Signups {
+		s = append(s, &boltTrialSignup{
+			name:     userMentionOverflowFix(ps.Name),
+			role:     ps.Role,
+			canceled: ps.State == signupCanceled,
+			details:  protoSignupDetails(ps),
+			census:   b.census,
+		})
+	}
+
+	return s
+}
+
 func (b *boltTrial) GetRoleCounts(ctx context.Context) []RoleCount {
 	ctx, span := b.census.StartSpan(ctx, "boltTrial.GetRoleCounts")
 	defer span.End()
@@ -93,9 +233,15 @@ func (b *boltTrial) GetRoleCounts(ctx context.Context) []RoleCount {
 	for _, rName := range rcNames {
 		r := b.protoTrial.RoleCountMap[rName]
 		s = append(s, &boltRoleCount{
-			role:   r.Name,
-			count:  r.Count,
-			emoji:  r.Emoji,
+			role:    r.Name,
+			count:   r.Count,
+			emoji:   r.Emoji,
+			aliases: r.Aliases,
+			reservation: Reservation{
+				Slots:  r.Reserved,
+				Users:  r.ReservedUsers,
+				RoleID: r.ReservedRole,
+			},
 			census: b.census,
 		})
 	}
@@ -111,30 +257,24 @@ func (b *boltTrial) PrettyRoles(ctx context.Context, indent string) string {
 	lines := make([]string, 0, len(rcs))
 
 	for _, rc := range rcs {
-		lines = append(lines, fmt.Sprintf("%s%s: %d", rc.GetEmoji(ctx), rc.GetRole(ctx), rc.GetCount(ctx)))
+		line := fmt.Sprintf("%s%s: %d", rc.GetEmoji(ctx), rc.GetRole(ctx), rc.GetCount(ctx))
+		if aliases := rc.GetAliases(ctx); len(aliases) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(aliases, ", "))
+		}
+		if res := rc.GetReservation(ctx); !res.IsZero() {
+			line += fmt.Sprintf(" [%d reserved: %s]", res.Slots, strings.Join(reservationHolders(res), ", "))
+		}
+		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n"+indent)
 }
 
-func (b *boltTrial) PrettySettings(ctx context.Context) string {
+func (b *boltTrial) PrettySettings(ctx context.Context, p i18n.Printer) string {
 	ctx, span := b.census.StartSpan(ctx, "boltTrial.PrettySettings")
 	defer span.End()
 
-	return fmt.Sprintf(`
-Event settings:
-
-	- State: '%[4]s',
-	- AnnounceChannel: '#%[1]s',
-	- SignupChannel: '#%[2]s',
-	- AnnounceTo: '%[3]s', 
-	- Roles:
-		%[5]s
-
-Description:
-%s
-
-	`, b.GetAnnounceChannel(ctx), b.GetSignupChannel(ctx), b.GetAnnounceTo(ctx), b.GetState(ctx), b.PrettyRoles(ctx, "    "), b.GetDescription(ctx))
+	return p.Sprintf("trial.settings", b.GetAnnounceChannel(ctx), b.GetSignupChannel(ctx), b.GetAnnounceTo(ctx), b.GetState(ctx), b.PrettyRoles(ctx, "    "), b.GetDescription(ctx), prettyStartTime(p, b.GetStartTime(ctx)), b.GetDuration(ctx), b.GetReserveRelease(ctx), prettyPolicy(p, b.GetRosterPolicy(ctx), b.GetPriorityRoles(ctx), b.GetLotterySeed(ctx)))
 }
 
 func (b *boltTrial) SetName(ctx context.Context, name string) {
@@ -157,10 +297,88 @@ func (b *boltTrial) SetSignupChannel(ctx context.Context, val string) {
 	b.protoTrial.SignupChannel = val
 }
 
+func (b *boltTrial) SetAnnounceChannelID(ctx context.Context, val string) {
+	b.protoTrial.AnnounceChannelId = val
+}
+
+func (b *boltTrial) SetSignupChannelID(ctx context.Context, val string) {
+	b.protoTrial.SignupChannelId = val
+}
+
 func (b *boltTrial) SetState(ctx context.Context, state TrialState) {
+	if b.protoTrial.State != string(state) {
+		b.protoTrial.StateChangedAt = time.Now().Unix()
+	}
 	b.protoTrial.State = string(state)
 }
 
+func (b *boltTrial) SetCreatedAt(ctx context.Context, t time.Time) {
+	b.protoTrial.CreatedAt = unixTimestamp(t)
+}
+
+func (b *boltTrial) SetStateChangedAt(ctx context.Context, t time.Time) {
+	b.protoTrial.StateChangedAt = unixTimestamp(t)
+}
+
+// SetStartTime unschedules the trial when passed the zero time
+func (b *boltTrial) SetStartTime(ctx context.Context, t time.Time) {
+	if t.IsZero() {
+		b.protoTrial.StartTime = 0
+		return
+	}
+	b.protoTrial.StartTime = t.Unix()
+}
+
+func (b *boltTrial) SetDuration(ctx context.Context, d time.Duration) {
+	b.protoTrial.Duration = int64(d / time.Second)
+}
+
+func (b *boltTrial) SetReserveRelease(ctx context.Context, d time.Duration) {
+	b.protoTrial.ReserveRelease = int64(d / time.Second)
+}
+
+func (b *boltTrial) SetRosterPolicy(ctx context.Context, policy RosterPolicy) {
+	b.protoTrial.RosterPolicy = string(policy)
+}
+
+func (b *boltTrial) SetPriorityRoles(ctx context.Context, roleIDs []string) {
+	b.protoTrial.PriorityRoles = append([]string(nil), roleIDs...)
+}
+
+func (b *boltTrial) SetLotterySeed(ctx context.Context, seed int64) {
+	b.protoTrial.LotterySeed = seed
+}
+
+// SetRosterOrder stores the order of the signups of a locked roster; nil unlocks it
+func (b *boltTrial) SetRosterOrder(ctx context.Context, names []string) {
+	b.protoTrial.RosterOrder = append([]string(nil), names...)
+}
+
+func (b *boltTrial) SetRemindedAt(ctx context.Context, t time.Time) {
+	b.protoTrial.RemindedAt = unixTimestamp(t)
+}
+
+func (b *boltTrial) SetCancelReason(ctx context.Context, reason string) {
+	b.protoTrial.CancelReason = reason
+}
+
+func (b *boltTrial) SetRescheduledAt(ctx context.Context, t time.Time) {
+	b.protoTrial.RescheduledAt = unixTimestamp(t)
+}
+
+// SetLockedRoster stores a roster snapshot; the zero LockedRoster unlocks the roster
+func (b *boltTrial) SetLockedRoster(ctx context.Context, lr LockedRoster) {
+	b.protoTrial.LockedAt = unixTimestamp(lr.LockedAt)
+	b.protoTrial.ConfirmDeadline = unixTimestamp(lr.Deadline)
+	b.protoTrial.Confirmed = append([]string(nil), lr.Confirmed...)
+	b.protoTrial.LockSettled = lr.Settled
+
+	b.protoTrial.LockedSignups = make([]*ProtoLockedSignup, 0, len(lr.Signups))
+	for _, ls := range lr.Signups {
+		b.protoTrial.LockedSignups = append(b.protoTrial.LockedSignups, &ProtoLockedSignup{Name: ls.Name, Role: ls.Role, Overflow: ls.Overflow})
+	}
+}
+
 func isSameUser(dbName, argName string) bool {
 	return dbName == argName || userMentionOverflowFix(dbName) == argName
 }
@@ -170,11 +388,14 @@ func (b *boltTrial) AddSignup(ctx context.Context, name, role string) {
 	defer span.End()
 
 	lowerRole := strings.ToLower(role)
+	var details SignupDetails
 	s := b.getSignups(ctx, true)
 	for _, su := range s {
 		suName := su.GetName(ctx)
 		suRole := su.GetRole(ctx)
 		if isSameUser(suName, name) && strings.ToLower(suRole) != lowerRole {
+			// the details describe the member's character, so they carry over to the new role
+			details = su.GetDetails(ctx)
 			b.RemoveSignup(ctx, suName)
 			break
 		}
@@ -185,12 +406,29 @@ func (b *boltTrial) AddSignup(ctx context.Context, name, role string) {
 	}
 
 	b.protoTrial.Signups = append(b.protoTrial.Signups, &ProtoTrialSignup{
-		Name:  name,
-		Role:  role,
-		State: signupOk,
+		Name:      name,
+		Role:      role,
+		State:     signupOk,
+		Character: details.Character,
+		Class:     details.Class,
+		Note:      details.Note,
 	})
 }
 
+// SetSignupDetails replaces the details of the active signup of a user, if there is one
+func (b *boltTrial) SetSignupDetails(ctx context.Context, name string, details SignupDetails) {
+	_, span := b.census.StartSpan(ctx, "boltTrial.SetSignupDetails")
+	defer span.End()
+
+	for _, ps := range b.protoTrial.Signups {
+		if ps.State != signupCanceled && isSameUser(ps.Name, name) {
+			ps.Character = details.Character
+			ps.Class = details.Class
+			ps.Note = details.Note
+		}
+	}
+}
+
 func (b *boltTrial) RemoveSignup(ctx context.Context, name string) {
 	_, span := b.census.StartSpan(ctx, "boltTrial.RemoveSignup")
 	defer span.End()
@@ -232,6 +470,42 @@ func (b *boltTrial) SetRoleCount(ctx context.Context, name, emoji string, ct uin
 	b.protoTrial.RoleCountMap[lowerName] = prc
 }
 
+// SetRoleAliases replaces the aliases of a role; it does nothing if the trial has no such role
+func (b *boltTrial) SetRoleAliases(ctx context.Context, name string, aliases []string) {
+	ctx, span := b.census.StartSpan(ctx, "boltTrial.SetRoleAliases")
+	defer span.End()
+
+	b.migrateRoleCounts(ctx)
+
+	prc, ok := b.protoTrial.RoleCountMap[strings.ToLower(name)]
+	if !ok {
+		return
+	}
+
+	prc.Aliases = make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		prc.Aliases = append(prc.Aliases, strings.ToLower(a))
+	}
+}
+
+// SetRoleReservation replaces the reserved slots of a role; it does nothing if the trial has
+// no such role
+func (b *boltTrial) SetRoleReservation(ctx context.Context, name string, res Reservation) {
+	ctx, span := b.census.StartSpan(ctx, "boltTrial.SetRoleReservation")
+	defer span.End()
+
+	b.migrateRoleCounts(ctx)
+
+	prc, ok := b.protoTrial.RoleCountMap[strings.ToLower(name)]
+	if !ok {
+		return
+	}
+
+	prc.Reserved = res.Slots
+	prc.ReservedUsers = append([]string(nil), res.Users...)
+	prc.ReservedRole = res.RoleID
+}
+
 func (b *boltTrial) RemoveRole(ctx context.Context, name string) {
 	ctx, span := b.census.StartSpan(ctx, "boltTrial.RemoveRole")
 	defer span.End()
@@ -277,9 +551,19 @@ func (b *boltTrial) migrateRoleCounts(ctx context.Context) {
 }
 
 type boltTrialSignup struct {
-	name   string
-	role   string
-	census *census.Census
+	name     string
+	role     string
+	canceled bool
+	details  SignupDetails
+	census   *census.Census
+}
+
+func protoSignupDetails(ps *ProtoTrialSignup) SignupDetails {
+	return SignupDetails{
+		Character: ps.Character,
+		Class:     ps.Class,
+		Note:      ps.Note,
+	}
 }
 
 func (b *boltTrialSignup) GetName(ctx context.Context) string {
@@ -290,11 +574,21 @@ func (b *boltTrialSignup) GetRole(ctx context.Context) string {
 	return b.role
 }
 
+func (b *boltTrialSignup) IsCanceled(ctx context.Context) bool {
+	return b.canceled
+}
+
+func (b *boltTrialSignup) GetDetails(ctx context.Context) SignupDetails {
+	return b.details
+}
+
 type boltRoleCount struct {
-	role   string
-	count  uint64
-	emoji  string
-	census *census.Census
+	role        string
+	count       uint64
+	emoji       string
+	aliases     []string
+	reservation Reservation
+	census      *census.Census
 }
 
 func (b *boltRoleCount) GetRole(ctx context.Context) string {
@@ -308,3 +602,20 @@ func (b *boltRoleCount) GetCount(ctx context.Context) uint64 {
 func (b *boltRoleCount) GetEmoji(ctx context.Context) string {
 	return b.emoji
 }
+
+func (b *boltRoleCount) GetAliases(ctx context.Context) []string {
+	return b.aliases
+}
+
+func (b *boltRoleCount) GetReservation(ctx context.Context) Reservation {
+	return b.reservation
+}
+
+// reservationHolders are the members a reservation is held for, as mentions
+func reservationHolders(res Reservation) []string {
+	holders := append([]string(nil), res.Users...)
+	if res.RoleID != "" {
+		holders = append(holders, fmt.Sprintf("<@&%s>", res.RoleID))
+	}
+	return holders
+}
@@ -3,15 +3,18 @@ package storage
 import (
 	"context"
 	"strings"
+	"time"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/golang/protobuf/proto"
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 // ErrTrialNotExist is the error returned if a trial does not exist
-var ErrTrialNotExist = errors.New("trial does not exist")
+var ErrTrialNotExist = i18n.NewError("err.trial_not_exist")
 
 type boltTrialAPI struct {
 	db     *bolt.DB
@@ -34,18 +37,31 @@ func (b *boltTrialAPI) NewTransaction(ctx context.Context, guild string, writabl
 
 	bucketName := []byte(guild)
 
-	err := b.db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(bucketName)
-		if err != nil {
-			return errors.Wrap(err, "could not create bucket")
-		}
+	// the bucket is only created when missing, since every update is a commit to disk, and
+	// read-only transactions should not need one
+	exists := false
+	err := b.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(bucketName) != nil
 		return nil
 	})
-
 	if err != nil {
 		return nil, err
 	}
 
+	if !exists {
+		err = b.db.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(bucketName)
+			if err != nil {
+				return errors.Wrap(err, "could not create bucket")
+			}
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := b.db.Begin(writable)
 	if err != nil {
 		return nil, err
@@ -90,7 +106,7 @@ func (b *boltTrialAPITx) AddTrial(ctx context.Context, name string) (Trial, erro
 	trial, err := b.GetTrial(ctx, name)
 	if err == ErrTrialNotExist {
 		trial = &boltTrial{
-			protoTrial: &ProtoTrial{Name: name},
+			protoTrial: &ProtoTrial{Name: name, CreatedAt: time.Now().Unix()},
 			census:     b.census,
 		}
 		err = nil
@@ -169,3 +185,36 @@ func (b *boltTrialAPITx) GetTrials(ctx context.Context) []Trial {
 
 	return t
 }
+
+func (b *boltTrialAPITx) GetCorruptTrialKeys(ctx context.Context) []string {
+	_, span := b.census.StartSpan(ctx, "boltTrialAPITx.GetCorruptTrialKeys")
+	defer span.End()
+
+	bucket := b.tx.Bucket(b.bucketName)
+
+	keys := []string{}
+	_ = bucket.ForEach(func(k []byte, v []byte) error {
+		protoTrial := ProtoTrial{}
+		if err := proto.Unmarshal(v, &protoTrial); err != nil {
+			keys = append(keys, string(k))
+		}
+
+		return nil
+	})
+
+	return keys
+}
+
+// DeleteTrialKey removes a raw key without trying to decode it first, so that it works for corrupt records
+func (b *boltTrialAPITx) DeleteTrialKey(ctx context.Context, key string) error {
+	_, span := b.census.StartSpan(ctx, "boltTrialAPITx.DeleteTrialKey")
+	defer span.End()
+
+	bucket := b.tx.Bucket(b.bucketName)
+
+	if bucket.Get([]byte(key)) == nil {
+		return ErrTrialNotExist
+	}
+
+	return bucket.Delete([]byte(key))
+}
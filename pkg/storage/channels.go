@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+)
+
+// ChannelLookup finds the id of a channel in a guild by name, as the bot currently sees the guild
+type ChannelLookup func(guildID, channelName string) (string, bool)
+
+// TrialAPIWithChannelIDs wraps a TrialAPI so that saving a trial records the ids of channels
+// it only knows by name, as trials created before ids were kept do
+func TrialAPIWithChannelIDs(api TrialAPI, lookup ChannelLookup) TrialAPI {
+	return &channelTrialAPI{
+		TrialAPI: api,
+		lookup:   lookup,
+	}
+}
+
+type channelTrialAPI struct {
+	TrialAPI
+	lookup ChannelLookup
+}
+
+func (a *channelTrialAPI) NewTransaction(ctx context.Context, guild string, writable bool) (TrialAPITx, error) {
+	t, err := a.TrialAPI.NewTransaction(ctx, guild, writable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelTrialAPITx{
+		TrialAPITx: t,
+		guild:      guild,
+		lookup:     a.lookup,
+	}, nil
+}
+
+type channelTrialAPITx struct {
+	TrialAPITx
+	guild  string
+	lookup ChannelLookup
+}
+
+func (t *channelTrialAPITx) SaveTrial(ctx context.Context, trial Trial) error {
+	if trial.GetAnnounceChannelID(ctx) == "" {
+		if cid, ok := t.lookup(t.guild, trial.GetAnnounceChannel(ctx)); ok {
+			trial.SetAnnounceChannelID(ctx, cid)
+		}
+	}
+
+	if trial.GetSignupChannelID(ctx) == "" {
+		if cid, ok := t.lookup(t.guild, trial.GetSignupChannel(ctx)); ok {
+			trial.SetSignupChannelID(ctx, cid)
+		}
+	}
+
+	return t.TrialAPITx.SaveTrial(ctx, trial)
+}
+
+// GuildAPIWithChannelIDs wraps a GuildAPI so that saving a guild records the ids of the
+// channels in its settings that are only known by name
+func GuildAPIWithChannelIDs(api GuildAPI, lookup ChannelLookup) GuildAPI {
+	return &channelGuildAPI{
+		GuildAPI: api,
+		lookup:   lookup,
+	}
+}
+
+type channelGuildAPI struct {
+	GuildAPI
+	lookup ChannelLookup
+}
+
+func (a *channelGuildAPI) NewTransaction(ctx context.Context, writable bool) (GuildAPITx, error) {
+	t, err := a.GuildAPI.NewTransaction(ctx, writable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelGuildAPITx{
+		GuildAPITx: t,
+		lookup:     a.lookup,
+	}, nil
+}
+
+type channelGuildAPITx struct {
+	GuildAPITx
+	lookup ChannelLookup
+}
+
+func (t *channelGuildAPITx) SaveGuild(ctx context.Context, guild Guild) error {
+	gid := guild.GetName(ctx)
+	s := guild.GetSettings(ctx)
+
+	for _, ch := range []struct {
+		id   *string
+		name string
+	}{
+		{&s.AnnounceChannelID, s.AnnounceChannel},
+		{&s.SignupChannelID, s.SignupChannel},
+		{&s.AdminChannelID, s.AdminChannel},
+	} {
+		if *ch.id != "" {
+			continue
+		}
+
+		if cid, ok := t.lookup(gid, ch.name); ok {
+			*ch.id = cid
+		}
+	}
+
+	guild.SetSettings(ctx, s)
+
+	return t.GuildAPITx.SaveGuild(ctx, guild)
+}
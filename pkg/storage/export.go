@@ -0,0 +1,609 @@
+package storage
+
+//go:generate easyjson export.go
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// ExportVersion is the version of the GuildExport document format written by ExportGuild
+const ExportVersion = 1
+
+// ErrUnsupportedExportVersion is the error returned when importing a document newer than this code understands
+var ErrUnsupportedExportVersion = errors.New("unsupported export version")
+
+// ConflictMode determines what ImportGuild does with a trial that already exists
+type ConflictMode string
+
+// Conflict modes
+const (
+	ConflictSkip      ConflictMode = "skip"
+	ConflictOverwrite ConflictMode = "overwrite"
+	ConflictRename    ConflictMode = "rename"
+)
+
+// ParseConflictMode converts a string into a ConflictMode
+func ParseConflictMode(val string) (ConflictMode, error) {
+	switch ConflictMode(val) {
+	case ConflictSkip, ConflictOverwrite, ConflictRename:
+		return ConflictMode(val), nil
+	default:
+		return "", fmt.Errorf("unknown conflict mode '%s' (need skip, overwrite, or rename)", val)
+	}
+}
+
+// GuildExport is the versioned json document containing all the data for a guild
+//
+//easyjson:json
+type GuildExport struct {
+	Version    int            `json:"version"`
+	GuildID    string         `json:"guild_id"`
+	ExportedAt string         `json:"exported_at"`
+	Settings   ExportSettings `json:"settings"`
+	Presets    []ExportPreset `json:"presets,omitempty"`
+	Trials     []ExportTrial  `json:"trials"`
+}
+
+// ExportPreset is the json representation of a Preset
+//
+//easyjson:json
+type ExportPreset struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Roles       string `json:"roles"`
+}
+
+// ExportSettings is the json representation of GuildSettings
+//
+//easyjson:json
+type ExportSettings struct {
+	ControlSequence   string `json:"control_sequence"`
+	AnnounceChannel   string `json:"announce_channel"`
+	AnnounceChannelID string `json:"announce_channel_id,omitempty"`
+	SignupChannel     string `json:"signup_channel"`
+	SignupChannelID   string `json:"signup_channel_id,omitempty"`
+	AdminChannel      string `json:"admin_channel"`
+	AdminChannelID    string `json:"admin_channel_id,omitempty"`
+	AnnounceTo        string `json:"announce_to"`
+	ShowAfterSignup   bool   `json:"show_after_signup"`
+	ShowAfterWithdraw bool   `json:"show_after_withdraw"`
+	AdminRole         string `json:"admin_role"`
+	AdminRoleName     string `json:"admin_role_name,omitempty"`
+	Locale            string `json:"locale,omitempty"`
+}
+
+// ExportTrial is the json representation of a Trial
+//
+//easyjson:json
+type ExportTrial struct {
+	Name              string         `json:"name"`
+	State             string         `json:"state"`
+	Description       string         `json:"description"`
+	AnnounceChannel   string         `json:"announce_channel"`
+	AnnounceChannelID string         `json:"announce_channel_id,omitempty"`
+	AnnounceTo        string         `json:"announce_to"`
+	SignupChannel     string         `json:"signup_channel"`
+	SignupChannelID   string         `json:"signup_channel_id,omitempty"`
+	StartTime         string         `json:"start_time,omitempty"`
+	DurationSeconds   int64          `json:"duration_seconds,omitempty"`
+	ReleaseSeconds    int64          `json:"reserve_release_seconds,omitempty"`
+	RosterPolicy      string         `json:"roster_policy,omitempty"`
+	PriorityRoles     []string       `json:"priority_roles,omitempty"`
+	LotterySeed       int64          `json:"lottery_seed,omitempty"`
+	RosterOrder       []string       `json:"roster_order,omitempty"`
+	Lock              *ExportLock    `json:"lock,omitempty"`
+	RemindedAt        string         `json:"reminded_at,omitempty"`
+	CancelReason      string         `json:"cancel_reason,omitempty"`
+	RescheduledAt     string         `json:"rescheduled_at,omitempty"`
+	CreatedAt         string         `json:"created_at,omitempty"`
+	StateChangedAt    string         `json:"state_changed_at,omitempty"`
+	Roles             []ExportRole   `json:"roles"`
+	Signups           []ExportSignup `json:"signups"`
+}
+
+// ExportLock is the json representation of a LockedRoster
+//
+//easyjson:json
+type ExportLock struct {
+	LockedAt  string               `json:"locked_at"`
+	Deadline  string               `json:"confirm_deadline"`
+	Signups   []ExportLockedSignup `json:"signups"`
+	Confirmed []string             `json:"confirmed,omitempty"`
+	Settled   bool                 `json:"settled,omitempty"`
+}
+
+// ExportLockedSignup is the json representation of a LockedSignup
+//
+//easyjson:json
+type ExportLockedSignup struct {
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Overflow bool   `json:"overflow,omitempty"`
+}
+
+// ExportRole is the json representation of a RoleCount
+//
+//easyjson:json
+type ExportRole struct {
+	Name          string   `json:"name"`
+	Count         uint64   `json:"count"`
+	Emoji         string   `json:"emoji"`
+	Aliases       []string `json:"aliases,omitempty"`
+	Reserved      uint64   `json:"reserved,omitempty"`
+	ReservedUsers []string `json:"reserved_users,omitempty"`
+	ReservedRole  string   `json:"reserved_role,omitempty"`
+}
+
+// ExportSignup is the json representation of a TrialSignup
+//
+//easyjson:json
+type ExportSignup struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Canceled  bool   `json:"canceled,omitempty"`
+	Character string `json:"character,omitempty"`
+	Class     string `json:"class,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// ExportTrialLine is a single trial tagged with its guild, for line-oriented dumps
+//
+//easyjson:json
+type ExportTrialLine struct {
+	GuildID string `json:"guild_id"`
+	ExportTrial
+}
+
+// ImportOptions provides a way to configure ImportGuild
+//
+//   - Conflict determines what happens to trials that already exist in the target guild
+//   - Settings can be set to true to also replace the target guild's settings, and its presets
+//     if the document has any
+type ImportOptions struct {
+	Conflict ConflictMode
+	Settings bool
+}
+
+// ImportResult describes what ImportGuild did with each trial in the document
+//
+// DroppedRoles are the settings that named roles of the exporting guild, which another
+// guild cannot have, so they were cleared and must be set again.
+type ImportResult struct {
+	Created      []string
+	Overwritten  []string
+	Renamed      map[string]string
+	Skipped      []string
+	DroppedRoles []string
+}
+
+// ExportSettingsFrom converts GuildSettings into their json representation
+func ExportSettingsFrom(s GuildSettings) ExportSettings {
+	return ExportSettings{
+		ControlSequence:   s.ControlSequence,
+		AnnounceChannel:   s.AnnounceChannel,
+		AnnounceChannelID: s.AnnounceChannelID,
+		SignupChannel:     s.SignupChannel,
+		SignupChannelID:   s.SignupChannelID,
+		AdminChannel:      s.AdminChannel,
+		AdminChannelID:    s.AdminChannelID,
+		AnnounceTo:        s.AnnounceTo,
+		ShowAfterSignup:   s.ShowAfterSignup == "true",
+		ShowAfterWithdraw: s.ShowAfterWithdraw == "true",
+		AdminRole:         s.AdminRole,
+		AdminRoleName:     s.AdminRoleName,
+		Locale:            s.Locale,
+	}
+}
+
+// ToGuildSettings converts the json representation back into GuildSettings
+func (e ExportSettings) ToGuildSettings() GuildSettings {
+	s := GuildSettings{
+		ControlSequence:   e.ControlSequence,
+		AnnounceChannel:   e.AnnounceChannel,
+		AnnounceChannelID: e.AnnounceChannelID,
+		SignupChannel:     e.SignupChannel,
+		SignupChannelID:   e.SignupChannelID,
+		AdminChannel:      e.AdminChannel,
+		AdminChannelID:    e.AdminChannelID,
+		AnnounceTo:        e.AnnounceTo,
+		ShowAfterSignup:   "false",
+		ShowAfterWithdraw: "false",
+		AdminRole:         e.AdminRole,
+		AdminRoleName:     e.AdminRoleName,
+		Locale:            e.Locale,
+	}
+
+	if e.ShowAfterSignup {
+		s.ShowAfterSignup = "true"
+	}
+
+	if e.ShowAfterWithdraw {
+		s.ShowAfterWithdraw = "true"
+	}
+
+	return s
+}
+
+// ExportTrialFrom converts a Trial into its json representation
+//
+// Canceled signups are only included when includeCanceled is true
+func ExportTrialFrom(ctx context.Context, t Trial, includeCanceled bool) ExportTrial {
+	rcs := t.GetRoleCounts(ctx)
+
+	var sus []TrialSignup
+	if includeCanceled {
+		sus = t.GetSignupHistory(ctx)
+	} else {
+		sus = t.GetSignups(ctx)
+	}
+
+	et := ExportTrial{
+		Name:              t.GetName(ctx),
+		State:             string(t.GetState(ctx)),
+		Description:       t.GetDescription(ctx),
+		AnnounceChannel:   t.GetAnnounceChannel(ctx),
+		AnnounceChannelID: t.GetAnnounceChannelID(ctx),
+		AnnounceTo:        t.GetAnnounceTo(ctx),
+		SignupChannel:     t.GetSignupChannel(ctx),
+		SignupChannelID:   t.GetSignupChannelID(ctx),
+		DurationSeconds:   int64(t.GetDuration(ctx) / time.Second),
+		ReleaseSeconds:    int64(t.GetReserveRelease(ctx) / time.Second),
+		RosterPolicy:      string(t.GetRosterPolicy(ctx)),
+		PriorityRoles:     t.GetPriorityRoles(ctx),
+		LotterySeed:       t.GetLotterySeed(ctx),
+		RosterOrder:       t.GetRosterOrder(ctx),
+		CancelReason:      t.GetCancelReason(ctx),
+		Roles:             make([]ExportRole, 0, len(rcs)),
+		Signups:           make([]ExportSignup, 0, len(sus)),
+	}
+
+	if start := t.GetStartTime(ctx); !start.IsZero() {
+		et.StartTime = start.UTC().Format(time.RFC3339)
+	}
+
+	if reminded := t.GetRemindedAt(ctx); !reminded.IsZero() {
+		et.RemindedAt = reminded.UTC().Format(time.RFC3339)
+	}
+
+	if rescheduled := t.GetRescheduledAt(ctx); !rescheduled.IsZero() {
+		et.RescheduledAt = rescheduled.UTC().Format(time.RFC3339)
+	}
+
+	if created := t.GetCreatedAt(ctx); !created.IsZero() {
+		et.CreatedAt = created.UTC().Format(time.RFC3339)
+	}
+
+	if changed := t.GetStateChangedAt(ctx); !changed.IsZero() {
+		et.StateChangedAt = changed.UTC().Format(time.RFC3339)
+	}
+
+	if lr := t.GetLockedRoster(ctx); !lr.IsZero() {
+		et.Lock = &ExportLock{
+			LockedAt:  lr.LockedAt.UTC().Format(time.RFC3339),
+			Deadline:  lr.Deadline.UTC().Format(time.RFC3339),
+			Signups:   make([]ExportLockedSignup, 0, len(lr.Signups)),
+			Confirmed: lr.Confirmed,
+			Settled:   lr.Settled,
+		}
+		for _, ls := range lr.Signups {
+			et.Lock.Signups = append(et.Lock.Signups, ExportLockedSignup{Name: ls.Name, Role: ls.Role, Overflow: ls.Overflow})
+		}
+	}
+
+	for _, rc := range rcs {
+		res := rc.GetReservation(ctx)
+		et.Roles = append(et.Roles, ExportRole{
+			Name:          rc.GetRole(ctx),
+			Count:         rc.GetCount(ctx),
+			Emoji:         rc.GetEmoji(ctx),
+			Aliases:       rc.GetAliases(ctx),
+			Reserved:      res.Slots,
+			ReservedUsers: res.Users,
+			ReservedRole:  res.RoleID,
+		})
+	}
+
+	for _, su := range sus {
+		d := su.GetDetails(ctx)
+		et.Signups = append(et.Signups, ExportSignup{
+			Name:      su.GetName(ctx),
+			Role:      su.GetRole(ctx),
+			Canceled:  su.IsCanceled(ctx),
+			Character: d.Character,
+			Class:     d.Class,
+			Note:      d.Note,
+		})
+	}
+
+	return et
+}
+
+// forOtherGuild clears the channel and role ids of settings exported from another guild;
+// the channels are found again by name, but the admin role is dropped, since roles are
+// only kept by id
+func (e ExportSettings) forOtherGuild() (ExportSettings, []string) {
+	e.AnnounceChannelID, e.SignupChannelID, e.AdminChannelID = "", "", ""
+
+	var dropped []string
+	if e.AdminRole != "" {
+		e.AdminRole, e.AdminRoleName = "", ""
+		dropped = append(dropped, "adminrole")
+	}
+
+	return e, dropped
+}
+
+// forOtherGuild clears the channel and role ids of a trial exported from another guild, in
+// the same way as ExportSettings.forOtherGuild
+func (e ExportTrial) forOtherGuild() (ExportTrial, []string) {
+	e.AnnounceChannelID, e.SignupChannelID = "", ""
+
+	var dropped []string
+	if len(e.PriorityRoles) > 0 {
+		e.PriorityRoles = nil
+		dropped = append(dropped, fmt.Sprintf("%s: tiers", e.Name))
+	}
+
+	roles := make([]ExportRole, 0, len(e.Roles))
+	for _, r := range e.Roles {
+		if r.ReservedRole != "" {
+			r.ReservedRole = ""
+			dropped = append(dropped, fmt.Sprintf("%s: reserve %s", e.Name, r.Name))
+		}
+		roles = append(roles, r)
+	}
+	e.Roles = roles
+
+	return e, dropped
+}
+
+// applyTo copies the exported data into a trial, replacing its roles and signups
+func (e ExportTrial) applyTo(ctx context.Context, t Trial) {
+	t.SetDescription(ctx, e.Description)
+	t.SetState(ctx, TrialState(e.State))
+	t.SetAnnounceChannel(ctx, e.AnnounceChannel)
+	t.SetAnnounceChannelID(ctx, e.AnnounceChannelID)
+	t.SetAnnounceTo(ctx, e.AnnounceTo)
+	t.SetSignupChannel(ctx, e.SignupChannel)
+	t.SetSignupChannelID(ctx, e.SignupChannelID)
+	t.SetDuration(ctx, time.Duration(e.DurationSeconds)*time.Second)
+	t.SetReserveRelease(ctx, time.Duration(e.ReleaseSeconds)*time.Second)
+	t.SetRosterPolicy(ctx, RosterPolicy(e.RosterPolicy))
+	t.SetPriorityRoles(ctx, e.PriorityRoles)
+	t.SetLotterySeed(ctx, e.LotterySeed)
+	t.SetRosterOrder(ctx, e.RosterOrder)
+	t.SetCancelReason(ctx, e.CancelReason)
+
+	// an unparseable start time leaves the trial unscheduled rather than failing the import
+	start, _ := time.Parse(time.RFC3339, e.StartTime)
+	t.SetStartTime(ctx, start)
+
+	// likewise a lock without a parseable time leaves the roster unlocked
+	var lr LockedRoster
+	if e.Lock != nil {
+		lr.LockedAt, _ = time.Parse(time.RFC3339, e.Lock.LockedAt)
+		lr.Deadline, _ = time.Parse(time.RFC3339, e.Lock.Deadline)
+		lr.Confirmed = e.Lock.Confirmed
+		lr.Settled = e.Lock.Settled
+		for _, ls := range e.Lock.Signups {
+			lr.Signups = append(lr.Signups, LockedSignup{Name: ls.Name, Role: ls.Role, Overflow: ls.Overflow})
+		}
+	}
+	if lr.LockedAt.IsZero() {
+		lr = LockedRoster{}
+	}
+	t.SetLockedRoster(ctx, lr)
+
+	reminded, _ := time.Parse(time.RFC3339, e.RemindedAt)
+	t.SetRemindedAt(ctx, reminded)
+
+	rescheduled, _ := time.Parse(time.RFC3339, e.RescheduledAt)
+	t.SetRescheduledAt(ctx, rescheduled)
+
+	// SetState stamps the import time, which would restart the cleanup and attendance ages;
+	// documents from before these were exported keep the import time
+	if created, err := time.Parse(time.RFC3339, e.CreatedAt); err == nil {
+		t.SetCreatedAt(ctx, created)
+	}
+	if changed, err := time.Parse(time.RFC3339, e.StateChangedAt); err == nil {
+		t.SetStateChangedAt(ctx, changed)
+	}
+
+	for _, rc := range t.GetRoleCounts(ctx) {
+		t.RemoveRole(ctx, rc.GetRole(ctx))
+	}
+	for _, r := range e.Roles {
+		t.SetRoleCount(ctx, r.Name, r.Emoji, r.Count)
+		t.SetRoleAliases(ctx, r.Name, r.Aliases)
+		t.SetRoleReservation(ctx, r.Name, Reservation{Slots: r.Reserved, Users: r.ReservedUsers, RoleID: r.ReservedRole})
+	}
+
+	t.ClearSignups(ctx)
+	for _, su := range e.Signups {
+		if su.Canceled {
+			continue
+		}
+		t.AddSignup(ctx, su.Name, su.Role)
+		t.SetSignupDetails(ctx, su.Name, SignupDetails{Character: su.Character, Class: su.Class, Note: su.Note})
+	}
+}
+
+// ExportGuild collects the settings and trials for a guild into a GuildExport document
+//
+// NOTE: this cannot be called after another transaction has been started
+func ExportGuild(ctx context.Context, gapi GuildAPI, tapi TrialAPI, gid string) (GuildExport, error) {
+	doc := GuildExport{
+		Version:    ExportVersion,
+		GuildID:    gid,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	gt, err := gapi.NewTransaction(ctx, false)
+	if err != nil {
+		return doc, errors.Wrap(err, "could not start settings transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return gt.Rollback(ctx) })
+
+	g, err := gt.AddGuild(ctx, gid)
+	if err != nil {
+		return doc, errors.Wrap(err, "unable to find guild")
+	}
+	doc.Settings = ExportSettingsFrom(g.GetSettings(ctx))
+	for _, p := range g.GetPresets(ctx) {
+		doc.Presets = append(doc.Presets, ExportPreset{
+			Name:        p.Name,
+			Description: p.Description,
+			Roles:       p.Roles,
+		})
+	}
+
+	if err = gt.Rollback(ctx); err != nil {
+		return doc, err
+	}
+
+	tt, err := tapi.NewTransaction(ctx, gid, false)
+	if err != nil {
+		return doc, errors.Wrap(err, "could not start trials transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return tt.Rollback(ctx) })
+
+	trials := tt.GetTrials(ctx)
+	doc.Trials = make([]ExportTrial, 0, len(trials))
+	for _, t := range trials {
+		doc.Trials = append(doc.Trials, ExportTrialFrom(ctx, t, false))
+	}
+
+	return doc, nil
+}
+
+// ImportGuild loads a GuildExport document into the guild with the provided id
+//
+// NOTE: this cannot be called after another transaction has been started
+func ImportGuild(ctx context.Context, gapi GuildAPI, tapi TrialAPI, gid string, doc GuildExport, opts ImportOptions) (ImportResult, error) {
+	res := ImportResult{
+		Renamed: map[string]string{},
+	}
+
+	if doc.Version > ExportVersion {
+		return res, errors.Wrap(ErrUnsupportedExportVersion, fmt.Sprintf("document version %d", doc.Version))
+	}
+
+	if opts.Conflict == "" {
+		opts.Conflict = ConflictSkip
+	}
+
+	// channel and role ids belong to the guild they were exported from
+	otherGuild := doc.GuildID != gid
+
+	if opts.Settings {
+		es := doc.Settings
+		if otherGuild {
+			var dropped []string
+			es, dropped = es.forOtherGuild()
+			res.DroppedRoles = append(res.DroppedRoles, dropped...)
+		}
+
+		if err := importSettings(ctx, gapi, gid, es, doc.Presets); err != nil {
+			return res, err
+		}
+	}
+
+	tt, err := tapi.NewTransaction(ctx, gid, true)
+	if err != nil {
+		return res, errors.Wrap(err, "could not start trials transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return tt.Rollback(ctx) })
+
+	for _, et := range doc.Trials {
+		name := et.Name
+
+		if otherGuild {
+			var dropped []string
+			et, dropped = et.forOtherGuild()
+			res.DroppedRoles = append(res.DroppedRoles, dropped...)
+		}
+
+		_, err := tt.GetTrial(ctx, name)
+		switch {
+		case err == ErrTrialNotExist:
+			res.Created = append(res.Created, name)
+		case err != nil:
+			return res, errors.Wrap(err, "could not check for existing trial", "trial_name", name)
+		case opts.Conflict == ConflictSkip:
+			res.Skipped = append(res.Skipped, name)
+			continue
+		case opts.Conflict == ConflictOverwrite:
+			if err := tt.DeleteTrial(ctx, name); err != nil {
+				return res, errors.Wrap(err, "could not replace existing trial", "trial_name", name)
+			}
+			res.Overwritten = append(res.Overwritten, name)
+		case opts.Conflict == ConflictRename:
+			name = availableTrialName(ctx, tt, name)
+			res.Renamed[et.Name] = name
+		}
+
+		t, err := tt.AddTrial(ctx, name)
+		if err != nil {
+			return res, errors.Wrap(err, "could not add trial", "trial_name", name)
+		}
+		t.SetName(ctx, name)
+		et.applyTo(ctx, t)
+
+		if err := tt.SaveTrial(ctx, t); err != nil {
+			return res, errors.Wrap(err, "could not save trial", "trial_name", name)
+		}
+	}
+
+	if err := tt.Commit(ctx); err != nil {
+		return res, errors.Wrap(err, "could not save imported trials")
+	}
+
+	return res, nil
+}
+
+func importSettings(ctx context.Context, gapi GuildAPI, gid string, es ExportSettings, eps []ExportPreset) error {
+	gt, err := gapi.NewTransaction(ctx, true)
+	if err != nil {
+		return errors.Wrap(err, "could not start settings transaction")
+	}
+	defer deferutil.CheckDefer(func() error { return gt.Rollback(ctx) })
+
+	g, err := gt.AddGuild(ctx, gid)
+	if err != nil {
+		return errors.Wrap(err, "unable to find or add guild")
+	}
+	g.SetSettings(ctx, es.ToGuildSettings())
+
+	if len(eps) > 0 {
+		presets := make([]Preset, 0, len(eps))
+		for _, ep := range eps {
+			presets = append(presets, Preset{
+				Name:        ep.Name,
+				Description: ep.Description,
+				Roles:       ep.Roles,
+			})
+		}
+		g.SetPresets(ctx, presets)
+	}
+
+	if err := gt.SaveGuild(ctx, g); err != nil {
+		return errors.Wrap(err, "could not save guild settings")
+	}
+
+	return errors.Wrap(gt.Commit(ctx), "could not save guild settings")
+}
+
+func availableTrialName(ctx context.Context, tt TrialAPITx, name string) string {
+	candidate := fmt.Sprintf("%s-imported", name)
+	for i := 2; ; i++ {
+		if _, err := tt.GetTrial(ctx, candidate); err == ErrTrialNotExist {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-imported-%d", name, i)
+	}
+}
@@ -0,0 +1,398 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// testAPIs are the guild and trial apis of a new database, with a function to close it
+func testAPIs(t *testing.T) (GuildAPI, TrialAPI, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "storage-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		os.RemoveAll(dir) // nolint: errcheck
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	done := func() {
+		db.Close()        // nolint: errcheck
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+
+	gapi, err := NewBoltGuildAPI(context.Background(), db, nil)
+	if err != nil {
+		done()
+		t.Fatalf("could not create guild api: %v", err)
+	}
+
+	tapi, err := NewBoltTrialAPI(db, nil)
+	if err != nil {
+		done()
+		t.Fatalf("could not create trial api: %v", err)
+	}
+
+	return gapi, tapi, done
+}
+
+var (
+	testCreatedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	testChangedAt = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+)
+
+// seedGuild saves the settings and trials of a guild with everything an export carries
+func seedGuild(ctx context.Context, t *testing.T, gapi GuildAPI, tapi TrialAPI, gid string) {
+	t.Helper()
+
+	gt, err := gapi.NewTransaction(ctx, true)
+	if err != nil {
+		t.Fatalf("could not start settings transaction: %v", err)
+	}
+	defer gt.Rollback(ctx) // nolint: errcheck
+
+	g, err := gt.AddGuild(ctx, gid)
+	if err != nil {
+		t.Fatalf("could not add guild: %v", err)
+	}
+	g.SetSettings(ctx, GuildSettings{
+		ControlSequence:   "!",
+		AnnounceChannel:   "events",
+		AnnounceChannelID: "101",
+		SignupChannel:     "signups",
+		SignupChannelID:   "102",
+		AdminChannel:      "officers",
+		AdminChannelID:    "103",
+		AnnounceTo:        "everyone",
+		ShowAfterSignup:   "true",
+		ShowAfterWithdraw: "false",
+		AdminRole:         "201",
+		AdminRoleName:     "Raid Lead",
+		Locale:            "de",
+	})
+	g.SetPresets(ctx, []Preset{{Name: "vss-hm", Description: "Sunspire HM", Roles: "dps:8,healer:2,tank:2"}})
+	if err = gt.SaveGuild(ctx, g); err != nil {
+		t.Fatalf("could not save guild: %v", err)
+	}
+	if err = gt.Commit(ctx); err != nil {
+		t.Fatalf("could not commit guild: %v", err)
+	}
+
+	tt, err := tapi.NewTransaction(ctx, gid, true)
+	if err != nil {
+		t.Fatalf("could not start trials transaction: %v", err)
+	}
+	defer tt.Rollback(ctx) // nolint: errcheck
+
+	vaa, err := tt.AddTrial(ctx, "vaa")
+	if err != nil {
+		t.Fatalf("could not add trial: %v", err)
+	}
+	vaa.SetDescription(ctx, "Aetherian Archive")
+	vaa.SetState(ctx, TrialStateClosed)
+	vaa.SetAnnounceChannel(ctx, "events")
+	vaa.SetAnnounceChannelID(ctx, "101")
+	vaa.SetSignupChannel(ctx, "signups")
+	vaa.SetSignupChannelID(ctx, "102")
+	vaa.SetAnnounceTo(ctx, "here")
+	vaa.SetStartTime(ctx, time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC))
+	vaa.SetDuration(ctx, 2*time.Hour)
+	vaa.SetReserveRelease(ctx, time.Hour)
+	vaa.SetRosterPolicy(ctx, RosterTiers)
+	vaa.SetPriorityRoles(ctx, []string{"202", "203"})
+	vaa.SetRosterOrder(ctx, []string{"<@!2>", "<@!1>", "<@!3>"})
+	vaa.SetRoleCount(ctx, "dps", "⚔", 1)
+	vaa.SetRoleCount(ctx, "healer", "", 1)
+	vaa.SetRoleAliases(ctx, "dps", []string{"dd"})
+	vaa.SetRoleReservation(ctx, "dps", Reservation{Slots: 1, Users: []string{"<@!1>"}, RoleID: "204"})
+	vaa.AddSignup(ctx, "<@!1>", "dps")
+	vaa.AddSignup(ctx, "<@!2>", "dps")
+	vaa.AddSignup(ctx, "<@!3>", "healer")
+	vaa.SetSignupDetails(ctx, "<@!2>", SignupDetails{Character: "Zyra", Class: "nb", Note: "late 10min"})
+	vaa.SetLockedRoster(ctx, LockedRoster{
+		LockedAt:  time.Date(2026, 3, 8, 19, 0, 0, 0, time.UTC),
+		Deadline:  time.Date(2026, 3, 9, 19, 0, 0, 0, time.UTC),
+		Signups:   []LockedSignup{{Name: "<@!1>", Role: "dps"}, {Name: "<@!2>", Role: "dps", Overflow: true}, {Name: "<@!3>", Role: "healer"}},
+		Confirmed: []string{"<@!1>"},
+		Settled:   true,
+	})
+	vaa.SetRemindedAt(ctx, time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC))
+	vaa.SetCreatedAt(ctx, testCreatedAt)
+	vaa.SetStateChangedAt(ctx, testChangedAt)
+
+	vss, err := tt.AddTrial(ctx, "vss")
+	if err != nil {
+		t.Fatalf("could not add trial: %v", err)
+	}
+	vss.SetState(ctx, TrialStateCanceled)
+	vss.SetCancelReason(ctx, "server down")
+	vss.SetRescheduledAt(ctx, time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC))
+	vss.SetRosterPolicy(ctx, RosterLottery)
+	vss.SetLotterySeed(ctx, 42)
+	vss.SetRoleCount(ctx, "tank", "", 2)
+	vss.AddSignup(ctx, "<@!4>", "tank")
+	vss.SetCreatedAt(ctx, testCreatedAt)
+	vss.SetStateChangedAt(ctx, testChangedAt)
+
+	for _, trial := range []Trial{vaa, vss} {
+		if err = tt.SaveTrial(ctx, trial); err != nil {
+			t.Fatalf("could not save trial: %v", err)
+		}
+	}
+	if err = tt.Commit(ctx); err != nil {
+		t.Fatalf("could not commit trials: %v", err)
+	}
+}
+
+func exportJSON(ctx context.Context, t *testing.T, gapi GuildAPI, tapi TrialAPI, gid string) (GuildExport, string) {
+	t.Helper()
+
+	doc, err := ExportGuild(ctx, gapi, tapi, gid)
+	if err != nil {
+		t.Fatalf("could not export guild %s: %v", gid, err)
+	}
+
+	normalized := doc
+	normalized.ExportedAt = ""
+	b, err := normalized.MarshalJSON()
+	if err != nil {
+		t.Fatalf("could not marshal export: %v", err)
+	}
+
+	return doc, string(b)
+}
+
+func TestImportGuildRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	gapi, tapi, done := testAPIs(t)
+	defer done()
+	seedGuild(ctx, t, gapi, tapi, "1")
+
+	doc, want := exportJSON(ctx, t, gapi, tapi, "1")
+
+	if got := len(doc.Trials); got != 2 {
+		t.Fatalf("exported %d trials, want 2", got)
+	}
+	if doc.Trials[0].CreatedAt != testCreatedAt.Format(time.RFC3339) || doc.Trials[0].StateChangedAt != testChangedAt.Format(time.RFC3339) {
+		t.Errorf("exported created_at=%q state_changed_at=%q, want %q and %q", doc.Trials[0].CreatedAt, doc.Trials[0].StateChangedAt, testCreatedAt.Format(time.RFC3339), testChangedAt.Format(time.RFC3339))
+	}
+
+	// a document read back from json imports the same as the one exported
+	b, err := doc.MarshalJSON()
+	if err != nil {
+		t.Fatalf("could not marshal export: %v", err)
+	}
+	var read GuildExport
+	if err = read.UnmarshalJSON(b); err != nil {
+		t.Fatalf("could not unmarshal export: %v", err)
+	}
+
+	gapi2, tapi2, done2 := testAPIs(t)
+	defer done2()
+
+	res, err := ImportGuild(ctx, gapi2, tapi2, "1", read, ImportOptions{Settings: true})
+	if err != nil {
+		t.Fatalf("ImportGuild() error = %v", err)
+	}
+	if want := []string{"vaa", "vss"}; !reflect.DeepEqual(res.Created, want) {
+		t.Errorf("Created = %v, want %v", res.Created, want)
+	}
+	if len(res.Skipped)+len(res.Overwritten)+len(res.Renamed)+len(res.DroppedRoles) != 0 {
+		t.Errorf("ImportGuild() = %+v, want only created trials", res)
+	}
+
+	if _, got := exportJSON(ctx, t, gapi2, tapi2, "1"); got != want {
+		t.Errorf("export after import =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestImportGuildConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		mode        ConflictMode
+		imports     int
+		wantResult  ImportResult
+		wantTrials  []string
+		wantChanged bool
+	}{
+		{
+			mode:        ConflictSkip,
+			imports:     1,
+			wantResult:  ImportResult{Skipped: []string{"vaa", "vss"}, Renamed: map[string]string{}},
+			wantTrials:  []string{"vaa", "vss"},
+			wantChanged: true,
+		},
+		{
+			mode:       "",
+			imports:    1,
+			wantResult: ImportResult{Skipped: []string{"vaa", "vss"}, Renamed: map[string]string{}},
+			wantTrials: []string{"vaa", "vss"},
+			// the default is to skip
+			wantChanged: true,
+		},
+		{
+			mode:       ConflictOverwrite,
+			imports:    2,
+			wantResult: ImportResult{Overwritten: []string{"vaa", "vss"}, Renamed: map[string]string{}},
+			wantTrials: []string{"vaa", "vss"},
+		},
+		{
+			mode:        ConflictRename,
+			imports:     3,
+			wantResult:  ImportResult{Renamed: map[string]string{"vaa": "vaa-imported-3", "vss": "vss-imported-3"}},
+			wantTrials:  []string{"vaa", "vaa-imported", "vaa-imported-2", "vaa-imported-3", "vss", "vss-imported", "vss-imported-2", "vss-imported-3"},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			gapi, tapi, done := testAPIs(t)
+			defer done()
+			seedGuild(ctx, t, gapi, tapi, "1")
+
+			doc, _ := exportJSON(ctx, t, gapi, tapi, "1")
+			wantTrial := doc.Trials[0]
+
+			changeDescription(ctx, t, tapi, "1", "vaa", "changed since the export")
+
+			var res ImportResult
+			for i := 0; i < tt.imports; i++ {
+				var err error
+				res, err = ImportGuild(ctx, gapi, tapi, "1", doc, ImportOptions{Conflict: tt.mode})
+				if err != nil {
+					t.Fatalf("ImportGuild() error = %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(res, tt.wantResult) {
+				t.Errorf("ImportGuild() = %+v, want %+v", res, tt.wantResult)
+			}
+
+			got, _ := exportJSON(ctx, t, gapi, tapi, "1")
+
+			names := make([]string, 0, len(got.Trials))
+			byName := map[string]ExportTrial{}
+			for _, et := range got.Trials {
+				names = append(names, et.Name)
+				byName[et.Name] = et
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.wantTrials) {
+				t.Errorf("trials = %v, want %v", names, tt.wantTrials)
+			}
+
+			if changed := byName["vaa"].Description != wantTrial.Description; changed != tt.wantChanged {
+				t.Errorf("vaa description = %q after import, want changed=%v", byName["vaa"].Description, tt.wantChanged)
+			}
+
+			// a renamed trial is the exported one under another name
+			if renamed, ok := tt.wantResult.Renamed["vaa"]; ok {
+				imported := byName[renamed]
+				imported.Name = wantTrial.Name
+				if !reflect.DeepEqual(imported, wantTrial) {
+					t.Errorf("renamed trial = %+v, want %+v", imported, wantTrial)
+				}
+			}
+		})
+	}
+}
+
+func changeDescription(ctx context.Context, t *testing.T, tapi TrialAPI, gid, name, desc string) {
+	t.Helper()
+
+	tt, err := tapi.NewTransaction(ctx, gid, true)
+	if err != nil {
+		t.Fatalf("could not start trials transaction: %v", err)
+	}
+	defer tt.Rollback(ctx) // nolint: errcheck
+
+	trial, err := tt.GetTrial(ctx, name)
+	if err != nil {
+		t.Fatalf("could not get trial: %v", err)
+	}
+	trial.SetDescription(ctx, desc)
+
+	if err = tt.SaveTrial(ctx, trial); err != nil {
+		t.Fatalf("could not save trial: %v", err)
+	}
+	if err = tt.Commit(ctx); err != nil {
+		t.Fatalf("could not commit trial: %v", err)
+	}
+}
+
+func TestImportGuildOtherGuild(t *testing.T) {
+	ctx := context.Background()
+
+	gapi, tapi, done := testAPIs(t)
+	defer done()
+	seedGuild(ctx, t, gapi, tapi, "1")
+
+	doc, _ := exportJSON(ctx, t, gapi, tapi, "1")
+
+	res, err := ImportGuild(ctx, gapi, tapi, "2", doc, ImportOptions{Settings: true})
+	if err != nil {
+		t.Fatalf("ImportGuild() error = %v", err)
+	}
+
+	if want := []string{"adminrole", "vaa: tiers", "vaa: reserve dps"}; !reflect.DeepEqual(res.DroppedRoles, want) {
+		t.Errorf("DroppedRoles = %v, want %v", res.DroppedRoles, want)
+	}
+
+	got, _ := exportJSON(ctx, t, gapi, tapi, "2")
+
+	wantSettings := doc.Settings
+	wantSettings.AnnounceChannelID, wantSettings.SignupChannelID, wantSettings.AdminChannelID = "", "", ""
+	wantSettings.AdminRole, wantSettings.AdminRoleName = "", ""
+	if !reflect.DeepEqual(got.Settings, wantSettings) {
+		t.Errorf("settings = %+v, want %+v", got.Settings, wantSettings)
+	}
+
+	if !reflect.DeepEqual(got.Presets, doc.Presets) {
+		t.Errorf("presets = %+v, want %+v", got.Presets, doc.Presets)
+	}
+
+	vaa := got.Trials[0]
+	if vaa.AnnounceChannel != "events" || vaa.SignupChannel != "signups" {
+		t.Errorf("channels = %q, %q, want the names kept", vaa.AnnounceChannel, vaa.SignupChannel)
+	}
+	if vaa.AnnounceChannelID != "" || vaa.SignupChannelID != "" {
+		t.Errorf("channel ids = %q, %q, want them cleared", vaa.AnnounceChannelID, vaa.SignupChannelID)
+	}
+	if len(vaa.PriorityRoles) != 0 {
+		t.Errorf("priority roles = %v, want none", vaa.PriorityRoles)
+	}
+	if r := vaa.Roles[0]; r.ReservedRole != "" || r.Reserved != 1 || !reflect.DeepEqual(r.ReservedUsers, []string{"<@!1>"}) {
+		t.Errorf("dps reservation = %+v, want the members kept and the role dropped", r)
+	}
+	if vaa.CreatedAt != doc.Trials[0].CreatedAt || vaa.StateChangedAt != doc.Trials[0].StateChangedAt {
+		t.Errorf("created_at=%q state_changed_at=%q, want %q and %q", vaa.CreatedAt, vaa.StateChangedAt, doc.Trials[0].CreatedAt, doc.Trials[0].StateChangedAt)
+	}
+}
+
+func TestImportGuildVersion(t *testing.T) {
+	ctx := context.Background()
+
+	gapi, tapi, done := testAPIs(t)
+	defer done()
+
+	_, err := ImportGuild(ctx, gapi, tapi, "1", GuildExport{Version: ExportVersion + 1}, ImportOptions{})
+	if e, ok := err.(errors.Error); !ok || e.Cause() != ErrUnsupportedExportVersion {
+		t.Errorf("ImportGuild() error = %v, want %v", err, ErrUnsupportedExportVersion)
+	}
+}
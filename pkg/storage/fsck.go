@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+)
+
+var quarantineBucket = []byte("Quarantine")
+
+// IssueKind is the type of problem found by a Checker
+type IssueKind string
+
+// Issue kinds
+const (
+	IssueCorrupt         IssueKind = "corrupt"
+	IssueKeyMismatch     IssueKind = "key-mismatch"
+	IssueDuplicateSignup IssueKind = "duplicate-signup"
+	IssueMissingRole     IssueKind = "missing-role"
+)
+
+// Issue is a single problem with a database record
+//
+// Bucket is the guild id for trial records, or the guild settings bucket name
+type Issue struct {
+	Bucket string
+	Key    string
+	Kind   IssueKind
+	Detail string
+}
+
+// Quarantinable reports whether the record cannot be used as-is and should be moved aside by a repair
+func (i Issue) Quarantinable() bool {
+	return i.Kind == IssueCorrupt || i.Kind == IssueKeyMismatch
+}
+
+func (i Issue) String() string {
+	if i.Detail == "" {
+		return fmt.Sprintf("%s bucket=%s key=%q", i.Kind, i.Bucket, i.Key)
+	}
+	return fmt.Sprintf("%s bucket=%s key=%q: %s", i.Kind, i.Bucket, i.Key, i.Detail)
+}
+
+// Checker is the api for checking the integrity of the whole database
+type Checker interface {
+	Check(ctx context.Context) ([]Issue, error)
+	Quarantine(ctx context.Context, issues []Issue) (int, error)
+}
+
+type boltChecker struct {
+	db     *bolt.DB
+	census *census.Census
+}
+
+// NewBoltChecker constructs a boltDB-backed Checker
+func NewBoltChecker(db *bolt.DB, c *census.Census) Checker {
+	return &boltChecker{
+		db:     db,
+		census: c,
+	}
+}
+
+// Check walks every bucket and key and reports the problems it finds
+func (b *boltChecker) Check(ctx context.Context) ([]Issue, error) {
+	_, span := b.census.StartSpan(ctx, "boltChecker.Check")
+	defer span.End()
+
+	var issues []Issue
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(bucketName []byte, bucket *bolt.Bucket) error {
+			if !isTrialBucket(bucketName) {
+				if bytes.Equal(bucketName, settingsBucket) {
+					issues = append(issues, checkGuildRecords(bucket)...)
+				}
+				return nil
+			}
+
+			return bucket.ForEach(func(k, v []byte) error {
+				issues = append(issues, checkTrialRecord(string(bucketName), string(k), v)...)
+				return nil
+			})
+		})
+	})
+
+	return issues, err
+}
+
+func checkGuildRecords(bucket *bolt.Bucket) []Issue {
+	var issues []Issue
+
+	_ = bucket.ForEach(func(k, v []byte) error {
+		protoGuild := ProtoGuild{}
+		if err := proto.Unmarshal(v, &protoGuild); err != nil {
+			issues = append(issues, Issue{Bucket: string(settingsBucket), Key: string(k), Kind: IssueCorrupt, Detail: err.Error()})
+		}
+		return nil
+	})
+
+	return issues
+}
+
+func checkTrialRecord(gid, key string, v []byte) []Issue {
+	protoTrial := ProtoTrial{}
+	if err := proto.Unmarshal(v, &protoTrial); err != nil {
+		return []Issue{{Bucket: gid, Key: key, Kind: IssueCorrupt, Detail: err.Error()}}
+	}
+
+	var issues []Issue
+
+	if key != strings.ToLower(protoTrial.Name) {
+		issues = append(issues, Issue{Bucket: gid, Key: key, Kind: IssueKeyMismatch, Detail: fmt.Sprintf("record name is %q", protoTrial.Name)})
+	}
+
+	roles := map[string]bool{}
+	if protoTrial.RoleCountMap != nil {
+		for k := range protoTrial.RoleCountMap {
+			roles[k] = true
+		}
+	} else {
+		for k := range protoTrial.RoleCounts {
+			roles[strings.ToLower(k)] = true
+		}
+	}
+
+	seen := map[string]int{}
+	missing := map[string]bool{}
+	for _, su := range protoTrial.Signups {
+		if su.State == signupCanceled {
+			continue
+		}
+
+		seen[userMentionOverflowFix(su.Name)]++
+
+		lowerRole := strings.ToLower(su.Role)
+		if !roles[lowerRole] && !missing[lowerRole] {
+			missing[lowerRole] = true
+			issues = append(issues, Issue{Bucket: gid, Key: key, Kind: IssueMissingRole, Detail: fmt.Sprintf("role %q has signups but no count", su.Role)})
+		}
+	}
+
+	for _, su := range protoTrial.Signups {
+		name := userMentionOverflowFix(su.Name)
+		if su.State == signupCanceled || seen[name] < 2 {
+			continue
+		}
+
+		issues = append(issues, Issue{Bucket: gid, Key: key, Kind: IssueDuplicateSignup, Detail: fmt.Sprintf("%s is signed up %d times", name, seen[name])})
+		seen[name] = 0 // only report each user once
+	}
+
+	return issues
+}
+
+// Quarantine moves the records behind quarantinable issues into a separate bucket,
+// keyed by their original bucket, and returns how many were moved
+func (b *boltChecker) Quarantine(ctx context.Context, issues []Issue) (int, error) {
+	_, span := b.census.StartSpan(ctx, "boltChecker.Quarantine")
+	defer span.End()
+
+	moved := 0
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		qBucket, err := tx.CreateBucketIfNotExists(quarantineBucket)
+		if err != nil {
+			return errors.Wrap(err, "could not create quarantine bucket")
+		}
+
+		for _, i := range issues {
+			if !i.Quarantinable() {
+				continue
+			}
+
+			src := tx.Bucket([]byte(i.Bucket))
+			if src == nil {
+				continue
+			}
+
+			v := src.Get([]byte(i.Key))
+			if v == nil {
+				continue // already moved (a record can have more than one issue)
+			}
+
+			dst, err := qBucket.CreateBucketIfNotExists([]byte(i.Bucket))
+			if err != nil {
+				return errors.Wrap(err, "could not create quarantine bucket", "bucket", i.Bucket)
+			}
+
+			// v is only valid for the life of the transaction, and Put may not copy it before Delete
+			val := make([]byte, len(v))
+			copy(val, v)
+
+			if err := dst.Put([]byte(i.Key), val); err != nil {
+				return errors.Wrap(err, "could not quarantine record", "bucket", i.Bucket, "key", i.Key)
+			}
+
+			if err := src.Delete([]byte(i.Key)); err != nil {
+				return errors.Wrap(err, "could not remove quarantined record", "bucket", i.Bucket, "key", i.Key)
+			}
+
+			moved++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
@@ -4,47 +4,45 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v5/errors"
 	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 // ErrBadSetting is the error returned if an unknown setting is accessed
-var ErrBadSetting = errors.New("bad setting")
+var ErrBadSetting = i18n.NewError("err.bad_setting")
 
 // GuildSettings is the set of configuration settings for a guild
+//
+// Channels are kept both by name, for display, and by id once they have been checked
+// against the guild; the ids are empty for channels set before that was done. AdminRole
+// is a role id, and AdminRoleName its name when it was set.
 type GuildSettings struct {
 	census            *census.Census
 	ControlSequence   string
 	AnnounceChannel   string
+	AnnounceChannelID string
 	SignupChannel     string
+	SignupChannelID   string
 	AdminChannel      string
+	AdminChannelID    string
 	AnnounceTo        string
 	ShowAfterSignup   string
 	ShowAfterWithdraw string
 	AdminRole         string
+	AdminRoleName     string
+	Locale            string
 }
 
-// PrettyString returns a multi-line string describing the settings
-func (s *GuildSettings) PrettyString(ctx context.Context) string {
+// PrettyString returns a multi-line string describing the settings, in the locale of the printer
+func (s *GuildSettings) PrettyString(ctx context.Context, p i18n.Printer) string {
 	_, span := s.census.StartSpan(ctx, "GuildSettings.PrettyString")
 	defer span.End()
 
-	return fmt.Sprintf(`
-GuildSettings:
-
-	- ControlSequence: '%[2]s',
-	- AnnounceChannel: '#%[3]s',
-	- SignupChannel: '#%[4]s',
-	- AdminChannel: '#%[5]s',
-	- AnnounceTo: '%[6]s', 
-	- ShowAfterSignup: '%[7]s',
-	- ShowAfterWithdraw: '%[8]s',
-	- AdminRole: '<@&%[9]s>',
-
-	`, "```", s.ControlSequence, s.AnnounceChannel, s.SignupChannel, s.AdminChannel, s.AnnounceTo, s.ShowAfterSignup, s.ShowAfterWithdraw, s.AdminRole)
+	return p.Sprintf("settings.guild", "```", s.ControlSequence, s.AnnounceChannel, s.SignupChannel, s.AdminChannel, s.AnnounceTo, s.ShowAfterSignup, s.ShowAfterWithdraw, s.AdminRole, i18n.For(s.Locale).Locale())
 }
 
 // GetSettingString gets the value of a setting
@@ -69,6 +67,8 @@ func (s *GuildSettings) GetSettingString(ctx context.Context, name string) (stri
 		return s.ShowAfterWithdraw, nil
 	case "adminrole":
 		return s.AdminRole, nil
+	case "locale":
+		return i18n.For(s.Locale).Locale(), nil
 	default:
 		return "", ErrBadSetting
 	}
@@ -81,7 +81,7 @@ func normalizeTrueFalseString(val string) (string, error) {
 	case "", "no", "false", "not ok", "0", "-", "f", "off":
 		return "false", nil
 	default:
-		return val, errors.New("could not understand option value")
+		return val, i18n.NewError("err.bad_option_value")
 	}
 }
 
@@ -94,15 +94,8 @@ func (s *GuildSettings) SetSettingString(ctx context.Context, name, val string)
 	case "controlsequence":
 		s.ControlSequence = val
 		return nil
-	case "announcechannel":
-		s.AnnounceChannel = strings.TrimLeft(val, "#")
-		return nil
-	case "adminchannel":
-		s.AdminChannel = strings.TrimLeft(val, "#")
-		return nil
-	case "signupchannel":
-		s.SignupChannel = strings.TrimLeft(val, "#")
-		return nil
+	case "announcechannel", "adminchannel", "signupchannel":
+		return s.SetChannel(ctx, name, val, "")
 	case "announceto":
 		s.AnnounceTo = val
 		return nil
@@ -123,9 +116,67 @@ func (s *GuildSettings) SetSettingString(ctx context.Context, name, val string)
 	case "adminrole":
 		s.AdminRole = val
 		return nil
+	case "locale":
+		v, ok := i18n.ParseLocale(val)
+		if !ok {
+			return i18n.NewError("err.bad_locale", val, strings.Join(i18n.Locales(), ", "))
+		}
+		s.Locale = v
+		return nil
+	default:
+		return ErrBadSetting
+	}
+}
+
+// SetChannel sets a channel setting to the channel with the given name and id; an empty
+// id leaves the channel to be found by name
+func (s *GuildSettings) SetChannel(ctx context.Context, name, channelName, channelID string) error {
+	_, span := s.census.StartSpan(ctx, "GuildSettings.SetChannel")
+	defer span.End()
+
+	channelName = strings.TrimLeft(channelName, "#")
+
+	switch strings.ToLower(name) {
+	case "announcechannel":
+		s.AnnounceChannel, s.AnnounceChannelID = channelName, channelID
+	case "adminchannel":
+		s.AdminChannel, s.AdminChannelID = channelName, channelID
+	case "signupchannel":
+		s.SignupChannel, s.SignupChannelID = channelName, channelID
 	default:
 		return ErrBadSetting
 	}
+
+	return nil
+}
+
+// Webhook is an outbound webhook registered for a guild
+//
+// Events is the list of event types delivered to the URL, and Secret is the key
+// used to sign the payloads
+type Webhook struct {
+	URL    string
+	Events []string
+	Secret string
+}
+
+// Wants reports whether the webhook should receive events of the given type
+func (w Webhook) Wants(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Preset is a named role layout that new events can be created from
+//
+// Roles is in the same format as the roles setting of a trial
+type Preset struct {
+	Name        string
+	Description string
+	Roles       string
 }
 
 // GuildAPI is the api for managing guild settings transactions
@@ -148,9 +199,17 @@ type GuildAPITx interface {
 type Guild interface {
 	GetName(ctx context.Context) string
 	GetSettings(ctx context.Context) GuildSettings
+	GetAPITokenHash(ctx context.Context) string
+	GetWebhooks(ctx context.Context) []Webhook
+	GetPresets(ctx context.Context) []Preset
+	GetCalendarKey(ctx context.Context) string
 
 	SetName(ctx context.Context, name string)
 	SetSettings(ctx context.Context, s GuildSettings)
+	SetAPITokenHash(ctx context.Context, hash string)
+	SetWebhooks(ctx context.Context, hooks []Webhook)
+	SetPresets(ctx context.Context, presets []Preset)
+	SetCalendarKey(ctx context.Context, key string)
 
 	Serialize(ctx context.Context) ([]byte, error)
 }
@@ -10,6 +10,8 @@ import (
 
 	"github.com/gsmcwhirter/discord-bot-lib/v12/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 // GetSettings is a wrapper to get the configuration settings for a guild
@@ -30,6 +32,83 @@ func GetSettings(ctx context.Context, gapi GuildAPI, gid snowflake.Snowflake) (G
 	return bGuild.GetSettings(ctx), nil
 }
 
+// GetPrinter is a wrapper to get the message printer for the locale of a guild; guilds
+// whose settings cannot be read get the default locale
+//
+// NOTE: this cannot be called after another transaction has been started
+func GetPrinter(ctx context.Context, gapi GuildAPI, gid snowflake.Snowflake) i18n.Printer {
+	s, err := GetSettings(ctx, gapi, gid)
+	if err != nil {
+		return i18n.For(i18n.DefaultLocale)
+	}
+
+	return i18n.For(s.Locale)
+}
+
+// GetWebhooks is a wrapper to get the outbound webhooks for a guild
+//
+// NOTE: this cannot be called after another transaction has been started
+func GetWebhooks(ctx context.Context, gapi GuildAPI, gid string) ([]Webhook, error) {
+	t, err := gapi.NewTransaction(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	bGuild, err := t.GetGuild(ctx, gid)
+	if err == ErrGuildNotExist {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find guild")
+	}
+
+	return bGuild.GetWebhooks(ctx), nil
+}
+
+// GetPresets is a wrapper to get the role layout presets a guild has defined
+//
+// NOTE: this cannot be called after another transaction has been started
+func GetPresets(ctx context.Context, gapi GuildAPI, gid string) ([]Preset, error) {
+	t, err := gapi.NewTransaction(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	bGuild, err := t.GetGuild(ctx, gid)
+	if err == ErrGuildNotExist {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find guild")
+	}
+
+	return bGuild.GetPresets(ctx), nil
+}
+
+// GetCalendarKey is a wrapper to get the key that calendar feed urls for a guild are derived from,
+// which is empty if the guild has never had one generated
+//
+// NOTE: this cannot be called after another transaction has been started
+func GetCalendarKey(ctx context.Context, gapi GuildAPI, gid string) (string, error) {
+	t, err := gapi.NewTransaction(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	bGuild, err := t.GetGuild(ctx, gid)
+	if err == ErrGuildNotExist {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "unable to find guild")
+	}
+
+	return bGuild.GetCalendarKey(ctx), nil
+}
+
 func userMentionOverflowFix(userMention string) string {
 	if !strings.HasPrefix(userMention, "<@!-") {
 		return userMention
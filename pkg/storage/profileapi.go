@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/go-util/v5/deferutil"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/snowflake"
+)
+
+//go:generate protoc --go_out=. --proto_path=. ./profileapi.proto
+
+// Profile is what a member usually signs up with in a guild, and which notices they get
+type Profile struct {
+	Role      string
+	Character string
+	Class     string
+	Notify    NotifyPreference
+}
+
+// NotifyPreference is which notices a member gets by direct message
+type NotifyPreference string
+
+// Notify preferences; an empty preference is off, so members opt in
+const (
+	NotifyOff        NotifyPreference = "off"
+	NotifyOn         NotifyPreference = "on"
+	NotifyPromotions NotifyPreference = "promotions-only"
+)
+
+// IsZero reports whether nothing is set in the profile
+func (p Profile) IsZero() bool {
+	return p == Profile{}
+}
+
+// ProfileAPI is the api for managing member profiles transactions
+type ProfileAPI interface {
+	NewTransaction(ctx context.Context, guild string, writable bool) (ProfileAPITx, error)
+}
+
+// ProfileAPITx is the api for managing the member profiles of a guild within a transaction
+type ProfileAPITx interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+
+	// GetProfile returns the zero Profile for members without one
+	GetProfile(ctx context.Context, user string) (Profile, error)
+	SaveProfile(ctx context.Context, user string, p Profile) error
+	DeleteProfile(ctx context.Context, user string) error
+}
+
+// GetProfile is a wrapper to get the profile of a member of a guild
+//
+// NOTE: this cannot be called after another transaction has been started
+func GetProfile(ctx context.Context, papi ProfileAPI, gid, uid snowflake.Snowflake) (Profile, error) {
+	t, err := papi.NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	return t.GetProfile(ctx, uid.ToString())
+}
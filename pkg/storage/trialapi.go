@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 //go:generate protoc --go_out=. --proto_path=. ./trialapi.proto
@@ -11,8 +14,20 @@ type TrialState string
 
 // State Constants
 const (
-	TrialStateOpen   = "open"
-	TrialStateClosed = "closed"
+	TrialStateOpen     = "open"
+	TrialStateClosed   = "closed"
+	TrialStateCanceled = "canceled"
+)
+
+// RosterPolicy is how the signups of a trial are ordered when a role has more signups than slots
+type RosterPolicy string
+
+// Roster policies; an empty policy is first-come
+const (
+	RosterFirstCome  RosterPolicy = "first-come"
+	RosterTiers      RosterPolicy = "tiers"
+	RosterAttendance RosterPolicy = "attendance"
+	RosterLottery    RosterPolicy = "lottery"
 )
 
 // TrialAPI is the API for managing trials transactions
@@ -31,6 +46,8 @@ type TrialAPITx interface {
 	DeleteTrial(ctx context.Context, name string) error
 
 	GetTrials(ctx context.Context) []Trial
+	GetCorruptTrialKeys(ctx context.Context) []string
+	DeleteTrialKey(ctx context.Context, key string) error
 }
 
 // Trial is the api for managing a particular trial
@@ -40,20 +57,54 @@ type Trial interface {
 	GetAnnounceTo(ctx context.Context) string
 	GetAnnounceChannel(ctx context.Context) string
 	GetSignupChannel(ctx context.Context) string
+	GetAnnounceChannelID(ctx context.Context) string
+	GetSignupChannelID(ctx context.Context) string
 	GetState(ctx context.Context) TrialState
+	GetCreatedAt(ctx context.Context) time.Time
+	GetStateChangedAt(ctx context.Context) time.Time
+	GetStartTime(ctx context.Context) time.Time
+	GetDuration(ctx context.Context) time.Duration
+	GetReserveRelease(ctx context.Context) time.Duration
+	GetRosterPolicy(ctx context.Context) RosterPolicy
+	GetPriorityRoles(ctx context.Context) []string
+	GetLotterySeed(ctx context.Context) int64
+	GetRosterOrder(ctx context.Context) []string
+	GetLockedRoster(ctx context.Context) LockedRoster
+	GetRemindedAt(ctx context.Context) time.Time
+	GetCancelReason(ctx context.Context) string
+	GetRescheduledAt(ctx context.Context) time.Time
 	GetSignups(ctx context.Context) []TrialSignup
+	GetSignupHistory(ctx context.Context) []TrialSignup
 	GetRoleCounts(ctx context.Context) []RoleCount
-	PrettySettings(ctx context.Context) string
+	PrettySettings(ctx context.Context, p i18n.Printer) string
 
 	SetName(ctx context.Context, name string)
 	SetDescription(ctx context.Context, d string)
 	SetAnnounceTo(ctx context.Context, val string)
 	SetAnnounceChannel(ctx context.Context, val string)
 	SetSignupChannel(ctx context.Context, val string)
+	SetAnnounceChannelID(ctx context.Context, val string)
+	SetSignupChannelID(ctx context.Context, val string)
 	SetState(ctx context.Context, state TrialState)
+	SetCreatedAt(ctx context.Context, t time.Time)
+	SetStateChangedAt(ctx context.Context, t time.Time)
+	SetStartTime(ctx context.Context, t time.Time)
+	SetDuration(ctx context.Context, d time.Duration)
+	SetReserveRelease(ctx context.Context, d time.Duration)
+	SetRosterPolicy(ctx context.Context, policy RosterPolicy)
+	SetPriorityRoles(ctx context.Context, roleIDs []string)
+	SetLotterySeed(ctx context.Context, seed int64)
+	SetRosterOrder(ctx context.Context, names []string)
+	SetLockedRoster(ctx context.Context, lr LockedRoster)
+	SetRemindedAt(ctx context.Context, t time.Time)
+	SetCancelReason(ctx context.Context, reason string)
+	SetRescheduledAt(ctx context.Context, t time.Time)
 	AddSignup(ctx context.Context, name, role string)
+	SetSignupDetails(ctx context.Context, name string, details SignupDetails)
 	RemoveSignup(ctx context.Context, name string)
 	SetRoleCount(ctx context.Context, name, emoji string, ct uint64)
+	SetRoleAliases(ctx context.Context, name string, aliases []string)
+	SetRoleReservation(ctx context.Context, name string, res Reservation)
 	RemoveRole(ctx context.Context, name string)
 
 	ClearSignups(ctx context.Context)
@@ -65,6 +116,20 @@ type Trial interface {
 type TrialSignup interface {
 	GetName(ctx context.Context) string
 	GetRole(ctx context.Context) string
+	IsCanceled(ctx context.Context) bool
+	GetDetails(ctx context.Context) SignupDetails
+}
+
+// SignupDetails are the optional details a member gives about what they bring to a signup
+type SignupDetails struct {
+	Character string
+	Class     string
+	Note      string
+}
+
+// IsZero reports whether no details were given
+func (d SignupDetails) IsZero() bool {
+	return d == SignupDetails{}
 }
 
 // RoleCount is the api for managing a role in a trial
@@ -72,4 +137,56 @@ type RoleCount interface {
 	GetRole(ctx context.Context) string
 	GetCount(ctx context.Context) uint64
 	GetEmoji(ctx context.Context) string
+	GetAliases(ctx context.Context) []string
+	GetReservation(ctx context.Context) Reservation
+}
+
+// Reservation is the slots of a role that are held for some members until the trial
+// releases them
+//
+// Users are user mentions, as signups are named, and RoleID is the id of a discord role
+// whose members may also claim the slots
+type Reservation struct {
+	Slots  uint64
+	Users  []string
+	RoleID string
+}
+
+// IsZero reports whether no slots are reserved
+func (r Reservation) IsZero() bool {
+	return r.Slots == 0
+}
+
+// LockedRoster is the main group and overflow of each role as they were when the roster was
+// locked, for the main group to confirm by the deadline
+//
+// Settled is set once the members promoted at the deadline have been told
+type LockedRoster struct {
+	LockedAt  time.Time
+	Deadline  time.Time
+	Signups   []LockedSignup
+	Confirmed []string
+	Settled   bool
+}
+
+// LockedSignup is a member of a locked roster, in roster order
+type LockedSignup struct {
+	Name     string
+	Role     string
+	Overflow bool
+}
+
+// IsZero reports whether the roster is not locked
+func (lr LockedRoster) IsZero() bool {
+	return lr.LockedAt.IsZero()
+}
+
+// HasConfirmed reports whether a member of the main group has confirmed
+func (lr LockedRoster) HasConfirmed(name string) bool {
+	for _, c := range lr.Confirmed {
+		if c == name {
+			return true
+		}
+	}
+	return false
 }
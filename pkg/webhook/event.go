@@ -0,0 +1,126 @@
+package webhook
+
+//go:generate easyjson event.go
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// Event types that a webhook can subscribe to
+const (
+	EventSignup     = "signup"
+	EventWithdraw   = "withdraw"
+	EventOpen       = "open"
+	EventClose      = "close"
+	EventCreate     = "create"
+	EventDelete     = "delete"
+	EventCancel     = "cancel"
+	EventReschedule = "reschedule"
+)
+
+// Events is the list of every event type, in the order they are documented
+var Events = []string{EventSignup, EventWithdraw, EventOpen, EventClose, EventCreate, EventDelete, EventCancel, EventReschedule}
+
+// ActorAPI is the Event Actor for changes made through the http api
+const ActorAPI = "api"
+
+// SignatureHeader is the request header holding the payload signature
+const SignatureHeader = "X-Signup-Signature"
+
+// ParseEvents converts a comma-separated list of event types into a de-duplicated list
+//
+// An empty value or "all" selects every event type
+func ParseEvents(val string) ([]string, error) {
+	val = strings.ToLower(strings.TrimSpace(val))
+	if val == "" || val == "all" {
+		return append([]string(nil), Events...), nil
+	}
+
+	seen := map[string]bool{}
+	events := make([]string, 0, len(Events))
+	for _, e := range strings.Split(val, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+
+		if !knownEvent(e) {
+			return nil, fmt.Errorf("unknown event '%s' (need %s)", e, strings.Join(Events, ", "))
+		}
+
+		seen[e] = true
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func knownEvent(e string) bool {
+	for _, k := range Events {
+		if k == e {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret generates a random secret for signing a webhook's payloads
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate secret")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Sign computes the value of the SignatureHeader for a payload
+//
+// Receivers should compute the HMAC-SHA256 of the raw request body with the webhook
+// secret and compare it to the header value
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint: errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Event is the json payload describing a change to an event
+//
+// ID and Timestamp are filled in by Notify. Users lists the user mentions affected
+// by a signup or withdraw, or the signups told of a cancel or reschedule, and Overflow is
+// the subset of them that landed in overflow. Reason is why an event was canceled, and
+// Start is the new start of a rescheduled event in RFC3339. Actor is the mention of
+// whoever made the change, or ActorAPI for the http api.
+//
+//easyjson:json
+type Event struct {
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	Timestamp string   `json:"timestamp"`
+	GuildID   string   `json:"guild_id"`
+	Trial     string   `json:"trial"`
+	State     string   `json:"state,omitempty"`
+	Role      string   `json:"role,omitempty"`
+	Users     []string `json:"users,omitempty"`
+	Overflow  []string `json:"overflow,omitempty"`
+	Reason    string   `json:"reason,omitempty"`
+	Start     string   `json:"start,omitempty"`
+	Actor     string   `json:"actor,omitempty"`
+}
+
+// DeadLetter is a line of the dead-letter log, written for each delivery that gave up
+//
+//easyjson:json
+type DeadLetter struct {
+	URL      string `json:"url"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+	FailedAt string `json:"failed_at"`
+	Event    Event  `json:"event"`
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+)
+
+// ErrInsecureURL is the error returned for a webhook url that is not an https url naming a host
+var ErrInsecureURL = errors.New("webhook url must be an https url with a host name")
+
+// ErrAddressHost is the error returned for a webhook url with an ip address as its host;
+// only host names are accepted, whether the address is public or not
+var ErrAddressHost = errors.New("webhook url must name a host rather than an ip address")
+
+// ErrForbiddenAddress is the error returned for a webhook host that is, or resolves to, a
+// loopback, private, or link-local address, which would let a guild admin probe the
+// network the bot runs in
+var ErrForbiddenAddress = errors.New("webhook host is a private or local address")
+
+// privateNets are the ranges net.IP has no predicate for in go1.12
+var privateNets = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10", // carrier-grade nat
+	"fc00::/7",      // unique local
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// PublicIP reports whether a webhook may be delivered to an address
+func PublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckURL parses a webhook url, requiring https and a host name rather than an address
+func CheckURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse webhook url")
+	}
+
+	host := u.Hostname()
+	if u.Scheme != "https" || host == "" {
+		return nil, ErrInsecureURL
+	}
+
+	if net.ParseIP(host) != nil {
+		return nil, ErrAddressHost
+	}
+
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return nil, ErrForbiddenAddress
+	}
+
+	return u, nil
+}
+
+// CheckHost resolves a webhook host, failing if any of its addresses is not public
+func CheckHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve webhook host", "host", host)
+	}
+
+	for _, a := range addrs {
+		if !PublicIP(a.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+
+	return nil
+}
+
+// dialControl refuses connections to addresses that are not public; it runs after name
+// resolution, so it also covers redirects and hosts that resolve differently over time
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "bad dial address")
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !PublicIP(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// forbiddenDial reports whether a request failed because dialControl refused the address,
+// which retrying cannot fix
+func forbiddenDial(err error) bool {
+	if ue, ok := err.(*url.Error); ok {
+		err = ue.Err
+	}
+	if oe, ok := err.(*net.OpError); ok {
+		err = oe.Err
+	}
+	return err == ErrForbiddenAddress
+}
+
+// newGuardedClient is the http client deliveries are made with
+func newGuardedClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,290 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v5/errors"
+	"github.com/gsmcwhirter/go-util/v5/logging/level"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/httpclient"
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// ErrQueueFull is the error recorded when an event is dropped because deliveries are backed up
+var ErrQueueFull = errors.New("webhook queue is full")
+
+type dependencies interface {
+	Logger() logging.Logger
+	GuildAPI() storage.GuildAPI
+	Census() *census.Census
+}
+
+// Options provides a way to pass configuration to NewDispatcher
+//
+// - QueueSize is the number of events that can wait for delivery (defaults to 100)
+// - Attempts is the number of tries for each delivery before giving up (defaults to 5)
+// - Backoff is the wait before the first retry, doubling after each one (defaults to 1s)
+// - MaxBackoff caps the wait between retries (defaults to 1m)
+// - Timeout limits each request (defaults to 10s)
+// - DeadLetterFile is where failed deliveries are appended as json lines (empty only logs them)
+type Options struct {
+	QueueSize      int
+	Attempts       int
+	Backoff        time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+	DeadLetterFile string
+}
+
+// Dispatcher is the api for delivering events to the webhooks registered for a guild
+type Dispatcher interface {
+	Notify(ctx context.Context, ev Event)
+	Run(ctx context.Context) error
+}
+
+type dispatcher struct {
+	deps           dependencies
+	queue          chan Event
+	attempts       int
+	backoff        time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+	deadLetterFile string
+
+	deadLetterLock *sync.Mutex
+	now            func() time.Time
+
+	// client refuses to connect to addresses that are not public, and checkURL is applied
+	// to each url again before delivery, since webhooks may predate the checks
+	client   httpclient.Doer
+	checkURL func(string) (*url.URL, error)
+}
+
+// NewDispatcher creates a new Dispatcher object
+func NewDispatcher(deps dependencies, opts Options) Dispatcher {
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+
+	d := &dispatcher{
+		deps:           deps,
+		queue:          make(chan Event, queueSize),
+		attempts:       opts.Attempts,
+		backoff:        opts.Backoff,
+		maxBackoff:     opts.MaxBackoff,
+		timeout:        opts.Timeout,
+		deadLetterFile: opts.DeadLetterFile,
+		deadLetterLock: &sync.Mutex{},
+		now:            time.Now,
+		client:         newGuardedClient(),
+		checkURL:       CheckURL,
+	}
+
+	if d.attempts <= 0 {
+		d.attempts = 5
+	}
+	if d.backoff <= 0 {
+		d.backoff = time.Second
+	}
+	if d.maxBackoff <= 0 {
+		d.maxBackoff = time.Minute
+	}
+	if d.timeout <= 0 {
+		d.timeout = 10 * time.Second
+	}
+
+	return d
+}
+
+// Notify queues an event for delivery without waiting for it to be sent
+//
+// It should be called only after the change has been committed
+func (d *dispatcher) Notify(ctx context.Context, ev Event) {
+	if ev.ID == "" {
+		ev.ID = newDeliveryID()
+	}
+	if ev.Timestamp == "" {
+		ev.Timestamp = d.now().UTC().Format(time.RFC3339)
+	}
+
+	select {
+	case d.queue <- ev:
+	default:
+		d.deadLetter(ctx, "", 0, ev, ErrQueueFull)
+	}
+}
+
+// Run delivers queued events until the context is canceled
+func (d *dispatcher) Run(ctx context.Context) error {
+	level.Info(d.deps.Logger()).Message("starting webhook dispatcher", "attempts", d.attempts, "dead_letter_file", d.deadLetterFile)
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(d.deps.Logger()).Message("stopping webhook dispatcher")
+			return ctx.Err()
+		case ev := <-d.queue:
+			d.dispatch(ctx, wg, ev)
+		}
+	}
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, wg *sync.WaitGroup, ev Event) {
+	ctx, span := d.deps.Census().StartSpan(ctx, "dispatcher.dispatch", "guild_id", ev.GuildID)
+	defer span.End()
+
+	hooks, err := storage.GetWebhooks(ctx, d.deps.GuildAPI(), ev.GuildID)
+	if err != nil {
+		d.deadLetter(ctx, "", 0, ev, errors.Wrap(err, "could not load webhooks"))
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.Wants(ev.Type) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(hook storage.Webhook) {
+			defer wg.Done()
+			d.deliver(ctx, hook, ev)
+		}(hook)
+	}
+}
+
+func (d *dispatcher) deliver(ctx context.Context, hook storage.Webhook, ev Event) {
+	logger := logging.WithContext(ctx, d.deps.Logger())
+
+	body, err := ev.MarshalJSON()
+	if err != nil {
+		d.deadLetter(ctx, hook.URL, 0, ev, errors.Wrap(err, "could not marshal event"))
+		return
+	}
+
+	wait := d.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(ctx, hook, ev, body)
+		if err == nil {
+			level.Debug(logger).Message("webhook delivered", "url", hook.URL, "event_id", ev.ID, "attempt", attempt)
+			return
+		}
+
+		if !retry || attempt >= d.attempts {
+			d.deadLetter(ctx, hook.URL, attempt, ev, err)
+			return
+		}
+
+		level.Info(logger).Message("webhook delivery failed; retrying", "url", hook.URL, "event_id", ev.ID, "attempt", attempt, "wait", wait.String(), "err", err)
+
+		select {
+		case <-ctx.Done():
+			d.deadLetter(ctx, hook.URL, attempt, ev, errors.Wrap(ctx.Err(), "gave up during shutdown"))
+			return
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+		if wait > d.maxBackoff {
+			wait = d.maxBackoff
+		}
+	}
+}
+
+// post makes a single delivery attempt, reporting whether a failure is worth retrying
+func (d *dispatcher) post(ctx context.Context, hook storage.Webhook, ev Event, body []byte) (bool, error) {
+	if _, err := d.checkURL(hook.URL); err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "could not create request")
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "discord-signup-bot-webhook")
+	req.Header.Set("X-Signup-Event", ev.Type)
+	req.Header.Set("X-Signup-Delivery", ev.ID)
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return !forbiddenDial(err), errors.Wrap(err, "could not complete request")
+	}
+	defer resp.Body.Close()                                  // nolint: errcheck
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096)) // nolint: errcheck
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+}
+
+func (d *dispatcher) deadLetter(ctx context.Context, url string, attempts int, ev Event, cause error) {
+	logger := logging.WithContext(ctx, d.deps.Logger())
+	level.Error(logger).Err("webhook delivery failed", cause, "url", url, "event_id", ev.ID, "event_type", ev.Type, "guild_id", ev.GuildID, "attempts", attempts)
+
+	if d.deadLetterFile == "" {
+		return
+	}
+
+	line, err := DeadLetter{
+		URL:      url,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		FailedAt: d.now().UTC().Format(time.RFC3339),
+		Event:    ev,
+	}.MarshalJSON()
+	if err != nil {
+		level.Error(logger).Err("could not marshal dead letter", err, "event_id", ev.ID)
+		return
+	}
+
+	d.deadLetterLock.Lock()
+	defer d.deadLetterLock.Unlock()
+
+	f, err := os.OpenFile(d.deadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		level.Error(logger).Err("could not open dead letter file", err, "file", d.deadLetterFile)
+		return
+	}
+	defer f.Close() // nolint: errcheck
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		level.Error(logger).Err("could not write dead letter", err, "file", d.deadLetterFile)
+	}
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
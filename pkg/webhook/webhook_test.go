@@ -0,0 +1,245 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/gsmcwhirter/go-util/v5/logging"
+	census "github.com/gsmcwhirter/go-util/v5/stats"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v12/logging"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Log(...interface{}) error { return nil }
+
+type testDeps struct{}
+
+func (testDeps) Logger() logging.Logger     { return log.NewFrom(nopLogger{}) }
+func (testDeps) GuildAPI() storage.GuildAPI { return nil }
+func (testDeps) Census() *census.Census     { return nil }
+
+// recorder is a webhook receiver answering with the statuses it is given in turn, then 200
+type recorder struct {
+	lock     sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+	times    []time.Time
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+
+	rec.bodies = append(rec.bodies, body)
+	rec.headers = append(rec.headers, r.Header)
+	rec.times = append(rec.times, time.Now())
+
+	status := http.StatusOK
+	if len(rec.statuses) > 0 {
+		status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// newTestDispatcher is a dispatcher allowed to deliver to the loopback address of srv
+func newTestDispatcher(srv *httptest.Server, opts Options) *dispatcher {
+	d := NewDispatcher(testDeps{}, opts).(*dispatcher)
+	d.client = srv.Client()
+	d.checkURL = url.Parse
+	return d
+}
+
+func testEvent() Event {
+	return Event{ID: "delivery-1", Type: EventSignup, GuildID: "1234", Trial: "vAA"}
+}
+
+func TestDeliverSignsPayload(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	d := newTestDispatcher(srv, Options{})
+	d.deliver(context.Background(), storage.Webhook{URL: srv.URL, Secret: "s3cret"}, testEvent())
+
+	if len(rec.bodies) != 1 {
+		t.Fatalf("got %d requests, want 1", len(rec.bodies))
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(rec.bodies[0]) // nolint: errcheck
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := rec.headers[0].Get(SignatureHeader); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+	if got := rec.headers[0].Get("X-Signup-Event"); got != EventSignup {
+		t.Errorf("X-Signup-Event = %q, want %q", got, EventSignup)
+	}
+	if got := rec.headers[0].Get("X-Signup-Delivery"); got != "delivery-1" {
+		t.Errorf("X-Signup-Delivery = %q, want %q", got, "delivery-1")
+	}
+}
+
+func TestDeliverRetriesServerErrors(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	backoff := 20 * time.Millisecond
+	d := newTestDispatcher(srv, Options{Attempts: 3, Backoff: backoff})
+	d.deliver(context.Background(), storage.Webhook{URL: srv.URL, Secret: "s3cret"}, testEvent())
+
+	if len(rec.times) != 3 {
+		t.Fatalf("got %d requests, want 3", len(rec.times))
+	}
+
+	if gap := rec.times[1].Sub(rec.times[0]); gap < backoff {
+		t.Errorf("first retry after %s, want at least %s", gap, backoff)
+	}
+	if gap := rec.times[2].Sub(rec.times[1]); gap < 2*backoff {
+		t.Errorf("second retry after %s, want at least %s", gap, 2*backoff)
+	}
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusBadRequest}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	d := newTestDispatcher(srv, Options{Attempts: 3, Backoff: time.Millisecond})
+	d.deliver(context.Background(), storage.Webhook{URL: srv.URL, Secret: "s3cret"}, testEvent())
+
+	if len(rec.times) != 1 {
+		t.Errorf("got %d requests, want 1", len(rec.times))
+	}
+}
+
+func TestDeliverDeadLettersAfterAttempts(t *testing.T) {
+	rec := &recorder{statuses: []int{500, 500, 500}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "webhook-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	deadLetterFile := filepath.Join(dir, "dead.jsonl")
+	d := newTestDispatcher(srv, Options{Attempts: 3, Backoff: time.Millisecond, DeadLetterFile: deadLetterFile})
+	d.deliver(context.Background(), storage.Webhook{URL: srv.URL, Secret: "s3cret"}, testEvent())
+
+	if len(rec.times) != 3 {
+		t.Fatalf("got %d requests, want 3", len(rec.times))
+	}
+
+	f, err := os.Open(deadLetterFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() // nolint: errcheck
+
+	var lines []DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var dl DeadLetter
+		if err := dl.UnmarshalJSON(scanner.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, dl)
+	}
+
+	if len(lines) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(lines))
+	}
+	if lines[0].URL != srv.URL || lines[0].Attempts != 3 || lines[0].Event.ID != "delivery-1" {
+		t.Errorf("got dead letter %+v, want url %s after 3 attempts for delivery-1", lines[0], srv.URL)
+	}
+}
+
+func TestDeliverRefusesLoopback(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	// the guarded client is kept, so the dial is refused even though the url is let through
+	d := NewDispatcher(testDeps{}, Options{Attempts: 3, Backoff: time.Millisecond}).(*dispatcher)
+	d.checkURL = url.Parse
+	d.deliver(context.Background(), storage.Webhook{URL: srv.URL, Secret: "s3cret"}, testEvent())
+
+	if len(rec.times) != 0 {
+		t.Errorf("got %d requests, want 0", len(rec.times))
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr error
+	}{
+		{"https://example.com/hook", nil},
+		{"https://example.com:8443/hook", nil},
+		{"http://example.com/hook", ErrInsecureURL},
+		{"https:///hook", ErrInsecureURL},
+		{"ftp://example.com/hook", ErrInsecureURL},
+		{"https://127.0.0.1/hook", ErrAddressHost},
+		{"https://93.184.216.34/hook", ErrAddressHost},
+		{"https://[::1]/hook", ErrAddressHost},
+		{"https://localhost/hook", ErrForbiddenAddress},
+		{"https://app.localhost/hook", ErrForbiddenAddress},
+	}
+
+	for _, tt := range tests {
+		if _, err := CheckURL(tt.url); err != tt.wantErr {
+			t.Errorf("CheckURL(%q) = %v, want %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"0.0.0.0", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"172.31.255.255", false},
+		{"172.32.0.1", true},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+	}
+
+	for _, tt := range tests {
+		if got := PublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("PublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}